@@ -0,0 +1,86 @@
+package invoiceninja
+
+import (
+	"fmt"
+	"math"
+)
+
+// AutoAllocate distributes a lump-sum payment of total across the request's
+// invoices in the order they are listed, which should be oldest first (e.g.
+// from Invoices.List with Sort "date|asc").
+//
+// On input, each entry's Amount is read as the invoice's outstanding balance.
+// Invoices are then paid in full while total lasts, the next one partially,
+// and invoices left with nothing are dropped from the request. Amount is set
+// to total; if total exceeds the listed balances, Invoice Ninja keeps the
+// excess as an unapplied amount on the client's account.
+//
+//	req := &invoiceninja.PaymentRequest{
+//		ClientID: clientID,
+//		Invoices: []invoiceninja.PaymentInvoice{
+//			{InvoiceID: "oldest", Amount: 100},
+//			{InvoiceID: "newer", Amount: 80},
+//		},
+//	}
+//	req.AutoAllocate(150) // oldest: 100, newer: 50
+func (r *PaymentRequest) AutoAllocate(total float64) {
+	r.Amount = total
+
+	remaining := total
+	var allocated []PaymentInvoice
+	for _, inv := range r.Invoices {
+		if remaining <= balanceTolerance {
+			break
+		}
+
+		amount := roundCents(math.Min(inv.Amount, remaining))
+		if amount <= 0 {
+			continue
+		}
+		remaining = roundCents(remaining - amount)
+		allocated = append(allocated, PaymentInvoice{InvoiceID: inv.InvoiceID, Amount: amount})
+	}
+	r.Invoices = allocated
+}
+
+// AllocationWarning describes how Invoice Ninja will treat a payment whose
+// Amount does not match the amounts applied to its invoices and credits, or
+// returns an empty string if they are consistent.
+//
+// Applying more than Amount is rejected by the server with a 422 response;
+// Validate reports that case as an error. Applying less is accepted, but the
+// remainder is left unapplied on the client's account, which is often not
+// what was intended. A zero Amount is computed by the server from the
+// applied amounts and is always consistent.
+func (r *PaymentRequest) AllocationWarning() string {
+	if r.Amount == 0 {
+		return ""
+	}
+
+	applied := r.appliedTotal()
+	switch {
+	case applied-r.Amount > balanceTolerance:
+		return fmt.Sprintf("payment amount %.2f is less than the %.2f applied to invoices; the server will reject it", r.Amount, applied)
+	case r.Amount-applied > balanceTolerance && len(r.Invoices) > 0:
+		return fmt.Sprintf("payment amount %.2f exceeds the %.2f applied to invoices; %.2f will remain unapplied on the client's account", r.Amount, applied, r.Amount-applied)
+	}
+	return ""
+}
+
+// appliedTotal returns the net amount the request applies to invoices: the
+// invoice amounts less the credits used towards them.
+func (r *PaymentRequest) appliedTotal() float64 {
+	var total float64
+	for _, inv := range r.Invoices {
+		total += inv.Amount
+	}
+	for _, credit := range r.Credits {
+		total -= credit.Amount
+	}
+	return roundCents(total)
+}
+
+// roundCents rounds an amount to two decimal places.
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,78 @@
+package invoiceninja
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPaymentRequestAutoAllocate(t *testing.T) {
+	tests := []struct {
+		name     string
+		total    float64
+		expected []PaymentInvoice
+	}{
+		{"partial", 150, []PaymentInvoice{{InvoiceID: "inv1", Amount: 100}, {InvoiceID: "inv2", Amount: 50}}},
+		{"exact", 210.5, []PaymentInvoice{{InvoiceID: "inv1", Amount: 100}, {InvoiceID: "inv2", Amount: 80}, {InvoiceID: "inv3", Amount: 30.5}}},
+		{"over", 250, []PaymentInvoice{{InvoiceID: "inv1", Amount: 100}, {InvoiceID: "inv2", Amount: 80}, {InvoiceID: "inv3", Amount: 30.5}}},
+		{"oldest only", 60.25, []PaymentInvoice{{InvoiceID: "inv1", Amount: 60.25}}},
+		{"nothing", 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			balances := []PaymentInvoice{
+				{InvoiceID: "inv1", Amount: 100},
+				{InvoiceID: "inv0", Amount: 0},
+				{InvoiceID: "inv2", Amount: 80},
+				{InvoiceID: "inv3", Amount: 30.5},
+			}
+			req := &PaymentRequest{ClientID: "c1", Invoices: balances}
+			req.AutoAllocate(tt.total)
+
+			if req.Amount != tt.total {
+				t.Errorf("expected amount %.2f, got %.2f", tt.total, req.Amount)
+			}
+			if len(req.Invoices) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, req.Invoices)
+			}
+			for i := range tt.expected {
+				if req.Invoices[i] != tt.expected[i] {
+					t.Errorf("invoice %d: expected %+v, got %+v", i, tt.expected[i], req.Invoices[i])
+				}
+			}
+			if balances[0].Amount != 100 || balances[1].InvoiceID != "inv0" {
+				t.Error("expected the original invoice slice to be left unchanged")
+			}
+			if err := req.Validate(); err != nil {
+				t.Errorf("expected allocated request to be valid, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPaymentRequestAllocationWarning(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      PaymentRequest
+		contains string
+	}{
+		{"consistent", PaymentRequest{Amount: 100, Invoices: []PaymentInvoice{{InvoiceID: "a", Amount: 60}, {InvoiceID: "b", Amount: 40}}}, ""},
+		{"rounding", PaymentRequest{Amount: 0.3, Invoices: []PaymentInvoice{{InvoiceID: "a", Amount: 0.1}, {InvoiceID: "b", Amount: 0.2}}}, ""},
+		{"computed amount", PaymentRequest{Invoices: []PaymentInvoice{{InvoiceID: "a", Amount: 60}}}, ""},
+		{"no invoices", PaymentRequest{Amount: 100}, ""},
+		{"over applied", PaymentRequest{Amount: 50, Invoices: []PaymentInvoice{{InvoiceID: "a", Amount: 60}}}, "reject"},
+		{"under applied", PaymentRequest{Amount: 100, Invoices: []PaymentInvoice{{InvoiceID: "a", Amount: 60}}}, "40.00 will remain unapplied"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warning := tt.req.AllocationWarning()
+			if tt.contains == "" && warning != "" {
+				t.Errorf("expected no warning, got %q", warning)
+			}
+			if tt.contains != "" && !strings.Contains(warning, tt.contains) {
+				t.Errorf("expected warning containing %q, got %q", tt.contains, warning)
+			}
+		})
+	}
+}
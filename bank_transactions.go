@@ -0,0 +1,253 @@
+package invoiceninja
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Bank transaction base types. Invoice Ninja records money received as
+// CREDIT (income) and money spent as DEBIT (expense).
+const (
+	BankTransactionIncome  = "CREDIT"
+	BankTransactionExpense = "DEBIT"
+)
+
+// BankTransactionsService handles bank transaction API operations used for
+// bank-feed reconciliation.
+type BankTransactionsService struct {
+	client *Client
+}
+
+// BankTransaction represents a transaction imported from a bank feed.
+type BankTransaction struct {
+	ID                string  `json:"id,omitempty"`
+	BankIntegrationID string  `json:"bank_integration_id,omitempty"`
+	TransactionID     int64   `json:"transaction_id,omitempty"`
+	BankAccountID     int64   `json:"bank_account_id,omitempty"`
+	Amount            float64 `json:"amount,omitempty"`
+	CurrencyID        string  `json:"currency_id,omitempty"`
+	AccountType       string  `json:"account_type,omitempty"`
+	Date              string  `json:"date,omitempty"`
+	Description       string  `json:"description,omitempty"`
+	BaseType          string  `json:"base_type,omitempty"`
+	CategoryID        int64   `json:"category_id,omitempty"`
+	NinjaCategoryID   string  `json:"ninja_category_id,omitempty"`
+	CategoryType      string  `json:"category_type,omitempty"`
+	StatusID          string  `json:"status_id,omitempty"`
+	InvoiceIDs        string  `json:"invoice_ids,omitempty"` // comma-separated
+	PaymentID         string  `json:"payment_id,omitempty"`
+	ExpenseID         string  `json:"expense_id,omitempty"`
+	VendorID          string  `json:"vendor_id,omitempty"`
+	Participant       string  `json:"participant,omitempty"`
+	ParticipantName   string  `json:"participant_name,omitempty"`
+	IsDeleted         bool    `json:"is_deleted,omitempty"`
+	CreatedAt         int64   `json:"created_at,omitempty"`
+	UpdatedAt         int64   `json:"updated_at,omitempty"`
+	ArchivedAt        int64   `json:"archived_at,omitempty"`
+}
+
+// BankTransactionMatch links a bank transaction to existing records.
+type BankTransactionMatch struct {
+	ID              string `json:"id"`
+	InvoiceIDs      string `json:"invoice_ids,omitempty"` // comma-separated
+	PaymentID       string `json:"payment_id,omitempty"`
+	ExpenseID       string `json:"expense_id,omitempty"`
+	VendorID        string `json:"vendor_id,omitempty"`
+	NinjaCategoryID string `json:"ninja_category_id,omitempty"`
+}
+
+// BankTransactionListOptions specifies the optional parameters for listing bank transactions.
+type BankTransactionListOptions struct {
+	PerPage int
+	Page    int
+	Filter  string
+
+	// Status filters by status (comma-separated: active, archived, deleted).
+	Status string
+
+	// IsDeleted filters by deleted status.
+	IsDeleted *bool
+
+	// ClientStatus filters by reconciliation state (comma-separated:
+	// unmatched, matched, converted, deposits, withdrawals).
+	ClientStatus string
+
+	Sort    string
+	Include string
+
+	// Filters are additional query filters built with NewFilter.
+	Filters []FilterExpr
+}
+
+// toQuery converts options to URL query parameters.
+func (o *BankTransactionListOptions) toQuery() url.Values {
+	if o == nil {
+		return nil
+	}
+
+	q := url.Values{}
+
+	if o.PerPage > 0 {
+		q.Set("per_page", strconv.Itoa(o.PerPage))
+	}
+	if o.Page > 0 {
+		q.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.Filter != "" {
+		q.Set("filter", o.Filter)
+	}
+	if o.Status != "" {
+		q.Set("status", o.Status)
+	}
+	if o.IsDeleted != nil {
+		q.Set("is_deleted", strconv.FormatBool(*o.IsDeleted))
+	}
+	if o.ClientStatus != "" {
+		q.Set("client_status", o.ClientStatus)
+	}
+	if o.Sort != "" {
+		q.Set("sort", o.Sort)
+	}
+	if o.Include != "" {
+		q.Set("include", o.Include)
+	}
+
+	applyFilters(q, o.Filters)
+
+	return q
+}
+
+// List retrieves a list of bank transactions.
+func (s *BankTransactionsService) List(ctx context.Context, opts *BankTransactionListOptions) (*ListResponse[BankTransaction], error) {
+	var resp ListResponse[BankTransaction]
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/bank_transactions"), opts.toQuery(), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Iter returns an iterator over all bank transactions matching opts, fetching pages lazily.
+// Paging starts at opts.Page (or page 1) and keeps every other option.
+func (s *BankTransactionsService) Iter(ctx context.Context, opts *BankTransactionListOptions) *Iterator[BankTransaction] {
+	return newIterator[BankTransaction](ctx, s.client, s.client.apiPath("/bank_transactions"), opts.toQuery())
+}
+
+// ListAll retrieves all bank transactions matching opts across every page.
+// If a page fails or ctx is done mid-scan, the transactions fetched so far are
+// returned together with the error.
+func (s *BankTransactionsService) ListAll(ctx context.Context, opts *BankTransactionListOptions) ([]BankTransaction, error) {
+	return listAll(s.Iter(ctx, opts))
+}
+
+// Count returns the number of bank transactions matching opts without fetching them,
+// from the pagination total of a one-item page. Paging options are ignored.
+func (s *BankTransactionsService) Count(ctx context.Context, opts *BankTransactionListOptions) (int, error) {
+	return count(ctx, s.client, s.client.apiPath("/bank_transactions"), opts.toQuery())
+}
+
+// Get retrieves a single bank transaction by ID.
+func (s *BankTransactionsService) Get(ctx context.Context, id string) (*BankTransaction, error) {
+	var resp SingleResponse[BankTransaction]
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/bank_transactions/%s", id), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Create creates a new bank transaction, e.g. from an imported bank CSV row.
+// The transaction must reference a bank integration through BankIntegrationID.
+func (s *BankTransactionsService) Create(ctx context.Context, txn *BankTransaction, opts ...RequestOption) (*BankTransaction, error) {
+	var resp SingleResponse[BankTransaction]
+	if err := s.client.doRequest(ctx, "POST", s.client.apiPath("/bank_transactions"), nil, txn, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Update updates an existing bank transaction.
+func (s *BankTransactionsService) Update(ctx context.Context, id string, txn *BankTransaction, opts ...RequestOption) (*BankTransaction, error) {
+	var resp SingleResponse[BankTransaction]
+	if err := s.client.doRequest(ctx, "PUT", s.client.apiPath("/bank_transactions/%s", id), nil, txn, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Delete deletes a bank transaction by ID.
+func (s *BankTransactionsService) Delete(ctx context.Context, id string, opts ...RequestOption) error {
+	return s.client.doRequest(ctx, "DELETE", s.client.apiPath("/bank_transactions/%s", id), nil, nil, nil, opts...)
+}
+
+// Archive archives a bank transaction.
+func (s *BankTransactionsService) Archive(ctx context.Context, id string) (*BankTransaction, error) {
+	return s.bulkAction(ctx, "archive", id)
+}
+
+// Restore restores an archived or deleted bank transaction.
+func (s *BankTransactionsService) Restore(ctx context.Context, id string) (*BankTransaction, error) {
+	return s.bulkAction(ctx, "restore", id)
+}
+
+// Bulk performs a bulk action on multiple bank transactions. Besides archive,
+// restore and delete, the API supports "convert_matched" and "unlink".
+func (s *BankTransactionsService) Bulk(ctx context.Context, action string, ids []string, opts ...RequestOption) ([]BankTransaction, error) {
+	req := BulkAction{
+		Action: action,
+		IDs:    ids,
+	}
+
+	return bulkRequest[BankTransaction](ctx, s.client, s.client.apiPath("/bank_transactions/bulk"), req, opts...)
+}
+
+// BulkTyped performs a bulk action on multiple bank transactions like Bulk,
+// but first checks that action is one of BulkArchive, BulkRestore, BulkDelete,
+// BulkConvertMatched and BulkUnlink, returning a *ValidationError otherwise.
+func (s *BankTransactionsService) BulkTyped(ctx context.Context, action BulkActionType, ids []string, opts ...RequestOption) ([]BankTransaction, error) {
+	if err := checkBulkAction("bank transactions", action, BulkConvertMatched, BulkUnlink); err != nil {
+		return nil, err
+	}
+	return s.Bulk(ctx, string(action), ids, opts...)
+}
+
+// bulkAction performs a single-item bulk action.
+func (s *BankTransactionsService) bulkAction(ctx context.Context, action, id string) (*BankTransaction, error) {
+	txns, err := s.Bulk(ctx, action, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	if len(txns) == 0 {
+		return nil, fmt.Errorf("%s bank transaction: %w", action, ErrEmptyBulkResponse)
+	}
+	return &txns[0], nil
+}
+
+// Match links bank transactions to existing invoices, payments or expenses
+// and returns the updated transactions.
+func (s *BankTransactionsService) Match(ctx context.Context, matches []BankTransactionMatch, opts ...RequestOption) ([]BankTransaction, error) {
+	req := struct {
+		Transactions []BankTransactionMatch `json:"transactions"`
+	}{Transactions: matches}
+
+	var resp ListResponse[BankTransaction]
+	if err := s.client.doRequest(ctx, "POST", s.client.apiPath("/bank_transactions/match"), nil, req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// MatchToPayment links a bank transaction to an existing payment, marking it
+// as matched (BankTransactionStatusMatched), and returns the updated transaction.
+func (s *BankTransactionsService) MatchToPayment(ctx context.Context, txnID, paymentID string, opts ...RequestOption) (*BankTransaction, error) {
+	txns, err := s.Match(ctx, []BankTransactionMatch{{ID: txnID, PaymentID: paymentID}}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for i := range txns {
+		if txns[i].ID == txnID {
+			return &txns[i], nil
+		}
+	}
+	return nil, fmt.Errorf("bank transaction %s not returned from match", txnID)
+}
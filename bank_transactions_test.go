@@ -0,0 +1,145 @@
+package invoiceninja
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBankTransactionsServiceList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("expected GET method, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/bank_transactions" {
+			t.Errorf("expected path /api/v1/bank_transactions, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("client_status") != "unmatched" {
+			t.Errorf("expected client_status unmatched, got %s", r.URL.Query().Get("client_status"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": [
+			{"id": "bt1", "amount": 150.5, "date": "2024-03-01", "description": "ACME payment", "base_type": "CREDIT", "bank_account_id": 12, "status_id": "1"},
+			{"id": "bt2", "amount": 20, "date": "2024-03-02", "description": "Bank fee", "base_type": "DEBIT", "category_id": 7, "status_id": "1"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	resp, err := client.BankTransactions.List(context.Background(), &BankTransactionListOptions{ClientStatus: "unmatched"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Data) != 2 {
+		t.Fatalf("expected 2 transactions, got %d", len(resp.Data))
+	}
+
+	txn := resp.Data[0]
+	if txn.Amount != 150.5 || txn.BaseType != BankTransactionIncome || txn.BankAccountID != 12 {
+		t.Errorf("unexpected first transaction: %+v", txn)
+	}
+	if txn.Status() != BankTransactionStatusUnmatched {
+		t.Errorf("expected unmatched status, got %s", txn.Status())
+	}
+	if resp.Data[1].BaseType != BankTransactionExpense || resp.Data[1].CategoryID != 7 {
+		t.Errorf("unexpected second transaction: %+v", resp.Data[1])
+	}
+}
+
+func TestBankTransactionsServiceCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/v1/bank_transactions" {
+			t.Errorf("expected POST /api/v1/bank_transactions, got %s %s", r.Method, r.URL.Path)
+		}
+
+		var txn BankTransaction
+		json.NewDecoder(r.Body).Decode(&txn)
+		if txn.BankIntegrationID != "bi1" || txn.Amount != 99.99 || txn.BaseType != "CREDIT" {
+			t.Errorf("unexpected transaction body: %+v", txn)
+		}
+
+		txn.ID = "bt1"
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SingleResponse[BankTransaction]{Data: txn})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	txn, err := client.BankTransactions.Create(context.Background(), &BankTransaction{
+		BankIntegrationID: "bi1",
+		Amount:            99.99,
+		Date:              "2024-03-01",
+		Description:       "Invoice 0042",
+		BaseType:          BankTransactionIncome,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if txn.ID != "bt1" {
+		t.Errorf("expected ID bt1, got %s", txn.ID)
+	}
+}
+
+func TestBankTransactionsServiceBulk(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/bank_transactions/bulk" {
+			t.Errorf("expected path /api/v1/bank_transactions/bulk, got %s", r.URL.Path)
+		}
+
+		var req BulkAction
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Action != "unlink" || len(req.IDs) != 2 {
+			t.Errorf("unexpected bulk request: %+v", req)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": [{"id": "bt1", "status_id": "1"}, {"id": "bt2", "status_id": "1"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	txns, err := client.BankTransactions.Bulk(context.Background(), "unlink", []string{"bt1", "bt2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(txns) != 2 {
+		t.Errorf("expected 2 transactions, got %d", len(txns))
+	}
+}
+
+func TestBankTransactionsServiceMatchToPayment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/v1/bank_transactions/match" {
+			t.Errorf("expected POST /api/v1/bank_transactions/match, got %s %s", r.Method, r.URL.Path)
+		}
+
+		var req struct {
+			Transactions []map[string]string `json:"transactions"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if len(req.Transactions) != 1 || req.Transactions[0]["id"] != "bt1" || req.Transactions[0]["payment_id"] != "pay1" {
+			t.Errorf("unexpected match request: %+v", req)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": [{"id": "bt1", "payment_id": "pay1", "status_id": "2"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	txn, err := client.BankTransactions.MatchToPayment(context.Background(), "bt1", "pay1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if txn.PaymentID != "pay1" || txn.Status() != BankTransactionStatusMatched {
+		t.Errorf("unexpected matched transaction: %+v", txn)
+	}
+}
@@ -0,0 +1,70 @@
+package invoiceninja
+
+import (
+	"context"
+	"sync"
+)
+
+// BatchOptions configures the CreateBatch methods.
+type BatchOptions struct {
+	// Concurrency is the number of creates in flight at once. Values below 2
+	// create the items one after another.
+	Concurrency int
+}
+
+// createBatch calls create for every item, on up to opts.Concurrency
+// goroutines. results[i] and errs[i] belong to items[i]; a failed item does
+// not stop the others. Once ctx is done, the items not yet started fail with
+// its error. Requests wait for the rate limiter of a RateLimitedClient but
+// are not retried, since creates are not idempotent.
+func createBatch[T, R any](ctx context.Context, c *Client, items []*T, opts *BatchOptions, create func(context.Context, *T) (*R, error)) ([]*R, []error) {
+	results := make([]*R, len(items))
+	errs := make([]error, len(items))
+
+	workers := 1
+	if opts != nil && opts.Concurrency > 1 {
+		workers = min(opts.Concurrency, len(items))
+	}
+
+	do := func(i int) {
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			return
+		}
+		if c.retrier != nil {
+			if err := c.retrier.rateLimiter.Wait(ctx); err != nil {
+				errs[i] = err
+				return
+			}
+		}
+		results[i], errs[i] = create(ctx, items[i])
+	}
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				do(i)
+			}
+		}()
+	}
+
+	for i := range items {
+		select {
+		case next <- i:
+			continue
+		case <-ctx.Done():
+		}
+		for ; i < len(items); i++ {
+			errs[i] = ctx.Err()
+		}
+		break
+	}
+	close(next)
+	wg.Wait()
+
+	return results, errs
+}
@@ -0,0 +1,133 @@
+package invoiceninja
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientsServiceCreateBatch(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		var body INClient
+		json.NewDecoder(r.Body).Decode(&body)
+
+		w.Header().Set("Content-Type", "application/json")
+		if body.Name == "bad" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"message": "The given data was invalid."}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"id": "id-" + body.Name, "name": body.Name},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	var rows []*INClient
+	for i := 0; i < 12; i++ {
+		name := fmt.Sprintf("row%d", i)
+		if i == 5 {
+			name = "bad"
+		}
+		rows = append(rows, &INClient{Name: name})
+	}
+
+	created, errs := client.Clients.CreateBatch(context.Background(), rows, &BatchOptions{Concurrency: 3})
+
+	if len(created) != len(rows) || len(errs) != len(rows) {
+		t.Fatalf("expected %d results, got %d and %d", len(rows), len(created), len(errs))
+	}
+	for i := range rows {
+		if i == 5 {
+			if apiErr, ok := IsAPIError(errs[i]); !ok || apiErr.StatusCode != http.StatusUnprocessableEntity || created[i] != nil {
+				t.Errorf("row 5: expected a 422 and no client, got %v and %+v", errs[i], created[i])
+			}
+			continue
+		}
+		if errs[i] != nil {
+			t.Errorf("row %d: unexpected error: %v", i, errs[i])
+		} else if created[i].ID != "id-"+rows[i].Name {
+			t.Errorf("row %d: expected ID id-%s, got %s", i, rows[i].Name, created[i].ID)
+		}
+	}
+	if m := atomic.LoadInt32(&maxInFlight); m > 3 || m < 2 {
+		t.Errorf("expected 2 to 3 requests in flight, got %d", m)
+	}
+}
+
+func TestInvoicesServiceCreateBatchCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 2 {
+			cancel()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "inv"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	invoices := make([]*Invoice, 5)
+	for i := range invoices {
+		invoices[i] = &Invoice{ClientID: "c1"}
+	}
+
+	created, errs := client.Invoices.CreateBatch(ctx, invoices, nil)
+
+	if errs[0] != nil || created[0] == nil {
+		t.Errorf("expected the first invoice to be created, got %v", errs[0])
+	}
+	for i := 2; i < len(invoices); i++ {
+		if !errors.Is(errs[i], context.Canceled) {
+			t.Errorf("invoice %d: expected context.Canceled, got %v", i, errs[i])
+		}
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("expected 2 requests before cancellation, got %d", got)
+	}
+}
+
+func TestCreateBatchUsesRateLimiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "c"}}`))
+	}))
+	defer server.Close()
+
+	client := NewRateLimitedClient("test-token", WithBaseURL(server.URL))
+	client.SetRateLimit(2)
+
+	start := time.Now()
+	_, errs := client.Clients.CreateBatch(context.Background(), []*INClient{{}, {}, {}}, &BatchOptions{Concurrency: 3})
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("client %d: unexpected error: %v", i, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond {
+		t.Errorf("expected the third create to wait for the rate limit, took %v", elapsed)
+	}
+}
@@ -0,0 +1,167 @@
+package invoiceninja
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// DefaultBulkChunkSize is the number of IDs sent per request by the BulkChunked helpers.
+const DefaultBulkChunkSize = 100
+
+// ErrEmptyBulkResponse is returned by single-item bulk actions such as
+// Archive, Restore or MarkSent when the server accepts the action but echoes
+// no entity back. The action may still have taken effect.
+var ErrEmptyBulkResponse = errors.New("bulk action returned no entity")
+
+// bulkRequest posts a bulk action to path and returns the entities in the
+// response. The data is normally an array, but some server versions answer a
+// single-ID action with one object, which is returned as a one-item slice; an
+// empty body, an empty object or null returns no entities. If the client
+// belongs to a RateLimitedClient, the request goes through its rate limiting,
+// circuit breaker and retry logic, so every chunk of a BulkChunked call is
+// paced and retried like a single request.
+func bulkRequest[T any](ctx context.Context, c *Client, path string, req BulkAction, opts ...RequestOption) ([]T, error) {
+	var resp struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := c.bulkPost(ctx, path, req, &resp, opts...); err != nil && !errors.Is(err, ErrEmptyResponse) {
+		return nil, err
+	}
+
+	data := bytes.TrimSpace(resp.Data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+
+	if data[0] == '{' {
+		var entity T
+		if err := c.jsonCodec().Unmarshal(data, &entity); err != nil {
+			return nil, newDecodeError("POST", path, data, err, c.apiToken)
+		}
+		if reflect.ValueOf(&entity).Elem().IsZero() {
+			return nil, nil
+		}
+		return []T{entity}, nil
+	}
+
+	var entities []T
+	if err := c.jsonCodec().Unmarshal(data, &entities); err != nil {
+		return nil, newDecodeError("POST", path, data, err, c.apiToken)
+	}
+	return entities, nil
+}
+
+// bulkPost posts a bulk action, through the RateLimitedClient owning c if any.
+func (c *Client) bulkPost(ctx context.Context, path string, req BulkAction, result interface{}, opts ...RequestOption) error {
+	if c.retrier != nil {
+		return c.retrier.DoRequestWithRetry(ctx, "POST", path, nil, req, result, opts...)
+	}
+	return c.doRequest(ctx, "POST", path, nil, req, result, opts...)
+}
+
+// BulkActionType is a bulk action understood by Invoice Ninja. The BulkTyped
+// methods accept it and reject actions the entity does not support before
+// sending the request; Bulk takes any string so that actions added to the
+// server later can still be used.
+type BulkActionType string
+
+// Bulk actions. Every entity supports BulkArchive, BulkRestore and
+// BulkDelete; the others apply to the entities named.
+const (
+	BulkArchive BulkActionType = "archive"
+	BulkRestore BulkActionType = "restore"
+	BulkDelete  BulkActionType = "delete"
+
+	// Invoices, quotes and credits.
+	BulkMarkSent BulkActionType = "mark_sent"
+	BulkEmail    BulkActionType = "email"
+
+	// Invoices.
+	BulkMarkPaid BulkActionType = "mark_paid"
+	BulkCancel   BulkActionType = "cancel"
+	BulkReverse  BulkActionType = "reverse"
+	BulkAutoBill BulkActionType = "auto_bill"
+
+	// Quotes.
+	BulkApprove          BulkActionType = "approve"
+	BulkReject           BulkActionType = "reject"
+	BulkConvertToInvoice BulkActionType = "convert_to_invoice"
+
+	// Bank transactions.
+	BulkConvertMatched BulkActionType = "convert_matched"
+	BulkUnlink         BulkActionType = "unlink"
+
+	// Recurring invoices.
+	BulkStart   BulkActionType = "start"
+	BulkStop    BulkActionType = "stop"
+	BulkSendNow BulkActionType = "send_now"
+)
+
+// lifecycleBulkActions are the bulk actions every entity supports.
+var lifecycleBulkActions = []BulkActionType{BulkArchive, BulkRestore, BulkDelete}
+
+// checkBulkAction returns a *ValidationError unless action is a lifecycle
+// action or one of extra.
+func checkBulkAction(entity string, action BulkActionType, extra ...BulkActionType) error {
+	if slices.Contains(lifecycleBulkActions, action) || slices.Contains(extra, action) {
+		return nil
+	}
+	verr := &ValidationError{}
+	verr.add("action", fmt.Sprintf("%q is not a bulk action for %s", action, entity))
+	return verr.errOrNil()
+}
+
+// BulkChunkError describes a chunk of a chunked bulk action that failed.
+type BulkChunkError struct {
+	// IDs are the entity IDs that were sent in the failed chunk.
+	IDs []string
+
+	// Err is the error returned for the chunk.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *BulkChunkError) Error() string {
+	return fmt.Sprintf("bulk chunk of %d ids failed: %v", len(e.IDs), e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *BulkChunkError) Unwrap() error {
+	return e.Err
+}
+
+// bulkChunked splits ids into chunks of chunkSize and calls fn once per chunk.
+// Entities returned by successful chunks are aggregated; failed chunks are
+// reported as joined *BulkChunkError values so partial failures stay visible.
+func bulkChunked[T any](ctx context.Context, ids []string, chunkSize int, fn func(context.Context, []string) ([]T, error)) ([]T, error) {
+	if chunkSize <= 0 {
+		chunkSize = DefaultBulkChunkSize
+	}
+
+	var results []T
+	var errs []error
+
+	for start := 0; start < len(ids); start += chunkSize {
+		end := min(start+chunkSize, len(ids))
+		chunk := ids[start:end]
+
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, &BulkChunkError{IDs: ids[start:], Err: err})
+			break
+		}
+
+		items, err := fn(ctx, chunk)
+		if err != nil {
+			errs = append(errs, &BulkChunkError{IDs: chunk, Err: err})
+			continue
+		}
+		results = append(results, items...)
+	}
+
+	return results, errors.Join(errs...)
+}
@@ -0,0 +1,386 @@
+package invoiceninja
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestInvoicesServiceBulkChunked(t *testing.T) {
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		var body BulkAction
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+
+		if len(body.IDs) > 100 {
+			t.Errorf("expected at most 100 ids per chunk, got %d", len(body.IDs))
+		}
+
+		data := make([]map[string]interface{}, 0, len(body.IDs))
+		for _, id := range body.IDs {
+			data = append(data, map[string]interface{}{"id": id})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	ids := make([]string, 250)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("inv%d", i)
+	}
+
+	invoices, err := client.Invoices.BulkChunked(context.Background(), "archive", ids, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if requests != 3 {
+		t.Errorf("expected 3 requests, got %d", requests)
+	}
+
+	if len(invoices) != 250 {
+		t.Errorf("expected 250 invoices, got %d", len(invoices))
+	}
+}
+
+func TestClientsServiceBulkChunkedPartialFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body BulkAction
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+
+		if body.IDs[0] == "c2" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		data := make([]map[string]interface{}, 0, len(body.IDs))
+		for _, id := range body.IDs {
+			data = append(data, map[string]interface{}{"id": id})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	ids := []string{"c0", "c1", "c2", "c3", "c4"}
+	clients, err := client.Clients.BulkChunked(context.Background(), "archive", ids, 2)
+
+	if err == nil {
+		t.Fatal("expected error for failed chunk")
+	}
+
+	var chunkErr *BulkChunkError
+	if !errors.As(err, &chunkErr) {
+		t.Fatalf("expected BulkChunkError, got %T", err)
+	}
+
+	if len(chunkErr.IDs) != 2 || chunkErr.IDs[0] != "c2" {
+		t.Errorf("expected failed chunk [c2 c3], got %v", chunkErr.IDs)
+	}
+
+	apiErr, ok := IsAPIError(err)
+	if !ok || !apiErr.IsServerError() {
+		t.Errorf("expected wrapped server error, got %v", err)
+	}
+
+	if len(clients) != 3 {
+		t.Errorf("expected 3 clients from successful chunks, got %d", len(clients))
+	}
+}
+
+func TestBulkChunkedContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := 0
+	_, err := bulkChunked(ctx, []string{"a", "b", "c"}, 1, func(ctx context.Context, ids []string) ([]string, error) {
+		calls++
+		return ids, nil
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	if calls != 0 {
+		t.Errorf("expected no chunk calls after cancellation, got %d", calls)
+	}
+}
+
+// lifecycleActions pairs a service's Archive and Restore methods with the
+// bulk action each is expected to send.
+func lifecycleActions[T any](archive, restore func(context.Context, string) (*T, error)) map[string]func(context.Context, string) error {
+	wrap := func(fn func(context.Context, string) (*T, error)) func(context.Context, string) error {
+		return func(ctx context.Context, id string) error {
+			_, err := fn(ctx, id)
+			return err
+		}
+	}
+	return map[string]func(context.Context, string) error{
+		"archive": wrap(archive),
+		"restore": wrap(restore),
+	}
+}
+
+func TestArchiveRestoreActions(t *testing.T) {
+	var gotPath, gotAction string
+	var gotIDs []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body BulkAction
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		gotPath, gotAction, gotIDs = r.Method+" "+r.URL.Path, body.Action, body.IDs
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": [{"id": "x1"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	tests := []struct {
+		path    string
+		actions map[string]func(context.Context, string) error
+	}{
+		{"/api/v1/bank_transactions/bulk", lifecycleActions(client.BankTransactions.Archive, client.BankTransactions.Restore)},
+		{"/api/v1/clients/bulk", lifecycleActions(client.Clients.Archive, client.Clients.Restore)},
+		{"/api/v1/credits/bulk", lifecycleActions(client.Credits.Archive, client.Credits.Restore)},
+		{"/api/v1/invoices/bulk", lifecycleActions(client.Invoices.Archive, client.Invoices.Restore)},
+		{"/api/v1/payment_terms/bulk", lifecycleActions(client.PaymentTerms.Archive, client.PaymentTerms.Restore)},
+		{"/api/v1/payments/bulk", lifecycleActions(client.Payments.Archive, client.Payments.Restore)},
+		{"/api/v1/products/bulk", lifecycleActions(client.Products.Archive, client.Products.Restore)},
+		{"/api/v1/quotes/bulk", lifecycleActions(client.Quotes.Archive, client.Quotes.Restore)},
+		{"/api/v1/recurring_invoices/bulk", lifecycleActions(client.RecurringInvoices.Archive, client.RecurringInvoices.Restore)},
+		{"/api/v1/subscriptions/bulk", lifecycleActions(client.Subscriptions.Archive, client.Subscriptions.Restore)},
+		{"/api/v1/webhooks/bulk", lifecycleActions(client.Webhooks.Archive, client.Webhooks.Restore)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			for want, fn := range tt.actions {
+				if err := fn(context.Background(), "x1"); err != nil {
+					t.Fatalf("%s: unexpected error: %v", want, err)
+				}
+				if gotPath != "POST "+tt.path {
+					t.Errorf("%s: expected POST %s, got %s", want, tt.path, gotPath)
+				}
+				if gotAction != want || len(gotIDs) != 1 || gotIDs[0] != "x1" {
+					t.Errorf("expected action %q for [x1], got %q for %v", want, gotAction, gotIDs)
+				}
+			}
+		})
+	}
+}
+
+func TestBulkActionEmptyResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	calls := map[string]func() error{
+		"products archive":   func() error { _, err := client.Products.Archive(ctx, "gone"); return err },
+		"invoices mark_paid": func() error { _, err := client.Invoices.MarkPaid(ctx, "gone"); return err },
+		"credits mark_sent":  func() error { _, err := client.Credits.MarkSent(ctx, "gone"); return err },
+		"quotes approve":     func() error { _, err := client.Quotes.MarkApproved(ctx, "gone"); return err },
+	}
+	for name, call := range calls {
+		if err := call(); !errors.Is(err, ErrEmptyBulkResponse) {
+			t.Errorf("%s: expected ErrEmptyBulkResponse, got %v", name, err)
+		}
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"message": "invalid"}`))
+	}))
+	defer failing.Close()
+
+	client.SetBaseURL(failing.URL)
+	if _, err := client.Products.Archive(ctx, "x1"); err == nil || errors.Is(err, ErrEmptyBulkResponse) {
+		t.Errorf("expected an API error distinct from ErrEmptyBulkResponse, got %v", err)
+	}
+}
+
+func TestBulkTyped(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		var body BulkAction
+		json.NewDecoder(r.Body).Decode(&body)
+		if r.URL.Path != "/api/v1/quotes/bulk" || body.Action != "convert_to_invoice" {
+			t.Errorf("unexpected request %s with action %q", r.URL.Path, body.Action)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": [{"id": "q1"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	quotes, err := client.Quotes.BulkTyped(ctx, BulkConvertToInvoice, []string{"q1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(quotes) != 1 || quotes[0].ID != "q1" {
+		t.Errorf("unexpected quotes: %+v", quotes)
+	}
+
+	rejected := map[string]func() error{
+		"invoices approve":   func() error { _, err := client.Invoices.BulkTyped(ctx, BulkApprove, []string{"i1"}); return err },
+		"products mark_paid": func() error { _, err := client.Products.BulkTyped(ctx, BulkMarkPaid, []string{"p1"}); return err },
+		"quotes unknown":     func() error { _, err := client.Quotes.BulkTyped(ctx, "explode", []string{"q1"}); return err },
+	}
+	for name, call := range rejected {
+		var verr *ValidationError
+		if err := call(); !errors.As(err, &verr) {
+			t.Errorf("%s: expected a *ValidationError, got %v", name, err)
+		}
+	}
+
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("expected rejected actions not to reach the server, got %d requests", got)
+	}
+}
+
+func TestBulkResponseShapes(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		ids  []string
+	}{
+		{"array", `[{"id": "x1"}, {"id": "x2"}]`, []string{"x1", "x2"}},
+		{"single object", `{"id": "x1", "status_id": "4"}`, []string{"x1"}},
+		{"empty array", `[]`, nil},
+		{"empty object", `{}`, nil},
+		{"null", `null`, nil},
+		{"empty body", ``, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.data == "" {
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"data": ` + tt.data + `}`))
+			}))
+			defer server.Close()
+
+			client := NewClient("test-token", WithBaseURL(server.URL))
+			ctx := context.Background()
+
+			invoices, err := client.Invoices.Bulk(ctx, "mark_paid", []string{"x1"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var ids []string
+			for _, inv := range invoices {
+				ids = append(ids, inv.ID)
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(tt.ids) {
+				t.Errorf("expected %v, got %v", tt.ids, ids)
+			}
+
+			product, err := client.Products.Archive(ctx, "x1")
+			if len(tt.ids) == 0 {
+				if !errors.Is(err, ErrEmptyBulkResponse) {
+					t.Errorf("expected ErrEmptyBulkResponse, got %v", err)
+				}
+				return
+			}
+			if err != nil || product.ID != "x1" {
+				t.Errorf("expected product x1, got %+v, %v", product, err)
+			}
+		})
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": "done"}`))
+	}))
+	defer server.Close()
+
+	var decodeErr *DecodeError
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	if _, err := client.Clients.Bulk(context.Background(), "archive", []string{"x1"}); !errors.As(err, &decodeErr) {
+		t.Errorf("expected a *DecodeError for unexpected data, got %v", err)
+	}
+}
+
+func TestBulkChunkedRateLimitedClientRetriesChunks(t *testing.T) {
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body BulkAction
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+
+		// Fail the first attempt of the second chunk
+		if atomic.AddInt32(&requests, 1) == 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		data := make([]map[string]interface{}, 0, len(body.IDs))
+		for _, id := range body.IDs {
+			data = append(data, map[string]interface{}{"id": id})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	}))
+	defer server.Close()
+
+	client := NewRateLimitedClient("test-token", WithBaseURL(server.URL))
+	client.SetRetryConfig(&RetryConfig{
+		MaxRetries:         2,
+		InitialBackoff:     time.Millisecond,
+		MaxBackoff:         time.Millisecond,
+		BackoffMultiplier:  1,
+		RetryOnStatusCodes: []int{http.StatusServiceUnavailable},
+		RetryUnsafeMethods: true,
+	})
+
+	ids := []string{"inv1", "inv2", "inv3", "inv4", "inv5"}
+	invoices, err := client.Invoices.BulkChunked(context.Background(), "archive", ids, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(invoices) != len(ids) {
+		t.Errorf("expected %d invoices, got %d", len(ids), len(invoices))
+	}
+	if requests != 4 {
+		t.Errorf("expected 3 chunks and 1 retry, got %d requests", requests)
+	}
+}
@@ -0,0 +1,113 @@
+package invoiceninja
+
+import (
+	"container/list"
+	"sync"
+)
+
+// DefaultCacheSize is the number of responses kept by the cache that
+// WithResponseCache creates when given a nil Cache.
+const DefaultCacheSize = 256
+
+// CachedResponse is a GET response body stored together with its ETag.
+type CachedResponse struct {
+	// ETag is the entity tag the server sent with the response.
+	ETag string
+
+	// Body is the raw response body.
+	Body []byte
+}
+
+// Cache stores GET responses for conditional requests. Keys are full request
+// URLs. Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the response stored for key, if any.
+	Get(key string) (CachedResponse, bool)
+
+	// Set stores a response for key.
+	Set(key string, resp CachedResponse)
+}
+
+// WithResponseCache enables ETag-based caching of GET responses. The ETag of
+// each cached response is sent in an If-None-Match header on the next GET of
+// the same URL; when the server answers 304 Not Modified, the cached body is
+// decoded instead. If cache is nil, an LRUCache of DefaultCacheSize entries
+// is used.
+//
+// Only GET requests without per-request options are cached, and only
+// responses that carry an ETag header. This is mostly useful for rarely
+// changing resources such as payment terms.
+func WithResponseCache(cache Cache) ClientOption {
+	return func(c *Client) {
+		if cache == nil {
+			cache = NewLRUCache(DefaultCacheSize)
+		}
+		c.cache = cache
+	}
+}
+
+// LRUCache is an in-memory Cache that holds up to a fixed number of
+// responses, evicting the least recently used one when full.
+type LRUCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+// lruEntry is the value stored in each LRUCache list element.
+type lruEntry struct {
+	key  string
+	resp CachedResponse
+}
+
+// NewLRUCache creates an LRUCache that holds up to size responses.
+// A size below 1 is treated as 1.
+func NewLRUCache(size int) *LRUCache {
+	return &LRUCache{
+		size:    max(size, 1),
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Get returns the response stored for key and marks it as recently used.
+func (l *LRUCache) Get(key string) (CachedResponse, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	elem, ok := l.entries[key]
+	if !ok {
+		return CachedResponse{}, false
+	}
+	l.order.MoveToFront(elem)
+	return elem.Value.(*lruEntry).resp, true
+}
+
+// Set stores a response for key, evicting the least recently used response
+// if the cache is full.
+func (l *LRUCache) Set(key string, resp CachedResponse) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if elem, ok := l.entries[key]; ok {
+		elem.Value.(*lruEntry).resp = resp
+		l.order.MoveToFront(elem)
+		return
+	}
+
+	l.entries[key] = l.order.PushFront(&lruEntry{key: key, resp: resp})
+
+	if l.order.Len() > l.size {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Len returns the number of cached responses.
+func (l *LRUCache) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.order.Len()
+}
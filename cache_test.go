@@ -0,0 +1,109 @@
+package invoiceninja
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLRUCacheEviction(t *testing.T) {
+	cache := NewLRUCache(2)
+
+	cache.Set("a", CachedResponse{ETag: `"a"`})
+	cache.Set("b", CachedResponse{ETag: `"b"`})
+
+	// Reading "a" makes "b" the least recently used entry
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatal("expected a to be cached")
+	}
+	cache.Set("c", CachedResponse{ETag: `"c"`})
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("expected b to be evicted")
+	}
+	if resp, ok := cache.Get("a"); !ok || resp.ETag != `"a"` {
+		t.Errorf("expected a to be kept, got %+v, %v", resp, ok)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", cache.Len())
+	}
+
+	cache.Set("a", CachedResponse{ETag: `"a2"`})
+	if resp, _ := cache.Get("a"); resp.ETag != `"a2"` {
+		t.Errorf("expected updated ETag, got %s", resp.ETag)
+	}
+}
+
+func TestWithResponseCache(t *testing.T) {
+	var requests, notModified int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method == "GET" && r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if r.Method == "GET" && r.URL.Path == "/api/v1/payment_terms" {
+			w.Header().Set("ETag", `"v1"`)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[{"id":"term1","num_days":30}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithResponseCache(nil))
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		resp, err := client.PaymentTerms.List(ctx, nil)
+		if err != nil {
+			t.Fatalf("request %d: unexpected error: %v", i, err)
+		}
+		if len(resp.Data) != 1 || resp.Data[0].ID != "term1" || resp.Data[0].NumDays != 30 {
+			t.Errorf("request %d: unexpected payment terms: %+v", i, resp.Data)
+		}
+	}
+	if notModified != 2 {
+		t.Errorf("expected 2 conditional hits, got %d", notModified)
+	}
+
+	// Responses without an ETag and non-GET requests are not cached
+	requests, notModified = 0, 0
+	for i := 0; i < 2; i++ {
+		if _, err := client.Invoices.List(ctx, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := client.Request(ctx, "POST", "/api/v1/payment_terms", map[string]int{"num_days": 7}, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if requests != 4 || notModified != 0 {
+		t.Errorf("expected 4 unconditional requests, got %d requests and %d conditional hits", requests, notModified)
+	}
+}
+
+func TestWithResponseCacheSkipsRequestOptions(t *testing.T) {
+	var conditional int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			conditional++
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"data":{"id":"inv1"}}`))
+	}))
+	defer server.Close()
+
+	cache := NewLRUCache(10)
+	client := NewClient("test-token", WithBaseURL(server.URL), WithResponseCache(cache))
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if err := client.Request(ctx, "GET", "/api/v1/invoices/inv1", nil, nil, WithHeader("X-Company", "c1")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if conditional != 0 || cache.Len() != 0 {
+		t.Errorf("expected requests with options to bypass the cache, got %d conditional requests and %d entries", conditional, cache.Len())
+	}
+}
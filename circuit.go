@@ -0,0 +1,118 @@
+package invoiceninja
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by a RateLimitedClient whose circuit breaker is
+// open, without sending the request.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// WithCircuitBreaker protects a struggling server from a RateLimitedClient
+// that keeps retrying. After failures consecutive retryable failures (network
+// errors or status codes in RetryConfig.RetryOnStatusCodes), the circuit
+// opens and requests fail fast with ErrCircuitOpen for cooldown. Then a single
+// probe request is let through: if it succeeds the circuit closes, otherwise
+// it opens for another cooldown. Failures further apart than cooldown are not
+// consecutive.
+//
+// The breaker applies to RateLimitedClient.DoRequestWithRetry and to the list
+// iterators of a RateLimitedClient; a plain Client ignores it.
+func WithCircuitBreaker(failures int, cooldown time.Duration) ClientOption {
+	return func(c *Client) {
+		if failures <= 0 {
+			c.breaker = nil
+			return
+		}
+		c.breaker = &circuitBreaker{
+			threshold: failures,
+			cooldown:  cooldown,
+			now:       func() time.Time { return c.timeSource().Now() },
+		}
+	}
+}
+
+// circuitBreaker counts consecutive retryable failures and fails fast while
+// open. A nil *circuitBreaker always allows requests.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu          sync.Mutex
+	failures    int
+	lastFailure time.Time
+	openedAt    time.Time
+	open        bool
+	probing     bool
+}
+
+// allow reports whether a request may be sent and whether it is the
+// half-open probe. It returns ErrCircuitOpen while the circuit is open or
+// while the probe is in flight. Every allowed request must be followed by
+// record or release.
+func (b *circuitBreaker) allow() (probe bool, err error) {
+	if b == nil {
+		return false, nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.open {
+		return false, nil
+	}
+	if b.probing || b.now().Sub(b.openedAt) < b.cooldown {
+		return false, ErrCircuitOpen
+	}
+
+	// Half-open: let one probe through
+	b.probing = true
+	return true, nil
+}
+
+// record registers the outcome of an allowed request.
+func (b *circuitBreaker) record(probe, failed bool) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if probe {
+		b.probing = false
+	}
+
+	if !failed {
+		b.failures = 0
+		b.open = false
+		return
+	}
+
+	now := b.now()
+	if now.Sub(b.lastFailure) > b.cooldown {
+		b.failures = 0
+	}
+	b.failures++
+	b.lastFailure = now
+
+	if probe || b.failures >= b.threshold {
+		b.open = true
+		b.openedAt = now
+	}
+}
+
+// release ends an allowed request whose outcome says nothing about the
+// server, such as one canceled by its context.
+func (b *circuitBreaker) release(probe bool) {
+	if b == nil || !probe {
+		return
+	}
+
+	b.mu.Lock()
+	b.probing = false
+	b.mu.Unlock()
+}
@@ -0,0 +1,149 @@
+package invoiceninja
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testBreaker returns a circuit breaker with a controllable clock.
+func testBreaker(failures int, cooldown time.Duration) (*circuitBreaker, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := &Client{}
+	WithCircuitBreaker(failures, cooldown)(c)
+	c.breaker.now = func() time.Time { return now }
+	return c.breaker, &now
+}
+
+func TestCircuitBreakerOpensAndProbes(t *testing.T) {
+	b, now := testBreaker(3, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		probe, err := b.allow()
+		if err != nil || probe {
+			t.Fatalf("attempt %d: expected closed circuit, got probe=%v err=%v", i, probe, err)
+		}
+		b.record(false, true)
+	}
+
+	if _, err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen after 3 failures, got %v", err)
+	}
+
+	// After the cooldown a single probe is let through
+	*now = now.Add(time.Minute)
+	probe, err := b.allow()
+	if err != nil || !probe {
+		t.Fatalf("expected half-open probe, got probe=%v err=%v", probe, err)
+	}
+	if _, err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected only one probe in flight, got %v", err)
+	}
+
+	// A failed probe reopens the circuit for another cooldown
+	b.record(true, true)
+	*now = now.Add(30 * time.Second)
+	if _, err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected circuit to reopen after failed probe, got %v", err)
+	}
+
+	// A successful probe closes it
+	*now = now.Add(30 * time.Second)
+	probe, _ = b.allow()
+	b.record(probe, false)
+	if probe, err := b.allow(); err != nil || probe {
+		t.Errorf("expected closed circuit after successful probe, got probe=%v err=%v", probe, err)
+	}
+}
+
+func TestCircuitBreakerCountsConsecutiveFailures(t *testing.T) {
+	b, now := testBreaker(2, time.Minute)
+
+	// A success resets the count
+	b.record(false, true)
+	b.record(false, false)
+	b.record(false, true)
+	if _, err := b.allow(); err != nil {
+		t.Fatalf("expected closed circuit, got %v", err)
+	}
+
+	// Failures further apart than the cooldown are not consecutive
+	*now = now.Add(2 * time.Minute)
+	b.record(false, true)
+	if _, err := b.allow(); err != nil {
+		t.Fatalf("expected closed circuit, got %v", err)
+	}
+
+	b.record(false, true)
+	if _, err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen, got %v", err)
+	}
+}
+
+func TestCircuitBreakerReleasedProbe(t *testing.T) {
+	b, now := testBreaker(1, time.Minute)
+	b.record(false, true)
+
+	*now = now.Add(time.Minute)
+	probe, _ := b.allow()
+	b.release(probe)
+
+	if probe, err := b.allow(); err != nil || !probe {
+		t.Errorf("expected a new probe after release, got probe=%v err=%v", probe, err)
+	}
+}
+
+func TestDoRequestWithRetryCircuitBreaker(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewRateLimitedClient("test-token", WithBaseURL(server.URL), WithCircuitBreaker(3, time.Hour))
+	client.SetRetryConfig(&RetryConfig{
+		MaxRetries:         5,
+		InitialBackoff:     time.Millisecond,
+		MaxBackoff:         time.Millisecond,
+		BackoffMultiplier:  1,
+		RetryOnStatusCodes: []int{http.StatusServiceUnavailable},
+	})
+
+	err := client.DoRequestWithRetry(context.Background(), "GET", "/api/v1/invoices", nil, nil, nil)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 3 {
+		t.Errorf("expected 3 requests before the circuit opened, got %d", n)
+	}
+
+	// Later calls fail fast without reaching the server
+	err = client.DoRequestWithRetry(context.Background(), "GET", "/api/v1/invoices", nil, nil, nil)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen, got %v", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 3 {
+		t.Errorf("expected no further requests, got %d", n)
+	}
+}
+
+func TestDoRequestWithRetryCircuitBreakerIgnoresClientErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewRateLimitedClient("test-token", WithBaseURL(server.URL), WithCircuitBreaker(1, time.Hour))
+
+	for i := 0; i < 3; i++ {
+		err := client.DoRequestWithRetry(context.Background(), "GET", "/api/v1/invoices/missing", nil, nil, nil)
+		if errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("request %d: expected 404 not to open the circuit", i)
+		}
+	}
+}
@@ -27,13 +27,18 @@ package invoiceninja
 
 import (
 	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
-	"encoding/json"
+	"crypto/tls"
 	"fmt"
 	"io"
+	"log/slog"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -44,6 +49,9 @@ const (
 	// DemoBaseURL is the demo Invoice Ninja API endpoint.
 	DemoBaseURL = "https://demo.invoiceninja.com"
 
+	// DefaultAPIPrefix is the path prefix of the Invoice Ninja v5 API.
+	DefaultAPIPrefix = "/api/v1"
+
 	// DefaultTimeout is the default HTTP client timeout.
 	DefaultTimeout = 30 * time.Second
 
@@ -52,16 +60,33 @@ const (
 )
 
 // Client is the Invoice Ninja API client.
+//
+// A Client is safe for concurrent use by multiple goroutines once constructed,
+// including calls to SetBaseURL while requests are in flight; create one per
+// API token and share it rather than creating one per request.
 type Client struct {
 	// httpClient is the underlying HTTP client used for requests.
 	httpClient *http.Client
 
-	// baseURL is the API base URL.
-	baseURL string
+	// baseURL holds the API base URL string. It is stored atomically so
+	// SetBaseURL can be called while other goroutines issue requests.
+	baseURL atomic.Value
+
+	// baseURLErr records why a URL passed to WithBaseURL was rejected.
+	baseURLErr error
 
 	// apiToken is the API authentication token.
 	apiToken string
 
+	// apiPrefix is the path prefix of every service endpoint.
+	apiPrefix string
+
+	// userAgent is the caller's application identifier prepended to the SDK User-Agent.
+	userAgent string
+
+	// defaultHeaders are added to every request before the SDK's own headers.
+	defaultHeaders http.Header
+
 	// Payments provides access to payment-related endpoints.
 	Payments *PaymentsService
 
@@ -77,16 +102,160 @@ type Client struct {
 	// Credits provides access to credit-related endpoints.
 	Credits *CreditsService
 
+	// RecurringInvoices provides access to recurring invoice endpoints.
+	RecurringInvoices *RecurringInvoicesService
+
+	// CompanyGateways provides access to payment gateway configuration endpoints.
+	CompanyGateways *CompanyGatewaysService
+
+	// Products provides access to product catalog endpoints.
+	Products *ProductsService
+
+	// Quotes provides access to quote-related endpoints.
+	Quotes *QuotesService
+
+	// Subscriptions provides access to subscription endpoints.
+	Subscriptions *SubscriptionsService
+
+	// BankTransactions provides access to bank transaction endpoints.
+	BankTransactions *BankTransactionsService
+
+	// Webhooks provides access to webhook subscription endpoints.
+	Webhooks *WebhooksService
+
+	// Designs provides access to PDF design endpoints.
+	Designs *DesignsService
+
+	// Static provides access to reference data such as currencies and countries.
+	Static *StaticService
+
+	// Users provides read-only access to user endpoints.
+	Users *UsersService
+
+	// Companies provides read-only access to company endpoints.
+	Companies *CompaniesService
+
 	// Downloads provides access to file download operations.
 	Downloads *DownloadsService
 
 	// Uploads provides access to file upload operations.
 	Uploads *UploadsService
+
+	// coalescer shares identical in-flight GET requests when enabled.
+	coalescer *flightGroup
+
+	// cache stores ETag-tagged GET responses when enabled.
+	cache Cache
+
+	// requestHooks run before each request is sent.
+	requestHooks []RequestHook
+
+	// responseHooks run after each request completes.
+	responseHooks []ResponseHook
+
+	// normalizeClients enables normalization of clients before they are created.
+	normalizeClients bool
+
+	// validatePayments enables invoice balance checks before payments are created.
+	validatePayments bool
+
+	// validateRequests enables client-side validation before entities are created.
+	validateRequests bool
+
+	// pageDelay is the pause between page requests made by iterators.
+	pageDelay time.Duration
+
+	// retrier is the RateLimitedClient wrapping this client, if any.
+	retrier *RateLimitedClient
+
+	// breaker fails requests fast after repeated retryable failures; only a
+	// RateLimitedClient consults it.
+	breaker *circuitBreaker
+
+	// dialTimeout limits how long establishing a TCP connection may take.
+	dialTimeout time.Duration
+
+	// tlsHandshakeTimeout limits how long the TLS handshake may take.
+	tlsHandshakeTimeout time.Duration
+
+	// insecureSkipVerify disables TLS certificate verification.
+	insecureSkipVerify bool
+
+	// methodOverride sends PUT, PATCH and DELETE requests as POST.
+	methodOverride bool
+
+	// logger receives warnings from the SDK; nil means slog.Default().
+	logger *slog.Logger
+
+	// codec encodes request bodies and decodes responses; nil means
+	// encoding/json.
+	codec Codec
+
+	// metrics is notified of every API request; nil disables it.
+	metrics MetricsObserver
+
+	// maxResponseBytes limits API response bodies; <= 0 means no limit.
+	maxResponseBytes int64
+
+	// maxDownloadBytes limits downloaded files; <= 0 means no limit.
+	maxDownloadBytes int64
+
+	// requestTimeout bounds requests whose context has no deadline;
+	// <= 0 means no default.
+	requestTimeout time.Duration
+
+	// sem holds a token per request in flight when WithMaxConcurrency is set.
+	sem chan struct{}
+
+	// clock drives rate limiting, retry backoff and the circuit breaker; nil
+	// means the real time.
+	clock Clock
+
+	// transport replaces the HTTP client's transport when set.
+	transport *http.Transport
+
+	// serverVersion holds the most recent version reported by the server.
+	serverVersion atomic.Value
 }
 
 // ClientOption is a function that configures a Client.
 type ClientOption func(*Client)
 
+// RequestOption is a function that customizes a single API request. Options
+// run after the SDK sets its default headers, so they can override them.
+type RequestOption func(*http.Request)
+
+// WithHeader sets a header on a single request, e.g. an idempotency or company key.
+//
+//	client.Payments.Create(ctx, payment, invoiceninja.WithHeader("X-Idempotency-Key", key))
+func WithHeader(key, value string) RequestOption {
+	return func(req *http.Request) {
+		req.Header.Set(key, value)
+	}
+}
+
+// IdempotencyKeyHeader is the header WithIdempotencyKey sets.
+const IdempotencyKeyHeader = "X-Idempotency-Key"
+
+// WithIdempotencyKey sets the idempotency key of a single request, so the
+// server processes it at most once. A RateLimitedClient retries POST
+// requests only when they carry one.
+func WithIdempotencyKey(key string) RequestOption {
+	return WithHeader(IdempotencyKeyHeader, key)
+}
+
+// hasIdempotencyKey reports whether opts set an idempotency key.
+func hasIdempotencyKey(opts []RequestOption) bool {
+	if len(opts) == 0 {
+		return false
+	}
+	req := &http.Request{Header: http.Header{}}
+	for _, opt := range opts {
+		opt(req)
+	}
+	return req.Header.Get(IdempotencyKeyHeader) != ""
+}
+
 // WithHTTPClient sets a custom HTTP client.
 func WithHTTPClient(httpClient *http.Client) ClientOption {
 	return func(c *Client) {
@@ -94,13 +263,62 @@ func WithHTTPClient(httpClient *http.Client) ClientOption {
 	}
 }
 
-// WithBaseURL sets a custom base URL (for self-hosted instances).
+// WithBaseURL sets a custom base URL (for self-hosted instances), such as
+// "https://invoices.example.com". The scheme and host are lowercased and
+// trailing slashes trimmed. A URL without an http or https scheme, without a
+// host or with a query is rejected: NewClientWithError returns an error
+// matching ErrInvalidBaseURL, while NewClient logs a warning and keeps the
+// default.
+// A URL with a path is accepted with a warning, since API paths such as
+// /api/v1 are appended to it; see WithAPIPrefix for a different API path.
 func WithBaseURL(baseURL string) ClientOption {
 	return func(c *Client) {
-		c.baseURL = strings.TrimSuffix(baseURL, "/")
+		normalized, err := normalizeBaseURL(baseURL)
+		if err != nil {
+			c.baseURLErr = err
+			return
+		}
+		c.baseURLErr = nil
+		c.baseURL.Store(normalized)
+	}
+}
+
+// WithAPIPrefix sets the path prefix used for every service endpoint
+// (default DefaultAPIPrefix, "/api/v1"), for instances that expose the API
+// under a different path. Paths passed to Request and RequestWithQuery are
+// used as given.
+func WithAPIPrefix(prefix string) ClientOption {
+	return func(c *Client) {
+		c.apiPrefix = "/" + strings.Trim(prefix, "/")
+	}
+}
+
+// WithTransport sets the transport used for requests, e.g. one with larger
+// connection pools from DefaultTransport for bulk import jobs.
+//
+// It composes with WithHTTPClient regardless of option order: the transport
+// replaces that client's transport in a copy of it, so the client passed to
+// WithHTTPClient is not modified and its other settings, such as its timeout,
+// are kept. WithDialTimeout and WithTLSHandshakeTimeout apply to a clone of
+// the transport.
+func WithTransport(transport *http.Transport) ClientOption {
+	return func(c *Client) {
+		c.transport = transport
 	}
 }
 
+// DefaultTransport returns a copy of http.DefaultTransport with connection
+// pools sized for high-throughput use: up to 100 idle connections in total and
+// 32 per host, kept for 90 seconds. The standard library keeps only 2 idle
+// connections per host, so concurrent requests beyond that open new ones.
+func DefaultTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = 100
+	transport.MaxIdleConnsPerHost = 32
+	transport.IdleConnTimeout = 90 * time.Second
+	return transport
+}
+
 // WithTimeout sets a custom timeout for the HTTP client.
 func WithTimeout(timeout time.Duration) ClientOption {
 	return func(c *Client) {
@@ -108,52 +326,481 @@ func WithTimeout(timeout time.Duration) ClientOption {
 	}
 }
 
-// NewClient creates a new Invoice Ninja API client.
+// WithDialTimeout limits how long establishing a TCP connection may take,
+// independently of the overall request timeout set by WithTimeout. This lets
+// unreachable hosts fail fast while large downloads keep a long timeout.
+//
+// The timeout is applied to a copy of the HTTP client's transport, so a client
+// passed to WithHTTPClient is not modified. It has no effect if that client
+// uses a transport other than *http.Transport.
+func WithDialTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.dialTimeout = timeout
+	}
+}
+
+// WithInsecureSkipVerify disables TLS certificate verification, so a
+// self-hosted instance behind a self-signed certificate can be reached during
+// local development. Never use it in production: it makes every request
+// open to interception. A warning is logged through the client's logger
+// (see WithLogger) whenever a client is created with it.
+//
+// The same transport rules as WithDialTimeout apply.
+func WithInsecureSkipVerify() ClientOption {
+	return func(c *Client) {
+		c.insecureSkipVerify = true
+	}
+}
+
+// WithMethodOverride sends PUT, PATCH and DELETE requests as POST with an
+// X-HTTP-Method-Override header naming the real method, which Invoice Ninja
+// honours. Use it behind web application firewalls or proxies that block
+// those verbs; file uploads already use the equivalent _method form field.
+func WithMethodOverride() ClientOption {
+	return func(c *Client) {
+		c.methodOverride = true
+	}
+}
+
+// WithLogger sets the logger that receives the SDK's warnings, such as the
+// one logged for WithInsecureSkipVerify, and the debug-level log of each retry
+// made by a RateLimitedClient. By default slog.Default() is used.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithTLSHandshakeTimeout limits how long the TLS handshake may take,
+// independently of the overall request timeout set by WithTimeout. The same
+// transport rules as WithDialTimeout apply.
+func WithTLSHandshakeTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.tlsHandshakeTimeout = timeout
+	}
+}
+
+// WithUserAgent sets an application identifier (e.g. "myapp/2.1") that is sent
+// in front of the SDK identifier, producing "myapp/2.1 (go-invoice-ninja/1.0.0)".
+func WithUserAgent(ua string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = strings.TrimSpace(ua)
+	}
+}
+
+// WithDefaultHeader adds a header sent with every request, including file
+// downloads and uploads, e.g. for a reverse proxy that requires its own
+// credentials. It can be given multiple times; a later value for the same key
+// replaces an earlier one. Headers set by the SDK, such as X-API-TOKEN, take
+// precedence, while per-request WithHeader options override both.
+//
+//	client := invoiceninja.NewClient(token,
+//		invoiceninja.WithDefaultHeader("CF-Access-Client-Id", id),
+//		invoiceninja.WithDefaultHeader("CF-Access-Client-Secret", secret),
+//	)
+func WithDefaultHeader(key, value string) ClientOption {
+	return func(c *Client) {
+		if c.defaultHeaders == nil {
+			c.defaultHeaders = make(http.Header)
+		}
+		c.defaultHeaders.Set(key, value)
+	}
+}
+
+// WithClientNormalization enables normalization of clients passed to
+// Clients.Create (see INClient.Normalized). Invalid emails or phone numbers
+// are reported as a *ValidationError without sending the request.
+func WithClientNormalization() ClientOption {
+	return func(c *Client) {
+		c.normalizeClients = true
+	}
+}
+
+// WithClientValidation enables client-side validation in Invoices.Create,
+// Payments.Create, Payments.CreateWithEmailReceipt and Clients.Create. The
+// entity's Validate method runs first and its *ValidationError is returned
+// without sending the request, saving a 422 round-trip. The Sort option of
+// list requests is likewise checked with ValidateSort.
+func WithClientValidation() ClientOption {
+	return func(c *Client) {
+		c.validateRequests = true
+	}
+}
+
+// WithPaymentValidation enables balance checks in Payments.Create and
+// Payments.CreateWithEmailReceipt. Each invoice the payment applies to is
+// fetched first, and an *OverApplicationError is returned without creating the
+// payment if the amount applied to an invoice exceeds its balance.
+func WithPaymentValidation() ClientOption {
+	return func(c *Client) {
+		c.validatePayments = true
+	}
+}
+
+// WithPageDelay sets a pause between page requests made by Iter and ListAll.
+// Use it to throttle large exports against servers with strict rate limits.
+func WithPageDelay(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.pageDelay = d
+	}
+}
+
+// WithRequestCoalescing enables sharing of identical in-flight GET requests.
+// When several goroutines issue the same GET (same URL and query) concurrently,
+// only one network call is made and every caller receives its result. The shared
+// call runs with the context of the caller that started it.
+func WithRequestCoalescing() ClientOption {
+	return func(c *Client) {
+		c.coalescer = &flightGroup{}
+	}
+}
+
+// NewClient creates a new Invoice Ninja API client. If WithBaseURL is given
+// an invalid URL, a warning is logged and DefaultBaseURL is used; use
+// NewClientWithError to get the error instead.
 func NewClient(apiToken string, opts ...ClientOption) *Client {
+	c := newClient(apiToken, opts...)
+	if c.baseURLErr != nil {
+		c.log().Warn("invoiceninja: ignoring invalid base URL", "error", c.baseURLErr, "base_url", c.BaseURL())
+	}
+	return c
+}
+
+// NewClientWithError creates a new Invoice Ninja API client like NewClient,
+// but returns an error matching ErrInvalidBaseURL if WithBaseURL is given an
+// invalid URL, so configuration mistakes surface at startup.
+func NewClientWithError(apiToken string, opts ...ClientOption) (*Client, error) {
+	c := newClient(apiToken, opts...)
+	if c.baseURLErr != nil {
+		return nil, c.baseURLErr
+	}
+	return c, nil
+}
+
+// newClient creates a client with opts applied, recording rather than
+// reporting an invalid base URL.
+func newClient(apiToken string, opts ...ClientOption) *Client {
 	c := &Client{
 		httpClient: &http.Client{
 			Timeout: DefaultTimeout,
 		},
-		baseURL:  DefaultBaseURL,
-		apiToken: apiToken,
+		apiToken:         apiToken,
+		apiPrefix:        DefaultAPIPrefix,
+		maxResponseBytes: DefaultMaxResponseBytes,
 	}
+	c.baseURL.Store(DefaultBaseURL)
 
 	for _, opt := range opts {
 		opt(c)
 	}
 
-	// Initialize services
+	c.configureTransport()
+	c.initServices()
+	c.warnBaseURLPath()
+
+	return c
+}
+
+// With returns a copy of the client with opts applied on top of its current
+// configuration, e.g. to talk to another company or instance with the same
+// token:
+//
+//	acme := client.With(invoiceninja.WithDefaultHeader("X-Api-Company-Key", acmeKey))
+//
+// The copy shares the HTTP transport, and so its connection pool, unless opts
+// change it (WithHTTPClient, WithTransport, WithDialTimeout and similar); its
+// timeout can be changed independently. Close on either client closes the
+// shared idle connections. Any response cache and WithMaxConcurrency limit
+// are shared, and the copy's services point at the copy. Request
+// coalescing is not shared, since the copies may send different headers.
+// The copy is not wrapped by a RateLimitedClient, so its list iterators do
+// not retry.
+func (c *Client) With(opts ...ClientOption) *Client {
+	httpClient := *c.httpClient
+	n := &Client{
+		httpClient:          &httpClient,
+		apiToken:            c.apiToken,
+		apiPrefix:           c.apiPrefix,
+		userAgent:           c.userAgent,
+		defaultHeaders:      c.defaultHeaders.Clone(),
+		cache:               c.cache,
+		requestHooks:        append([]RequestHook(nil), c.requestHooks...),
+		responseHooks:       append([]ResponseHook(nil), c.responseHooks...),
+		normalizeClients:    c.normalizeClients,
+		validatePayments:    c.validatePayments,
+		validateRequests:    c.validateRequests,
+		pageDelay:           c.pageDelay,
+		breaker:             c.breaker,
+		dialTimeout:         c.dialTimeout,
+		tlsHandshakeTimeout: c.tlsHandshakeTimeout,
+		insecureSkipVerify:  c.insecureSkipVerify,
+		methodOverride:      c.methodOverride,
+		logger:              c.logger,
+		codec:               c.codec,
+		metrics:             c.metrics,
+		clock:               c.clock,
+		sem:                 c.sem,
+		requestTimeout:      c.requestTimeout,
+		maxResponseBytes:    c.maxResponseBytes,
+		maxDownloadBytes:    c.maxDownloadBytes,
+		transport:           c.transport,
+	}
+	n.baseURL.Store(c.BaseURL())
+	if c.coalescer != nil {
+		n.coalescer = &flightGroup{}
+	}
+
+	for _, opt := range opts {
+		opt(n)
+	}
+	if n.baseURLErr != nil {
+		n.log().Warn("invoiceninja: ignoring invalid base URL", "error", n.baseURLErr, "base_url", n.BaseURL())
+		n.baseURLErr = nil
+	} else if n.BaseURL() != c.BaseURL() {
+		n.warnBaseURLPath()
+	}
+
+	// Rebuild the transport only if the options changed it, so the
+	// connection pool stays shared otherwise
+	if n.httpClient != &httpClient ||
+		n.transport != c.transport ||
+		n.dialTimeout != c.dialTimeout ||
+		n.tlsHandshakeTimeout != c.tlsHandshakeTimeout ||
+		n.insecureSkipVerify != c.insecureSkipVerify {
+		n.configureTransport()
+	}
+	n.initServices()
+
+	return n
+}
+
+// initServices points the client's services at c.
+func (c *Client) initServices() {
 	c.Payments = &PaymentsService{client: c}
 	c.Invoices = &InvoicesService{client: c}
 	c.Clients = &ClientsService{client: c}
 	c.PaymentTerms = &PaymentTermsService{client: c}
 	c.Credits = &CreditsService{client: c}
+	c.RecurringInvoices = &RecurringInvoicesService{client: c}
+	c.CompanyGateways = &CompanyGatewaysService{client: c}
+	c.Products = &ProductsService{client: c}
+	c.Quotes = &QuotesService{client: c}
+	c.Subscriptions = &SubscriptionsService{client: c}
+	c.BankTransactions = &BankTransactionsService{client: c}
+	c.Webhooks = &WebhooksService{client: c}
+	c.Designs = &DesignsService{client: c}
+	c.Static = &StaticService{client: c}
+	c.Users = &UsersService{client: c}
+	c.Companies = &CompaniesService{client: c}
 	c.Downloads = &DownloadsService{client: c}
 	c.Uploads = &UploadsService{client: c}
+}
 
-	return c
+// configureTransport installs the transport set with WithTransport and applies
+// the connection-level timeouts to a copy of the HTTP client's transport.
+func (c *Client) configureTransport() {
+	if c.transport != nil {
+		httpClient := *c.httpClient
+		httpClient.Transport = c.transport
+		c.httpClient = &httpClient
+	}
+
+	if c.dialTimeout <= 0 && c.tlsHandshakeTimeout <= 0 && !c.insecureSkipVerify {
+		return
+	}
+
+	base := c.httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	transport, ok := base.(*http.Transport)
+	if !ok {
+		return
+	}
+	transport = transport.Clone()
+
+	if c.dialTimeout > 0 {
+		dialer := &net.Dialer{
+			Timeout:   c.dialTimeout,
+			KeepAlive: 30 * time.Second,
+		}
+		transport.DialContext = dialer.DialContext
+	}
+	if c.tlsHandshakeTimeout > 0 {
+		transport.TLSHandshakeTimeout = c.tlsHandshakeTimeout
+	}
+	if c.insecureSkipVerify {
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		transport.TLSClientConfig.InsecureSkipVerify = true
+		c.log().Warn("invoiceninja: TLS certificate verification is disabled; use WithInsecureSkipVerify only for local development",
+			"base_url", c.BaseURL())
+	}
+
+	httpClient := *c.httpClient
+	httpClient.Transport = transport
+	c.httpClient = &httpClient
+}
+
+// log returns the configured logger or slog.Default().
+func (c *Client) log() *slog.Logger {
+	if c.logger != nil {
+		return c.logger
+	}
+	return slog.Default()
+}
+
+// ServerVersion returns the Invoice Ninja version reported by the server in
+// the X-App-Version (or X-Api-Version) header of the most recent response.
+// It is empty until a request has completed or if the server sends neither
+// header. Use it to adapt to older self-hosted instances.
+func (c *Client) ServerVersion() string {
+	v, _ := c.serverVersion.Load().(string)
+	return v
+}
+
+// recordServerVersion stores the version from response headers, if present.
+func (c *Client) recordServerVersion(header http.Header) {
+	version := header.Get("X-App-Version")
+	if version == "" {
+		version = header.Get("X-Api-Version")
+	}
+	if version != "" {
+		c.serverVersion.Store(version)
+	}
+}
+
+// apiPath builds a service endpoint path from the API prefix and a path
+// relative to it, formatted with args like fmt.Sprintf.
+func (c *Client) apiPath(format string, args ...interface{}) string {
+	if len(args) == 0 {
+		return c.apiPrefix + format
+	}
+	return c.apiPrefix + fmt.Sprintf(format, args...)
+}
+
+// BaseURL returns the API base URL requests are sent to.
+func (c *Client) BaseURL() string {
+	return c.baseURL.Load().(string)
+}
+
+// Close releases idle connections held by the underlying transport. Call it
+// when done with a Client in short-lived programs and tests. The Client stays
+// usable; later requests open new connections. Close does nothing if the
+// transport does not support closing idle connections.
+func (c *Client) Close() {
+	c.httpClient.CloseIdleConnections()
 }
 
 // SetBaseURL sets the API base URL. Use this for self-hosted instances.
+// It is safe to call concurrently with requests; requests already started
+// keep the URL they were built with. The URL is normalized and checked like
+// one passed to WithBaseURL; an invalid URL is ignored with a warning.
 func (c *Client) SetBaseURL(baseURL string) {
-	c.baseURL = strings.TrimSuffix(baseURL, "/")
+	normalized, err := normalizeBaseURL(baseURL)
+	if err != nil {
+		c.log().Warn("invoiceninja: ignoring invalid base URL", "error", err, "base_url", c.BaseURL())
+		return
+	}
+	c.baseURL.Store(normalized)
+	c.warnBaseURLPath()
+}
+
+// normalizeBaseURL validates an API base URL and returns it with a lowercase
+// scheme and host and without trailing slashes.
+func normalizeBaseURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if !strings.Contains(raw, "://") {
+		return "", fmt.Errorf("%w %q: missing scheme, e.g. https://", ErrInvalidBaseURL, raw)
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("%w %q: %v", ErrInvalidBaseURL, raw, err)
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("%w %q: scheme must be http or https", ErrInvalidBaseURL, raw)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("%w %q: missing host", ErrInvalidBaseURL, raw)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("%w %q: must not have a query or fragment", ErrInvalidBaseURL, raw)
+	}
+	u.Host = strings.ToLower(u.Host)
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = ""
+	return u.String(), nil
+}
+
+// warnBaseURLPath logs a warning if the base URL has a path, which is usually
+// a copied API URL such as https://host/api/v1 rather than the instance root.
+func (c *Client) warnBaseURLPath() {
+	u, err := url.Parse(c.BaseURL())
+	if err != nil || u.Path == "" {
+		return
+	}
+	c.log().Warn("invoiceninja: base URL has a path; API paths such as "+c.apiPrefix+" are appended to it",
+		"base_url", c.BaseURL())
 }
 
 // Request performs a generic API request.
 // This method can be used to access any API endpoint not covered by specialized methods.
-func (c *Client) Request(ctx context.Context, method, path string, body interface{}, result interface{}) error {
-	return c.doRequest(ctx, method, path, nil, body, result)
+//
+// The response is decoded into result unless it is nil. A 204 No Content
+// leaves result unchanged, while a 200 OK or 201 Created with an empty body
+// returns an error matching ErrEmptyResponse.
+func (c *Client) Request(ctx context.Context, method, path string, body interface{}, result interface{}, opts ...RequestOption) error {
+	return c.doRequest(ctx, method, path, nil, body, result, opts...)
 }
 
 // RequestWithQuery performs a generic API request with query parameters.
-func (c *Client) RequestWithQuery(ctx context.Context, method, path string, query url.Values, body interface{}, result interface{}) error {
-	return c.doRequest(ctx, method, path, query, body, result)
+func (c *Client) RequestWithQuery(ctx context.Context, method, path string, query url.Values, body interface{}, result interface{}, opts ...RequestOption) error {
+	return c.doRequest(ctx, method, path, query, body, result, opts...)
+}
+
+// Do performs a generic API request for an endpoint that returns a single
+// entity wrapped in a "data" object, and returns the decoded entity. It is a
+// typed alternative to Request for endpoints the SDK does not wrap yet:
+//
+//	type Expense struct {
+//		ID     string  `json:"id"`
+//		Amount float64 `json:"amount"`
+//	}
+//
+//	expense, err := invoiceninja.Do[Expense](ctx, client, "GET", "/api/v1/expenses/"+id, nil)
+func Do[T any](ctx context.Context, c *Client, method, path string, body interface{}, opts ...RequestOption) (*T, error) {
+	var resp SingleResponse[T]
+	if err := c.doRequest(ctx, method, path, nil, body, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// DoList performs a generic API request for an endpoint that returns a list
+// of entities, such as a list or bulk endpoint, and returns the decoded page
+// including its pagination metadata. The path may carry a query string:
+//
+//	expenses, err := invoiceninja.DoList[Expense](ctx, client, "GET", "/api/v1/expenses?per_page=50", nil)
+func DoList[T any](ctx context.Context, c *Client, method, path string, body interface{}, opts ...RequestOption) (*ListResponse[T], error) {
+	var resp ListResponse[T]
+	if err := c.doRequest(ctx, method, path, nil, body, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
 }
 
 // doRequest performs the actual HTTP request.
-func (c *Client) doRequest(ctx context.Context, method, path string, query url.Values, body, result interface{}) error {
+func (c *Client) doRequest(ctx context.Context, method, path string, query url.Values, body, result interface{}, opts ...RequestOption) error {
+	if c.validateRequests && query.Get("sort") != "" {
+		if err := ValidateSort(query.Get("sort")); err != nil {
+			return err
+		}
+	}
+
 	// Build URL
-	u, err := url.Parse(c.baseURL + path)
+	u, err := url.Parse(c.BaseURL() + path)
 	if err != nil {
 		return fmt.Errorf("invalid URL: %w", err)
 	}
@@ -162,52 +809,197 @@ func (c *Client) doRequest(ctx context.Context, method, path string, query url.V
 	}
 
 	// Prepare request body
-	var bodyReader io.Reader
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, marshalErr := json.Marshal(body)
+		var marshalErr error
+		jsonBody, marshalErr = c.jsonCodec().Marshal(body)
 		if marshalErr != nil {
 			return fmt.Errorf("failed to marshal request body: %w", marshalErr)
 		}
+	}
+
+	// Make a cached GET conditional on the stored ETag. Requests with per-call
+	// options are neither cached nor shared since they may differ in headers.
+	plainGet := method == http.MethodGet && body == nil && len(opts) == 0
+	var cached *CachedResponse
+	if c.cache != nil && plainGet {
+		if entry, ok := c.cache.Get(u.String()); ok {
+			cached = &entry
+			opts = []RequestOption{WithHeader("If-None-Match", entry.ETag)}
+		}
+	}
+
+	// Execute request, sharing identical in-flight GETs when coalescing is enabled.
+	start := time.Now()
+	var resp *rawResponse
+	if c.coalescer != nil && plainGet {
+		resp, err = c.coalescer.do(method+" "+u.String(), func() (*rawResponse, error) {
+			return c.send(ctx, method, u.String(), nil, opts...)
+		})
+	} else {
+		resp, err = c.send(ctx, method, u.String(), jsonBody, opts...)
+	}
+	c.observeRequest(method, path, resp, start)
+	if err != nil {
+		return err
+	}
+
+	if c.cache != nil && plainGet {
+		resp = c.applyCache(u.String(), resp, cached)
+	}
+	recordResponseMeta(ctx, resp)
+
+	// Check for errors
+	if resp.statusCode >= 400 {
+		return parseAPIError(c.jsonCodec(), resp.statusCode, resp.header, resp.body)
+	}
+	if job := pendingJob(c.jsonCodec(), resp); job != nil {
+		return job
+	}
+
+	// Parse response. A 204 No Content, or any other status that carries no
+	// entity, leaves result untouched; an empty 200 or 201 is an error.
+	if result == nil {
+		return nil
+	}
+	if len(bytes.TrimSpace(resp.body)) == 0 {
+		if resp.statusCode == http.StatusOK || resp.statusCode == http.StatusCreated {
+			return fmt.Errorf("%s %s: %w", method, path, ErrEmptyResponse)
+		}
+		return nil
+	}
+	if err := c.jsonCodec().Unmarshal(resp.body, result); err != nil {
+		return newDecodeError(method, path, resp.body, err, c.apiToken)
+	}
+
+	return nil
+}
+
+// applyCache substitutes the cached body for a 304 Not Modified response to a
+// conditional GET and stores successful responses that carry an ETag.
+func (c *Client) applyCache(key string, resp *rawResponse, cached *CachedResponse) *rawResponse {
+	if resp.statusCode == http.StatusNotModified && cached != nil {
+		return &rawResponse{statusCode: http.StatusOK, header: resp.header, body: cached.Body}
+	}
+
+	if etag := resp.header.Get("ETag"); resp.statusCode == http.StatusOK && etag != "" {
+		c.cache.Set(key, CachedResponse{ETag: etag, Body: resp.body})
+	}
+	return resp
+}
+
+// rawResponse holds the parts of an HTTP response needed after the body is read.
+type rawResponse struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+// send creates and executes a JSON API request and reads the full response
+// body, up to the WithMaxResponseBytes limit.
+func (c *Client) send(ctx context.Context, method, rawURL string, jsonBody []byte, opts ...RequestOption) (*rawResponse, error) {
+	return c.sendLimited(ctx, method, rawURL, jsonBody, c.maxResponseBytes, opts...)
+}
+
+// sendLimited is send with a limit on the response body size, so requests
+// that may return a file can be bound by WithMaxDownloadBytes instead.
+func (c *Client) sendLimited(ctx context.Context, method, rawURL string, jsonBody []byte, limit int64, opts ...RequestOption) (*rawResponse, error) {
+	var bodyReader io.Reader
+	if jsonBody != nil {
 		bodyReader = bytes.NewReader(jsonBody)
 	}
 
+	override := ""
+	if c.methodOverride && (method == http.MethodPut || method == http.MethodPatch || method == http.MethodDelete) {
+		override, method = method, http.MethodPost
+	}
+
 	// Create request
-	req, err := http.NewRequestWithContext(ctx, method, u.String(), bodyReader)
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, bodyReader)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers
+	c.setDefaultHeaders(req)
+	setRequestID(req)
 	req.Header.Set("X-API-TOKEN", c.apiToken)
 	req.Header.Set("X-Requested-With", "XMLHttpRequest")
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", "go-invoice-ninja/"+Version)
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+	req.Header.Set("User-Agent", c.userAgentHeader())
+	if override != "" {
+		req.Header.Set("X-HTTP-Method-Override", override)
+	}
+
+	for _, opt := range opts {
+		opt(req)
+	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
-	// Read response body
-	respBody, err := io.ReadAll(resp.Body)
+	c.recordServerVersion(resp.Header)
+
+	// Read response body, decompressing it since Accept-Encoding was set explicitly
+	body, err := decodeBody(resp)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
 	}
+	defer body.Close()
 
-	// Check for errors
-	if resp.StatusCode >= 400 {
-		return parseAPIError(resp.StatusCode, respBody)
+	respBody, err := readLimited(body, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	// Parse response
-	if result != nil && len(respBody) > 0 {
-		if err := json.Unmarshal(respBody, result); err != nil {
-			return fmt.Errorf("failed to unmarshal response: %w", err)
+	return &rawResponse{
+		statusCode: resp.StatusCode,
+		header:     resp.Header,
+		body:       respBody,
+	}, nil
+}
+
+// decodeBody returns a reader over the decompressed response body according to
+// its Content-Encoding. The transport only decompresses transparently when it
+// set Accept-Encoding itself, so this also covers custom transports injected
+// with WithHTTPClient.
+func decodeBody(resp *http.Response) (io.ReadCloser, error) {
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))) {
+	case "gzip", "x-gzip":
+		r, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress gzip response: %w", err)
 		}
+		return r, nil
+	case "deflate":
+		r, err := zlib.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress deflate response: %w", err)
+		}
+		return r, nil
+	default:
+		return io.NopCloser(resp.Body), nil
 	}
+}
 
-	return nil
+// setDefaultHeaders copies the headers configured with WithDefaultHeader onto req.
+func (c *Client) setDefaultHeaders(req *http.Request) {
+	for key, values := range c.defaultHeaders {
+		req.Header[key] = append([]string(nil), values...)
+	}
+}
+
+// userAgentHeader returns the User-Agent header value for outgoing requests.
+func (c *Client) userAgentHeader() string {
+	sdk := "go-invoice-ninja/" + Version
+	if c.userAgent == "" {
+		return sdk
+	}
+	return c.userAgent + " (" + sdk + ")"
 }
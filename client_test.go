@@ -1,11 +1,20 @@
 package invoiceninja
 
 import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestNewClient(t *testing.T) {
@@ -15,8 +24,8 @@ func TestNewClient(t *testing.T) {
 		t.Errorf("expected apiToken to be 'test-token', got '%s'", client.apiToken)
 	}
 
-	if client.baseURL != DefaultBaseURL {
-		t.Errorf("expected baseURL to be '%s', got '%s'", DefaultBaseURL, client.baseURL)
+	if client.BaseURL() != DefaultBaseURL {
+		t.Errorf("expected baseURL to be '%s', got '%s'", DefaultBaseURL, client.BaseURL())
 	}
 }
 
@@ -33,8 +42,8 @@ func TestNewClientWithOptions(t *testing.T) {
 		t.Error("expected custom HTTP client to be set")
 	}
 
-	if client.baseURL != customURL {
-		t.Errorf("expected baseURL to be '%s', got '%s'", customURL, client.baseURL)
+	if client.BaseURL() != customURL {
+		t.Errorf("expected baseURL to be '%s', got '%s'", customURL, client.BaseURL())
 	}
 }
 
@@ -44,8 +53,102 @@ func TestSetBaseURL(t *testing.T) {
 	client.SetBaseURL("https://custom.example.com/")
 
 	// Should trim trailing slash
-	if client.baseURL != "https://custom.example.com" {
-		t.Errorf("expected baseURL to be 'https://custom.example.com', got '%s'", client.baseURL)
+	if client.BaseURL() != "https://custom.example.com" {
+		t.Errorf("expected baseURL to be 'https://custom.example.com', got '%s'", client.BaseURL())
+	}
+}
+
+func TestNormalizeBaseURL(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"https://invoices.example.com", "https://invoices.example.com", false},
+		{" HTTPS://Invoices.Example.com// ", "https://invoices.example.com", false},
+		{"http://localhost:8000/", "http://localhost:8000", false},
+		{"https://example.com/ninja/", "https://example.com/ninja", false},
+		{"invoices.example.com", "", true},
+		{"localhost:8000", "", true},
+		{"ftp://example.com", "", true},
+		{"https://", "", true},
+		{"https://example.com/?company=1", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := normalizeBaseURL(tt.in)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidBaseURL) {
+				t.Errorf("normalizeBaseURL(%q): expected ErrInvalidBaseURL, got %q, %v", tt.in, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("normalizeBaseURL(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestNewClientWithError(t *testing.T) {
+	client, err := NewClientWithError("test-token", WithBaseURL("https://Invoices.Example.com/"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if client.BaseURL() != "https://invoices.example.com" {
+		t.Errorf("expected normalized base URL, got %q", client.BaseURL())
+	}
+
+	client, err = NewClientWithError("test-token", WithBaseURL("invoices.example.com"))
+	if !errors.Is(err, ErrInvalidBaseURL) || client != nil {
+		t.Errorf("expected ErrInvalidBaseURL and no client, got %v, %v", client, err)
+	}
+}
+
+func TestInvalidBaseURLFallsBack(t *testing.T) {
+	var logs bytes.Buffer
+	logger := WithLogger(slog.New(slog.NewTextHandler(&logs, nil)))
+
+	client := NewClient("test-token", logger, WithBaseURL("invoices.example.com"))
+	if client.BaseURL() != DefaultBaseURL {
+		t.Errorf("expected the default base URL, got %q", client.BaseURL())
+	}
+	if !strings.Contains(logs.String(), "ignoring invalid base URL") {
+		t.Errorf("expected a warning for the invalid URL, got %q", logs.String())
+	}
+
+	logs.Reset()
+	client.SetBaseURL("https://invoices.example.com")
+	client.SetBaseURL("ftp://invoices.example.com")
+	if client.BaseURL() != "https://invoices.example.com" {
+		t.Errorf("expected SetBaseURL to keep the previous URL, got %q", client.BaseURL())
+	}
+	if !strings.Contains(logs.String(), "scheme must be http or https") {
+		t.Errorf("expected a warning for the invalid URL, got %q", logs.String())
+	}
+
+	if derived := client.With(WithBaseURL("not a url")); derived.BaseURL() != client.BaseURL() {
+		t.Errorf("expected With to keep the base URL, got %q", derived.BaseURL())
+	}
+}
+
+func TestBaseURLWithPathWarns(t *testing.T) {
+	var logs bytes.Buffer
+	logger := WithLogger(slog.New(slog.NewTextHandler(&logs, nil)))
+
+	client := NewClient("test-token", logger, WithBaseURL("https://invoices.example.com/api/v1/"))
+	if client.BaseURL() != "https://invoices.example.com/api/v1" {
+		t.Errorf("expected the path to be kept, got %q", client.BaseURL())
+	}
+	if !strings.Contains(logs.String(), "base URL has a path") {
+		t.Errorf("expected a warning for the path, got %q", logs.String())
+	}
+
+	logs.Reset()
+	client.SetBaseURL("https://invoices.example.com")
+	client.With(WithDefaultHeader("X-Test", "1"))
+	if logs.Len() != 0 {
+		t.Errorf("expected no warning without a path, got %q", logs.String())
 	}
 }
 
@@ -139,3 +242,770 @@ func TestClientRequestWithBody(t *testing.T) {
 		t.Errorf("unexpected error: %v", err)
 	}
 }
+
+func TestEmptyResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := r.URL.Query().Get("status")
+		if header := r.Header.Get("X-Test-Status"); header != "" {
+			status = header
+		}
+		switch status {
+		case "204":
+			w.WriteHeader(http.StatusNoContent)
+		case "201":
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	// Calls that expect no data succeed on an empty 200 or 204
+	for _, status := range []string{"200", "204"} {
+		if err := client.Payments.Delete(ctx, "pay1", WithHeader("X-Test-Status", status)); err != nil {
+			t.Errorf("Delete with empty %s: unexpected error: %v", status, err)
+		}
+		if err := client.Request(ctx, "DELETE", "/api/v1/products/p1?status="+status, nil, nil); err != nil {
+			t.Errorf("Request with empty %s: unexpected error: %v", status, err)
+		}
+	}
+
+	// A 204 leaves the result untouched
+	result := map[string]interface{}{"kept": true}
+	if err := client.Request(ctx, "GET", "/api/v1/products?status=204", nil, &result); err != nil {
+		t.Errorf("expected no error for 204, got %v", err)
+	}
+	if result["kept"] != true {
+		t.Errorf("expected the result to be unchanged, got %v", result)
+	}
+
+	// An empty 200 or 201 is an error when data was expected
+	if _, err := client.Invoices.Get(ctx, "inv1"); !errors.Is(err, ErrEmptyResponse) {
+		t.Errorf("expected ErrEmptyResponse for an empty 200, got %v", err)
+	}
+	if err := client.Request(ctx, "POST", "/api/v1/products?status=201", nil, &result); !errors.Is(err, ErrEmptyResponse) {
+		t.Errorf("expected ErrEmptyResponse for an empty 201, got %v", err)
+	}
+}
+
+func TestClientUserAgent(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []ClientOption
+		expected string
+	}{
+		{
+			name:     "default",
+			expected: "go-invoice-ninja/" + Version,
+		},
+		{
+			name:     "custom",
+			opts:     []ClientOption{WithUserAgent("myapp/2.1")},
+			expected: "myapp/2.1 (go-invoice-ninja/" + Version + ")",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = append(got, r.Header.Get("User-Agent"))
+				w.Header().Set("Content-Type", "application/pdf")
+				w.Write([]byte("{}"))
+			}))
+			defer server.Close()
+
+			opts := append([]ClientOption{WithBaseURL(server.URL)}, tt.opts...)
+			client := NewClient("test-token", opts...)
+
+			if err := client.Request(context.Background(), "GET", "/test", nil, nil); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, err := client.Downloads.DownloadInvoicePDF(context.Background(), "key"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := client.Uploads.UploadDocumentFromReader(context.Background(), "invoices", "inv1", "a.txt", strings.NewReader("x")); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for i, ua := range got {
+				if ua != tt.expected {
+					t.Errorf("request %d: expected User-Agent %q, got %q", i, tt.expected, ua)
+				}
+			}
+		})
+	}
+}
+
+func TestClientDialTimeout(t *testing.T) {
+	// 10.255.255.1 is a non-routable address, so connecting hangs until the dial timeout.
+	client := NewClient("test-token",
+		WithBaseURL("http://10.255.255.1"),
+		WithTimeout(time.Minute),
+		WithDialTimeout(100*time.Millisecond),
+	)
+
+	start := time.Now()
+	err := client.Request(context.Background(), "GET", "/api/v1/ping", nil, nil)
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("expected error connecting to unroutable host")
+	}
+
+	if elapsed > 5*time.Second {
+		t.Errorf("expected dial to fail fast, took %v", elapsed)
+	}
+}
+
+func TestClientConnectionTimeoutsConfigureTransport(t *testing.T) {
+	base := &http.Transport{MaxIdleConns: 7}
+	httpClient := &http.Client{Transport: base, Timeout: time.Minute}
+
+	client := NewClient("test-token",
+		WithHTTPClient(httpClient),
+		WithDialTimeout(2*time.Second),
+		WithTLSHandshakeTimeout(3*time.Second),
+	)
+
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected *http.Transport, got %T", client.httpClient.Transport)
+	}
+
+	if transport.TLSHandshakeTimeout != 3*time.Second {
+		t.Errorf("expected TLS handshake timeout 3s, got %v", transport.TLSHandshakeTimeout)
+	}
+
+	if transport.DialContext == nil {
+		t.Error("expected custom DialContext")
+	}
+
+	if transport.MaxIdleConns != 7 {
+		t.Errorf("expected transport settings to be preserved, got MaxIdleConns %d", transport.MaxIdleConns)
+	}
+
+	if client.httpClient.Timeout != time.Minute {
+		t.Errorf("expected overall timeout to be preserved, got %v", client.httpClient.Timeout)
+	}
+
+	if httpClient.Transport != base || base.TLSHandshakeTimeout != 0 {
+		t.Error("expected caller's HTTP client to be left unmodified")
+	}
+}
+
+func TestWithInsecureSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": []}`))
+	}))
+	defer server.Close()
+
+	// The test server's certificate is self-signed
+	strict := NewClient("test-token", WithBaseURL(server.URL))
+	if _, err := strict.Invoices.List(context.Background(), nil); err == nil {
+		t.Fatal("expected certificate verification to fail by default")
+	}
+
+	var logs bytes.Buffer
+	client := NewClient("test-token",
+		WithBaseURL(server.URL),
+		WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+		WithInsecureSkipVerify(),
+	)
+
+	if _, err := client.Invoices.List(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(logs.String(), "level=WARN") || !strings.Contains(logs.String(), "TLS certificate verification is disabled") {
+		t.Errorf("expected a warning to be logged, got %q", logs.String())
+	}
+	if transport := http.DefaultTransport.(*http.Transport); transport.TLSClientConfig != nil && transport.TLSClientConfig.InsecureSkipVerify {
+		t.Error("expected the default transport to be left unmodified")
+	}
+}
+
+func TestRequestOptionsOnWriteMethods(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Method+" "+r.URL.Path+" "+r.Header.Get("X-Company-Key"))
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/bulk") {
+			w.Write([]byte(`{"data": [{"id": "inv1"}]}`))
+			return
+		}
+		w.Write([]byte(`{"data": {"id": "inv1"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	ctx := context.Background()
+	opt := WithHeader("X-Company-Key", "company1")
+
+	if _, err := client.Invoices.Create(ctx, &Invoice{ClientID: "c1"}, opt); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := client.Invoices.Update(ctx, "inv1", &Invoice{}, opt); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := client.Invoices.Delete(ctx, "inv1", opt); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := client.Invoices.Bulk(ctx, "archive", []string{"inv1"}, opt); err != nil {
+		t.Fatalf("Bulk: %v", err)
+	}
+	if _, err := client.Invoices.BulkChunked(ctx, "archive", []string{"inv1"}, 0, opt); err != nil {
+		t.Fatalf("BulkChunked: %v", err)
+	}
+	if _, err := client.Invoices.Get(ctx, "inv1"); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	expected := []string{
+		"POST /api/v1/invoices company1",
+		"PUT /api/v1/invoices/inv1 company1",
+		"DELETE /api/v1/invoices/inv1 company1",
+		"POST /api/v1/invoices/bulk company1",
+		"POST /api/v1/invoices/bulk company1",
+		"GET /api/v1/invoices/inv1 ",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected requests:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestRequestOptionOverridesDefaultHeader(t *testing.T) {
+	var accept string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept = r.Header.Get("Accept")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	if err := client.Request(context.Background(), "GET", "/api/v1/ping", nil, nil, WithHeader("Accept", "text/plain")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if accept != "text/plain" {
+		t.Errorf("expected Accept text/plain, got %q", accept)
+	}
+}
+
+func TestClientServerVersion(t *testing.T) {
+	versions := []http.Header{
+		{"X-App-Version": {"5.8.1"}},
+		{},
+		{"X-Api-Version": {"5.9.0"}},
+		{"X-App-Version": {"5.10.2"}, "X-Api-Version": {"5.10.0"}},
+	}
+	var call int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for k, v := range versions[call] {
+			w.Header()[k] = v
+		}
+		call++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	if v := client.ServerVersion(); v != "" {
+		t.Errorf("expected empty version before any request, got %q", v)
+	}
+
+	// A response without version headers keeps the last known version
+	for _, expected := range []string{"5.8.1", "5.8.1", "5.9.0", "5.10.2"} {
+		if err := client.Request(context.Background(), "GET", "/api/v1/ping", nil, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if v := client.ServerVersion(); v != expected {
+			t.Errorf("request %d: expected version %q, got %q", call, expected, v)
+		}
+	}
+}
+
+func TestWithAPIPrefix(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if !strings.HasPrefix(r.URL.Path, "/api/v2/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v2/invoices":
+			w.Write([]byte(`{"data":[{"id":"inv1"}],"meta":{"pagination":{"total_pages":1}}}`))
+		default:
+			w.Write([]byte(`{"data":{"id":"inv1"}}`))
+		}
+	}))
+	defer server.Close()
+
+	for _, prefix := range []string{"/api/v2", "api/v2/"} {
+		paths = nil
+		client := NewClient("test-token", WithBaseURL(server.URL), WithAPIPrefix(prefix))
+		ctx := context.Background()
+
+		if _, err := client.Invoices.List(ctx, nil); err != nil {
+			t.Fatalf("prefix %q: List failed: %v", prefix, err)
+		}
+		if _, err := client.Invoices.Get(ctx, "inv1"); err != nil {
+			t.Fatalf("prefix %q: Get failed: %v", prefix, err)
+		}
+		if err := client.Ping(ctx); err != nil {
+			t.Fatalf("prefix %q: Ping failed: %v", prefix, err)
+		}
+
+		expected := []string{"/api/v2/invoices", "/api/v2/invoices/inv1", "/api/v2/ping"}
+		if strings.Join(paths, ",") != strings.Join(expected, ",") {
+			t.Errorf("prefix %q: expected paths %v, got %v", prefix, expected, paths)
+		}
+	}
+}
+
+func TestDefaultAPIPrefix(t *testing.T) {
+	client := NewClient("test-token")
+	if got := client.apiPath("/invoices/%s", "abc"); got != "/api/v1/invoices/abc" {
+		t.Errorf("expected /api/v1/invoices/abc, got %s", got)
+	}
+}
+
+func TestClientDecompressesResponses(t *testing.T) {
+	payload := `{"data":[{"id":"pay1","amount":100}],"meta":{"pagination":{"total":1}}}`
+
+	tests := []struct {
+		name     string
+		encoding string
+		compress func(w io.Writer) io.WriteCloser
+	}{
+		{"gzip", "gzip", func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) }},
+		{"deflate", "deflate", func(w io.Writer) io.WriteCloser { return zlib.NewWriter(w) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.Contains(r.Header.Get("Accept-Encoding"), tt.encoding) {
+					t.Errorf("expected Accept-Encoding to include %s, got %q", tt.encoding, r.Header.Get("Accept-Encoding"))
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Content-Encoding", tt.encoding)
+				zw := tt.compress(w)
+				zw.Write([]byte(payload))
+				zw.Close()
+			}))
+			defer server.Close()
+
+			// A custom transport does not decompress on its own
+			httpClient := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+			client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(httpClient))
+
+			resp, err := client.Payments.List(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(resp.Data) != 1 || resp.Data[0].ID != "pay1" || resp.Data[0].Amount != 100 {
+				t.Errorf("unexpected payments: %+v", resp.Data)
+			}
+		})
+	}
+}
+
+func TestClientRejectsCorruptGzip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write([]byte("not gzip"))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	err := client.Request(context.Background(), "GET", "/api/v1/payments", nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "decompress") {
+		t.Errorf("expected decompression error, got %v", err)
+	}
+}
+
+func TestWithDefaultHeader(t *testing.T) {
+	var headers []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Clone())
+		if strings.Contains(r.URL.Path, "/invoice/") {
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Write([]byte("%PDF-1.4"))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token",
+		WithBaseURL(server.URL),
+		WithDefaultHeader("CF-Access-Client-Id", "client-id"),
+		WithDefaultHeader("CF-Access-Client-Secret", "client-secret"),
+		WithDefaultHeader("X-API-TOKEN", "proxy-token"),
+	)
+	ctx := context.Background()
+
+	if err := client.Request(ctx, "GET", "/api/v1/ping", nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Downloads.DownloadInvoicePDF(ctx, "inv-key"); err != nil {
+		t.Fatalf("unexpected download error: %v", err)
+	}
+	if err := client.Uploads.UploadDocumentFromReader(ctx, "invoices", "inv1", "a.txt", strings.NewReader("x")); err != nil {
+		t.Fatalf("unexpected upload error: %v", err)
+	}
+
+	if len(headers) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(headers))
+	}
+	for i, h := range headers {
+		if got := h.Get("CF-Access-Client-Id"); got != "client-id" {
+			t.Errorf("request %d: expected CF-Access-Client-Id client-id, got %q", i, got)
+		}
+		if got := h.Get("CF-Access-Client-Secret"); got != "client-secret" {
+			t.Errorf("request %d: expected CF-Access-Client-Secret client-secret, got %q", i, got)
+		}
+		if got := h.Values("X-API-TOKEN"); len(got) != 1 || got[0] != "test-token" {
+			t.Errorf("request %d: expected X-API-TOKEN test-token, got %v", i, got)
+		}
+	}
+}
+
+// TestClientConcurrentUse is meant to be run with -race.
+func TestClientConcurrentUse(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-App-Version", "5.10.0")
+		w.Write([]byte(`{"data":{"id":"pay1"}}`))
+	})
+	server1 := httptest.NewServer(handler)
+	defer server1.Close()
+	server2 := httptest.NewServer(handler)
+	defer server2.Close()
+
+	client := NewClient("test-token", WithBaseURL(server1.URL))
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 40)
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Payments.Get(ctx, "pay1"); err != nil {
+				errs <- err
+			}
+			client.ServerVersion()
+		}()
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				client.SetBaseURL(server2.URL)
+			} else {
+				client.SetBaseURL(server1.URL)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if got := client.BaseURL(); got != server1.URL && got != server2.URL {
+		t.Errorf("unexpected base URL %q", got)
+	}
+}
+
+func TestDoAndDoList(t *testing.T) {
+	type design struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v1/designs":
+			if r.URL.Query().Get("per_page") != "2" {
+				t.Errorf("expected per_page=2, got %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"data":[{"id":"d1","name":"Clean"},{"id":"d2","name":"Bold"}],"meta":{"pagination":{"total":5,"total_pages":3}}}`))
+		case r.Method == "PUT" && r.URL.Path == "/api/v1/designs/d1":
+			var body design
+			json.NewDecoder(r.Body).Decode(&body)
+			w.Write([]byte(`{"data":{"id":"d1","name":"` + body.Name + `"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	list, err := DoList[design](ctx, client, "GET", "/api/v1/designs?per_page=2", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list.Data) != 2 || list.Data[1].Name != "Bold" || list.Meta.Pagination.TotalPages != 3 {
+		t.Errorf("unexpected list response: %+v", list)
+	}
+
+	updated, err := Do[design](ctx, client, "PUT", "/api/v1/designs/d1", design{Name: "Modern"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.ID != "d1" || updated.Name != "Modern" {
+		t.Errorf("unexpected design: %+v", updated)
+	}
+
+	_, err = Do[design](ctx, client, "GET", "/api/v1/designs/missing", nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404 *APIError, got %v", err)
+	}
+}
+
+func TestWithTransport(t *testing.T) {
+	transport := DefaultTransport()
+	if transport.MaxIdleConnsPerHost != 32 || transport.MaxIdleConns != 100 {
+		t.Errorf("unexpected pool sizes: %d per host, %d total", transport.MaxIdleConnsPerHost, transport.MaxIdleConns)
+	}
+	if transport == http.DefaultTransport {
+		t.Error("expected DefaultTransport to return a copy")
+	}
+
+	custom := &http.Client{Timeout: 5 * time.Second}
+	for _, opts := range [][]ClientOption{
+		{WithHTTPClient(custom), WithTransport(transport)},
+		{WithTransport(transport), WithHTTPClient(custom)},
+	} {
+		client := NewClient("test-token", opts...)
+		if client.httpClient.Transport != transport {
+			t.Error("expected the transport to be used")
+		}
+		if client.httpClient.Timeout != 5*time.Second {
+			t.Errorf("expected the HTTP client's timeout to be kept, got %v", client.httpClient.Timeout)
+		}
+	}
+	if custom.Transport != nil {
+		t.Error("expected the HTTP client passed to WithHTTPClient to be unmodified")
+	}
+
+	// Connection timeouts apply to a clone, leaving the shared transport untouched
+	client := NewClient("test-token", WithTransport(transport), WithTLSHandshakeTimeout(time.Second))
+	configured, ok := client.httpClient.Transport.(*http.Transport)
+	if !ok || configured == transport || configured.TLSHandshakeTimeout != time.Second {
+		t.Errorf("expected a configured clone of the transport, got %+v", client.httpClient.Transport)
+	}
+	if configured.MaxIdleConnsPerHost != 32 {
+		t.Errorf("expected the clone to keep pool sizes, got %d", configured.MaxIdleConnsPerHost)
+	}
+}
+
+// BenchmarkConcurrentRequests compares the standard transport, which keeps two
+// idle connections per host, with DefaultTransport under concurrent load.
+func BenchmarkConcurrentRequests(b *testing.B) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"id":"pay1"}}`))
+	}))
+	defer server.Close()
+
+	for _, bm := range []struct {
+		name      string
+		transport func() *http.Transport
+	}{
+		{"StandardTransport", func() *http.Transport { return http.DefaultTransport.(*http.Transport).Clone() }},
+		{"DefaultTransport", DefaultTransport},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			transport := bm.transport()
+			defer transport.CloseIdleConnections()
+			client := NewClient("test-token", WithBaseURL(server.URL), WithTransport(transport))
+			ctx := context.Background()
+
+			b.SetParallelism(16)
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, err := client.Payments.Get(ctx, "pay1"); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
+
+// closeRecorder is a RoundTripper that counts CloseIdleConnections calls.
+type closeRecorder struct {
+	http.RoundTripper
+	closed int
+}
+
+func (r *closeRecorder) CloseIdleConnections() {
+	r.closed++
+}
+
+func TestClientClose(t *testing.T) {
+	recorder := &closeRecorder{RoundTripper: http.DefaultTransport}
+	client := NewClient("test-token", WithHTTPClient(&http.Client{Transport: recorder}))
+
+	client.Close()
+	if recorder.closed != 1 {
+		t.Errorf("expected idle connections to be closed once, got %d", recorder.closed)
+	}
+
+	// Transports without CloseIdleConnections are left alone
+	plain := NewClient("test-token", WithHTTPClient(&http.Client{
+		Transport: roundTripFunc(func(*http.Request) (*http.Response, error) { return nil, nil }),
+	}))
+	plain.Close()
+}
+
+func TestClientUsableAfterClose(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"id":"pay1"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithTransport(DefaultTransport()))
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Payments.Get(ctx, "pay1"); err != nil {
+			t.Fatalf("request %d: unexpected error: %v", i, err)
+		}
+		client.Close()
+	}
+}
+
+// roundTripFunc adapts a function to http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestClientWith(t *testing.T) {
+	newServer := func(name string, headers chan<- string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			headers <- name + ":" + r.Header.Get("X-Company") + ":" + r.Header.Get("X-API-TOKEN")
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"data": []}`))
+		}))
+	}
+
+	seen := make(chan string, 2)
+	serverA := newServer("a", seen)
+	defer serverA.Close()
+	serverB := newServer("b", seen)
+	defer serverB.Close()
+
+	transport := DefaultTransport()
+	parent := NewClient("test-token",
+		WithBaseURL(serverA.URL),
+		WithTransport(transport),
+		WithDefaultHeader("X-Company", "parent"),
+	)
+	child := parent.With(
+		WithBaseURL(serverB.URL),
+		WithDefaultHeader("X-Company", "acme"),
+		WithTimeout(5*time.Second),
+	)
+
+	if _, err := child.Invoices.List(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := <-seen; got != "b:acme:test-token" {
+		t.Errorf("expected child request to reach b with its header and the token, got %s", got)
+	}
+
+	if _, err := parent.Invoices.List(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := <-seen; got != "a:parent:test-token" {
+		t.Errorf("expected parent to be unchanged, got %s", got)
+	}
+
+	if child.httpClient.Transport != parent.httpClient.Transport {
+		t.Error("expected the transport to be shared")
+	}
+	if parent.httpClient.Timeout != DefaultTimeout || child.httpClient.Timeout != 5*time.Second {
+		t.Errorf("expected independent timeouts, got parent %v and child %v", parent.httpClient.Timeout, child.httpClient.Timeout)
+	}
+	if child.Payments.client != child || child.Uploads.client != child {
+		t.Error("expected the child's services to point at the child")
+	}
+}
+
+func TestClientWithTransportOptions(t *testing.T) {
+	parent := NewClient("test-token", WithTransport(DefaultTransport()))
+	child := parent.With(WithDialTimeout(time.Second))
+
+	if child.httpClient.Transport == parent.httpClient.Transport {
+		t.Error("expected a transport option to give the copy its own transport")
+	}
+	if parent.dialTimeout != 0 {
+		t.Error("expected the parent to be unchanged")
+	}
+}
+
+func TestWithMethodOverride(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Method+" "+r.Header.Get("X-HTTP-Method-Override"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "inv1"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithMethodOverride())
+	ctx := context.Background()
+
+	if _, err := client.Invoices.Update(ctx, "inv1", &Invoice{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := client.Invoices.Delete(ctx, "inv1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Invoices.Get(ctx, "inv1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Invoices.Create(ctx, &Invoice{ClientID: "c1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"POST PUT", "POST DELETE", "GET ", "POST "}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("request %d: expected %q, got %q", i, expected[i], got[i])
+		}
+	}
+
+	// Without the option, the real verbs are sent
+	got = nil
+	plain := NewClient("test-token", WithBaseURL(server.URL))
+	if _, err := plain.Invoices.Update(ctx, "inv1", &Invoice{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got[0] != "PUT " {
+		t.Errorf("expected a plain PUT, got %q", got[0])
+	}
+}
@@ -26,6 +26,17 @@ type ClientListOptions struct {
 	// Balance filters by balance (e.g., "gt:1000", "lt:500").
 	Balance string
 
+	// CreditBalance filters by unused credit, with the same operator syntax
+	// as Balance (e.g., "gt:0").
+	CreditBalance string
+
+	// PaidToDate filters by the total paid to date, with the same operator
+	// syntax as Balance (e.g., "gte:5000").
+	PaidToDate string
+
+	// AssignedUserID filters by the user the client is assigned to.
+	AssignedUserID string
+
 	// Status filters by status (comma-separated: active, archived, deleted).
 	Status string
 
@@ -43,6 +54,9 @@ type ClientListOptions struct {
 
 	// Include specifies related entities to include (contacts, documents, activities).
 	Include string
+
+	// Filters are additional query filters built with NewFilter.
+	Filters []FilterExpr
 }
 
 // toQuery converts options to URL query parameters.
@@ -65,9 +79,18 @@ func (o *ClientListOptions) toQuery() url.Values {
 	if o.Balance != "" {
 		q.Set("balance", o.Balance)
 	}
+	if o.CreditBalance != "" {
+		q.Set("credit_balance", o.CreditBalance)
+	}
+	if o.PaidToDate != "" {
+		q.Set("paid_to_date", o.PaidToDate)
+	}
 	if o.Status != "" {
 		q.Set("status", o.Status)
 	}
+	if o.AssignedUserID != "" {
+		q.Set("assigned_user", o.AssignedUserID)
+	}
 	if o.CreatedAt != "" {
 		q.Set("created_at", o.CreatedAt)
 	}
@@ -84,53 +107,163 @@ func (o *ClientListOptions) toQuery() url.Values {
 		q.Set("include", o.Include)
 	}
 
+	applyFilters(q, o.Filters)
+
 	return q
 }
 
 // List retrieves a list of clients.
 func (s *ClientsService) List(ctx context.Context, opts *ClientListOptions) (*ListResponse[INClient], error) {
 	var resp ListResponse[INClient]
-	if err := s.client.doRequest(ctx, "GET", "/api/v1/clients", opts.toQuery(), nil, &resp); err != nil {
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/clients"), opts.toQuery(), nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp, nil
 }
 
+// Iter returns an iterator over all clients matching opts, fetching pages lazily.
+// Paging starts at opts.Page (or page 1) and keeps every other option.
+func (s *ClientsService) Iter(ctx context.Context, opts *ClientListOptions) *Iterator[INClient] {
+	return newIterator[INClient](ctx, s.client, s.client.apiPath("/clients"), opts.toQuery())
+}
+
+// ListAll retrieves all clients matching opts across every page.
+// If a page fails or ctx is done mid-scan, the clients fetched so far are
+// returned together with the error.
+func (s *ClientsService) ListAll(ctx context.Context, opts *ClientListOptions) ([]INClient, error) {
+	return listAll(s.Iter(ctx, opts))
+}
+
+// Count returns the number of clients matching opts without fetching them,
+// from the pagination total of a one-item page. Paging options are ignored.
+func (s *ClientsService) Count(ctx context.Context, opts *ClientListOptions) (int, error) {
+	return count(ctx, s.client, s.client.apiPath("/clients"), opts.toQuery())
+}
+
 // Get retrieves a single client by ID.
 func (s *ClientsService) Get(ctx context.Context, id string) (*INClient, error) {
+	return s.GetWith(ctx, id)
+}
+
+// GetWith retrieves a single client by ID together with the related entities
+// named in include, e.g. "contacts", in one request.
+func (s *ClientsService) GetWith(ctx context.Context, id string, include ...string) (*INClient, error) {
 	var resp SingleResponse[INClient]
-	if err := s.client.doRequest(ctx, "GET", fmt.Sprintf("/api/v1/clients/%s", id), nil, nil, &resp); err != nil {
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/clients/%s", id), includeQuery(include), nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp.Data, nil
 }
 
+// Documents returns the documents attached to the client with the given ID,
+// fetching the client with its documents included.
+func (s *ClientsService) Documents(ctx context.Context, clientID string) ([]Document, error) {
+	entity, err := s.GetWith(ctx, clientID, "documents")
+	if err != nil {
+		return nil, err
+	}
+	return entity.Documents, nil
+}
+
 // Create creates a new client.
-func (s *ClientsService) Create(ctx context.Context, client *INClient) (*INClient, error) {
+// When the client was built with WithClientNormalization, the client is
+// normalized first and a *ValidationError is returned for invalid fields.
+// With WithClientValidation, INClient.Validate is checked before sending.
+func (s *ClientsService) Create(ctx context.Context, client *INClient, opts ...RequestOption) (*INClient, error) {
+	if s.client.normalizeClients {
+		normalized, err := client.Normalized()
+		if err != nil {
+			return nil, err
+		}
+		client = normalized
+	}
+	if s.client.validateRequests {
+		if err := client.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	var resp SingleResponse[INClient]
-	if err := s.client.doRequest(ctx, "POST", "/api/v1/clients", nil, client, &resp); err != nil {
+	if err := s.client.doRequest(ctx, "POST", s.client.apiPath("/clients"), nil, client, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp.Data, nil
 }
 
+// CreateBatch creates clients, e.g. rows of an import, reporting each result
+// separately: created[i] and errs[i] belong to clients[i], and one bad row does
+// not stop the rest. With opts.Concurrency above 1, that many creates run at
+// once. If ctx is done, the clients not yet sent fail with its error.
+func (s *ClientsService) CreateBatch(ctx context.Context, clients []*INClient, opts *BatchOptions) (created []*INClient, errs []error) {
+	return createBatch(ctx, s.client, clients, opts, func(ctx context.Context, client *INClient) (*INClient, error) {
+		return s.Create(ctx, client)
+	})
+}
+
 // Update updates an existing client.
-func (s *ClientsService) Update(ctx context.Context, id string, client *INClient) (*INClient, error) {
+func (s *ClientsService) Update(ctx context.Context, id string, client *INClient, opts ...RequestOption) (*INClient, error) {
 	var resp SingleResponse[INClient]
-	if err := s.client.doRequest(ctx, "PUT", fmt.Sprintf("/api/v1/clients/%s", id), nil, client, &resp); err != nil {
+	if err := s.client.doRequest(ctx, "PUT", s.client.apiPath("/clients/%s", id), nil, client, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp.Data, nil
 }
 
-// Delete deletes a client by ID (soft delete).
-func (s *ClientsService) Delete(ctx context.Context, id string) error {
-	return s.client.doRequest(ctx, "DELETE", fmt.Sprintf("/api/v1/clients/%s", id), nil, nil, nil)
+// Assign assigns the client to the user with the given ID by updating only its
+// assigned user.
+func (s *ClientsService) Assign(ctx context.Context, id, userID string, opts ...RequestOption) (*INClient, error) {
+	if err := validateAssignee(userID); err != nil {
+		return nil, err
+	}
+	return s.Update(ctx, id, &INClient{AssignedUserID: userID}, opts...)
+}
+
+// Delete deletes a client by ID (soft delete). The client's invoices and
+// payments are soft-deleted with it; see DeleteWithDependents.
+func (s *ClientsService) Delete(ctx context.Context, id string, opts ...RequestOption) error {
+	return s.client.doRequest(ctx, "DELETE", s.client.apiPath("/clients/%s", id), nil, nil, nil, opts...)
+}
+
+// ClientDependents lists the entities that are soft-deleted together with a client.
+type ClientDependents struct {
+	// Invoices are the client's active and archived invoices.
+	Invoices []Invoice
+
+	// Payments are the client's active and archived payments.
+	Payments []Payment
+}
+
+// DeleteWithDependents deletes a client by ID (soft delete) and reports the
+// dependents affected by the cascade. Invoice Ninja soft-deletes a client's
+// invoices and payments along with the client, so they are listed first.
+//
+// If listing fails the client is not deleted. If the delete itself fails, the
+// dependents that would have been affected are returned along with the error.
+func (s *ClientsService) DeleteWithDependents(ctx context.Context, id string) (*ClientDependents, error) {
+	invoices, err := s.client.Invoices.ListAll(ctx, &InvoiceListOptions{ClientID: id, Status: "active,archived"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list client invoices: %w", err)
+	}
+
+	payments, err := s.client.Payments.ListAll(ctx, &PaymentListOptions{ClientID: id, Status: "active,archived"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list client payments: %w", err)
+	}
+
+	deps := &ClientDependents{
+		Invoices: invoices,
+		Payments: payments,
+	}
+
+	if err := s.Delete(ctx, id); err != nil {
+		return deps, err
+	}
+	return deps, nil
 }
 
 // Purge permanently removes a client and all their records.
 func (s *ClientsService) Purge(ctx context.Context, id string) error {
-	return s.client.doRequest(ctx, "POST", fmt.Sprintf("/api/v1/clients/%s/purge", id), nil, nil, nil)
+	return s.client.doRequest(ctx, "POST", s.client.apiPath("/clients/%s/purge", id), nil, nil, nil)
 }
 
 // Archive archives a client.
@@ -138,7 +271,7 @@ func (s *ClientsService) Archive(ctx context.Context, id string) (*INClient, err
 	return s.bulkAction(ctx, "archive", id)
 }
 
-// Restore restores an archived client.
+// Restore restores an archived or deleted client.
 func (s *ClientsService) Restore(ctx context.Context, id string) (*INClient, error) {
 	return s.bulkAction(ctx, "restore", id)
 }
@@ -146,7 +279,7 @@ func (s *ClientsService) Restore(ctx context.Context, id string) (*INClient, err
 // Merge merges two clients.
 func (s *ClientsService) Merge(ctx context.Context, primaryID, mergeableID string) (*INClient, error) {
 	var resp SingleResponse[INClient]
-	path := fmt.Sprintf("/api/v1/clients/%s/%s/merge", primaryID, mergeableID)
+	path := s.client.apiPath("/clients/%s/%s/merge", primaryID, mergeableID)
 	if err := s.client.doRequest(ctx, "POST", path, nil, nil, &resp); err != nil {
 		return nil, err
 	}
@@ -154,17 +287,33 @@ func (s *ClientsService) Merge(ctx context.Context, primaryID, mergeableID strin
 }
 
 // Bulk performs a bulk action on multiple clients.
-func (s *ClientsService) Bulk(ctx context.Context, action string, ids []string) ([]INClient, error) {
+func (s *ClientsService) Bulk(ctx context.Context, action string, ids []string, opts ...RequestOption) ([]INClient, error) {
 	req := BulkAction{
 		Action: action,
 		IDs:    ids,
 	}
 
-	var resp ListResponse[INClient]
-	if err := s.client.doRequest(ctx, "POST", "/api/v1/clients/bulk", nil, req, &resp); err != nil {
+	return bulkRequest[INClient](ctx, s.client, s.client.apiPath("/clients/bulk"), req, opts...)
+}
+
+// BulkTyped performs a bulk action on multiple clients like Bulk, but first
+// checks that action is one of BulkArchive, BulkRestore and BulkDelete,
+// returning a *ValidationError otherwise.
+func (s *ClientsService) BulkTyped(ctx context.Context, action BulkActionType, ids []string, opts ...RequestOption) ([]INClient, error) {
+	if err := checkBulkAction("clients", action); err != nil {
 		return nil, err
 	}
-	return resp.Data, nil
+	return s.Bulk(ctx, string(action), ids, opts...)
+}
+
+// BulkChunked performs a bulk action on a large number of clients by splitting ids
+// into chunks of chunkSize (DefaultBulkChunkSize when chunkSize <= 0) and sending
+// one Bulk request per chunk. It returns the clients echoed by successful chunks along
+// with a joined error of *BulkChunkError values for any chunks that failed.
+func (s *ClientsService) BulkChunked(ctx context.Context, action string, ids []string, chunkSize int, opts ...RequestOption) ([]INClient, error) {
+	return bulkChunked(ctx, ids, chunkSize, func(ctx context.Context, chunk []string) ([]INClient, error) {
+		return s.Bulk(ctx, action, chunk, opts...)
+	})
 }
 
 // bulkAction performs a single-item bulk action.
@@ -174,7 +323,7 @@ func (s *ClientsService) bulkAction(ctx context.Context, action, id string) (*IN
 		return nil, err
 	}
 	if len(clients) == 0 {
-		return nil, fmt.Errorf("no client returned from bulk action")
+		return nil, fmt.Errorf("%s client: %w", action, ErrEmptyBulkResponse)
 	}
 	return &clients[0], nil
 }
@@ -182,7 +331,7 @@ func (s *ClientsService) bulkAction(ctx context.Context, action, id string) (*IN
 // GetBlank retrieves a blank client object with default values.
 func (s *ClientsService) GetBlank(ctx context.Context) (*INClient, error) {
 	var resp SingleResponse[INClient]
-	if err := s.client.doRequest(ctx, "GET", "/api/v1/clients/create", nil, nil, &resp); err != nil {
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/clients/create"), nil, nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp.Data, nil
@@ -192,6 +192,77 @@ func TestClientsServiceDelete(t *testing.T) {
 	}
 }
 
+func TestClientsServiceDeleteWithDependents(t *testing.T) {
+	var deleted bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/invoices", "GET /api/v1/payments":
+			if deleted {
+				t.Errorf("expected dependents to be listed before delete")
+			}
+			if got := r.URL.Query().Get("client_id"); got != "client123" {
+				t.Errorf("expected client_id client123, got %s", got)
+			}
+			if got := r.URL.Query().Get("status"); got != "active,archived" {
+				t.Errorf("expected status active,archived, got %s", got)
+			}
+			if r.URL.Path == "/api/v1/invoices" {
+				w.Write([]byte(`{"data": [{"id": "inv1"}, {"id": "inv2"}]}`))
+			} else {
+				w.Write([]byte(`{"data": [{"id": "pay1"}]}`))
+			}
+		case "DELETE /api/v1/clients/client123":
+			deleted = true
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	apiClient := NewClient("test-token", WithBaseURL(server.URL))
+
+	deps, err := apiClient.Clients.DeleteWithDependents(context.Background(), "client123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !deleted {
+		t.Error("expected client to be deleted")
+	}
+
+	if len(deps.Invoices) != 2 || deps.Invoices[0].ID != "inv1" {
+		t.Errorf("expected 2 invoices starting with inv1, got %+v", deps.Invoices)
+	}
+
+	if len(deps.Payments) != 1 || deps.Payments[0].ID != "pay1" {
+		t.Errorf("expected payment pay1, got %+v", deps.Payments)
+	}
+}
+
+func TestClientsServiceDeleteWithDependentsListError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			t.Error("expected client not to be deleted when listing fails")
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	apiClient := NewClient("test-token", WithBaseURL(server.URL))
+
+	deps, err := apiClient.Clients.DeleteWithDependents(context.Background(), "client123")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if deps != nil {
+		t.Errorf("expected nil dependents, got %+v", deps)
+	}
+}
+
 func TestClientsServicePurge(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != "POST" {
@@ -287,16 +358,18 @@ func TestClientsServiceBulk(t *testing.T) {
 func TestClientListOptionsToQuery(t *testing.T) {
 	isDeleted := true
 	opts := &ClientListOptions{
-		PerPage:   15,
-		Page:      2,
-		Filter:    "acme",
-		Balance:   "gt:1000",
-		Status:    "active",
-		CreatedAt: "2024-01-01",
-		UpdatedAt: "2024-01-15",
-		IsDeleted: &isDeleted,
-		Sort:      "name|asc",
-		Include:   "contacts,documents",
+		PerPage:       15,
+		Page:          2,
+		Filter:        "acme",
+		Balance:       "gt:1000",
+		CreditBalance: "gt:0",
+		PaidToDate:    "gte:5000",
+		Status:        "active",
+		CreatedAt:     "2024-01-01",
+		UpdatedAt:     "2024-01-15",
+		IsDeleted:     &isDeleted,
+		Sort:          "name|asc",
+		Include:       "contacts,documents",
 	}
 
 	q := opts.toQuery()
@@ -313,6 +386,12 @@ func TestClientListOptionsToQuery(t *testing.T) {
 	if q.Get("balance") != "gt:1000" {
 		t.Errorf("expected balance=gt:1000, got %s", q.Get("balance"))
 	}
+	if q.Get("credit_balance") != "gt:0" {
+		t.Errorf("expected credit_balance=gt:0, got %s", q.Get("credit_balance"))
+	}
+	if q.Get("paid_to_date") != "gte:5000" {
+		t.Errorf("expected paid_to_date=gte:5000, got %s", q.Get("paid_to_date"))
+	}
 	if q.Get("include") != "contacts,documents" {
 		t.Errorf("expected include=contacts,documents, got %s", q.Get("include"))
 	}
@@ -0,0 +1,41 @@
+package invoiceninja
+
+import "time"
+
+// Clock is the source of time for rate limiting, retry backoff and the
+// circuit breaker. Tests can supply a fake clock to control window expiry
+// and backoff without real sleeps.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// After waits for d to elapse and then sends the current time on the
+	// returned channel, like time.After.
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is the Clock backed by the time package.
+type realClock struct{}
+
+// Now returns time.Now().
+func (realClock) Now() time.Time { return time.Now() }
+
+// After returns time.After(d).
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// WithClock sets the clock used by a RateLimitedClient for its rate limiter,
+// retry backoff and circuit breaker, and by the client for the WithPageDelay
+// pause and job and download polling. By default the real time is used.
+func WithClock(clock Clock) ClientOption {
+	return func(c *Client) {
+		c.clock = clock
+	}
+}
+
+// timeSource returns the configured clock, or the real one.
+func (c *Client) timeSource() Clock {
+	if c.clock == nil {
+		return realClock{}
+	}
+	return c.clock
+}
@@ -0,0 +1,159 @@
+package invoiceninja
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock that only moves when advanced.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- f.now
+		return ch
+	}
+	f.waiters = append(f.waiters, fakeWaiter{deadline: f.now.Add(d), ch: ch})
+	return ch
+}
+
+// Advance moves the clock forward and fires the waiters that are due.
+func (f *fakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.now = f.now.Add(d)
+	pending := f.waiters[:0]
+	for _, w := range f.waiters {
+		if w.deadline.After(f.now) {
+			pending = append(pending, w)
+			continue
+		}
+		w.ch <- f.now
+	}
+	f.waiters = pending
+}
+
+// waitForWaiters blocks until n goroutines are waiting on the clock.
+func (f *fakeClock) waitForWaiters(t *testing.T, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		f.mu.Lock()
+		got := len(f.waiters)
+		f.mu.Unlock()
+		if got >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d clock waiters", n)
+}
+
+func TestRateLimiterWithFakeClock(t *testing.T) {
+	clock := newFakeClock()
+	limiter := NewRateLimiterWithClock(2, clock)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if err := limiter.Wait(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		limiter.Wait(ctx)
+		close(done)
+	}()
+
+	clock.waitForWaiters(t, 1)
+	clock.Advance(999 * time.Millisecond)
+	select {
+	case <-done:
+		t.Fatal("expected the third request to wait for the window to expire")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	clock.Advance(time.Millisecond)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the third request to proceed once the window expired")
+	}
+}
+
+func TestRateLimitedClientBackoffWithFakeClock(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"message": "unavailable"}`))
+			return
+		}
+		w.Write([]byte(`{"data": {"id": "inv1"}}`))
+	}))
+	defer server.Close()
+
+	clock := newFakeClock()
+	client := NewRateLimitedClient("test-token", WithBaseURL(server.URL), WithClock(clock))
+	client.SetRetryConfig(&RetryConfig{
+		MaxRetries:         1,
+		InitialBackoff:     10 * time.Second,
+		MaxBackoff:         time.Minute,
+		BackoffMultiplier:  2,
+		RetryOnStatusCodes: []int{http.StatusServiceUnavailable},
+	})
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- client.DoRequestWithRetry(context.Background(), "GET", "/api/v1/invoices/inv1", nil, nil, nil)
+	}()
+
+	clock.waitForWaiters(t, 1)
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected 1 request before the backoff elapsed, got %d", got)
+	}
+
+	clock.Advance(10 * time.Second)
+	select {
+	case err := <-errc:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the retry to run once the fake clock advanced")
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("expected 2 requests, got %d", got)
+	}
+}
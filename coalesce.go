@@ -0,0 +1,45 @@
+package invoiceninja
+
+import "sync"
+
+// flightGroup de-duplicates concurrent calls that share the same key.
+// It is a minimal internal equivalent of golang.org/x/sync/singleflight.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+// flightCall is an in-flight or completed call.
+type flightCall struct {
+	wg   sync.WaitGroup
+	resp *rawResponse
+	err  error
+}
+
+// do executes fn for key, making sure only one execution is in flight at a time.
+// Duplicate callers wait for the original call and receive the same result.
+func (g *flightGroup) do(key string, fn func() (*rawResponse, error)) (*rawResponse, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		call.wg.Wait()
+		return call.resp, call.err
+	}
+
+	call := &flightCall{}
+	call.wg.Add(1)
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	call.resp, call.err = fn()
+	call.wg.Done()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+
+	return call.resp, call.err
+}
@@ -0,0 +1,120 @@
+package invoiceninja
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRequestCoalescingSharesConcurrentGets(t *testing.T) {
+	var hits int32
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		<-release
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"id": "inv123", "number": "INV001"},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithRequestCoalescing())
+
+	const callers = 10
+	var wg sync.WaitGroup
+	results := make([]*Invoice, callers)
+	errs := make([]error, callers)
+
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = client.Invoices.Get(context.Background(), "inv123")
+		}(i)
+	}
+
+	// Give every goroutine time to join the in-flight request before releasing it
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if hits != 1 {
+		t.Errorf("expected 1 server hit, got %d", hits)
+	}
+
+	for i := 0; i < callers; i++ {
+		if errs[i] != nil {
+			t.Fatalf("caller %d: unexpected error: %v", i, errs[i])
+		}
+		if results[i].Number != "INV001" {
+			t.Errorf("caller %d: expected number INV001, got %s", i, results[i].Number)
+		}
+	}
+
+	// Results must be independent copies
+	results[0].Number = "changed"
+	if results[1].Number != "INV001" {
+		t.Error("expected coalesced results to be decoded independently")
+	}
+}
+
+func TestRequestCoalescingDisabledByDefault(t *testing.T) {
+	var hits int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		time.Sleep(20 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "inv123"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Invoices.Get(context.Background(), "inv123"); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if hits != 3 {
+		t.Errorf("expected 3 server hits without coalescing, got %d", hits)
+	}
+}
+
+func TestRequestCoalescingSkipsWrites(t *testing.T) {
+	var hits int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "inv123"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithRequestCoalescing())
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Invoices.Create(context.Background(), &Invoice{ClientID: "c1"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if hits != 2 {
+		t.Errorf("expected 2 server hits for POST requests, got %d", hits)
+	}
+}
@@ -0,0 +1,48 @@
+package invoiceninja
+
+import "encoding/json"
+
+// Codec encodes request bodies and decodes response bodies as JSON. Any
+// package with encoding/json compatible Marshal and Unmarshal functions can
+// be plugged in with WithCodec, e.g. jsoniter's ConfigCompatibleWithStandardLibrary
+// or github.com/goccy/go-json:
+//
+//	type goJSON struct{}
+//
+//	func (goJSON) Marshal(v interface{}) ([]byte, error)      { return gojson.Marshal(v) }
+//	func (goJSON) Unmarshal(data []byte, v interface{}) error { return gojson.Unmarshal(data, v) }
+//
+//	client := invoiceninja.NewClient(token, invoiceninja.WithCodec(goJSON{}))
+//
+// The codec must honor json struct tags and the json.Marshaler and
+// json.Unmarshaler methods some SDK types implement.
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// stdCodec is the Codec backed by encoding/json.
+type stdCodec struct{}
+
+// Marshal returns json.Marshal(v).
+func (stdCodec) Marshal(v interface{}) ([]byte, error) { return json.Marshal(v) }
+
+// Unmarshal returns json.Unmarshal(data, v).
+func (stdCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+
+// WithCodec sets the codec used to encode request bodies and decode responses,
+// including error responses, file download responses and background job
+// statuses. By default encoding/json is used.
+func WithCodec(codec Codec) ClientOption {
+	return func(c *Client) {
+		c.codec = codec
+	}
+}
+
+// jsonCodec returns the configured codec, or the encoding/json one.
+func (c *Client) jsonCodec() Codec {
+	if c.codec == nil {
+		return stdCodec{}
+	}
+	return c.codec
+}
@@ -0,0 +1,165 @@
+package invoiceninja
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// countingCodec is encoding/json counting its calls.
+type countingCodec struct {
+	marshals, unmarshals int32
+}
+
+func (c *countingCodec) Marshal(v interface{}) ([]byte, error) {
+	atomic.AddInt32(&c.marshals, 1)
+	return json.Marshal(v)
+}
+
+func (c *countingCodec) Unmarshal(data []byte, v interface{}) error {
+	atomic.AddInt32(&c.unmarshals, 1)
+	return json.Unmarshal(data, v)
+}
+
+func TestWithCodec(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "inv1", "client_id": "c1"}}`))
+	}))
+	defer server.Close()
+
+	codec := &countingCodec{}
+	client := NewClient("test-token", WithBaseURL(server.URL), WithCodec(codec))
+	ctx := context.Background()
+
+	invoice, err := client.Invoices.Create(ctx, &Invoice{ClientID: "c1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if invoice.ID != "inv1" {
+		t.Errorf("expected invoice inv1, got %q", invoice.ID)
+	}
+	if codec.marshals != 1 || codec.unmarshals != 1 {
+		t.Errorf("expected 1 marshal and 1 unmarshal, got %d and %d", codec.marshals, codec.unmarshals)
+	}
+
+	if _, err := client.With().Invoices.Get(ctx, "inv1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if codec.unmarshals != 2 {
+		t.Errorf("expected the derived client to share the codec, got %d unmarshals", codec.unmarshals)
+	}
+}
+
+func TestWithCodecErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"message": "The given data was invalid.", "errors": {"client_id": ["The client id field is required."]}}`))
+	}))
+	defer server.Close()
+
+	codec := &countingCodec{}
+	client := NewClient("test-token", WithBaseURL(server.URL), WithCodec(codec))
+
+	_, err := client.Invoices.Create(context.Background(), &Invoice{})
+	apiErr, ok := IsAPIError(err)
+	if !ok {
+		t.Fatalf("expected an API error, got %v", err)
+	}
+	if apiErr.Message != "The given data was invalid." || len(apiErr.Errors["client_id"]) != 1 {
+		t.Errorf("unexpected API error: %+v", apiErr)
+	}
+	if codec.unmarshals != 1 {
+		t.Errorf("expected the error response to be decoded with the codec, got %d unmarshals", codec.unmarshals)
+	}
+}
+
+func TestWebhookHandlerSetCodec(t *testing.T) {
+	codec := &countingCodec{}
+	handler := NewWebhookHandler("")
+	handler.SetCodec(codec)
+
+	var payment *Payment
+	handler.OnPaymentCreated(func(event *WebhookEvent) error {
+		var err error
+		payment, err = event.ParsePayment()
+		return err
+	})
+
+	body := []byte(`{"event_type": "payment.created", "data": {"id": "pay1", "amount": 100}}`)
+	w := httptest.NewRecorder()
+	handler.HandleRequest(w, httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body)))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if payment == nil || payment.ID != "pay1" {
+		t.Errorf("unexpected payment: %+v", payment)
+	}
+	if codec.unmarshals != 2 {
+		t.Errorf("expected the event and its data to be decoded with the codec, got %d unmarshals", codec.unmarshals)
+	}
+}
+
+// noDecodeCodec skips decoding. It stands in for an infinitely fast codec to
+// show how much of a list request is spent decoding.
+type noDecodeCodec struct{ stdCodec }
+
+func (noDecodeCodec) Unmarshal(data []byte, v interface{}) error { return nil }
+
+// BenchmarkListDecode fetches a page of 500 invoices with three line items
+// each. Comparing encoding/json with NoDecode shows the decode time a faster
+// codec, such as jsoniter or go-json set with WithCodec, can cut from large
+// list requests; add it to the table to measure it.
+func BenchmarkListDecode(b *testing.B) {
+	var page strings.Builder
+	page.WriteString(`{"data": [`)
+	for i := 0; i < 500; i++ {
+		if i > 0 {
+			page.WriteByte(',')
+		}
+		fmt.Fprintf(&page, `{"id": "inv%d", "client_id": "c1", "number": "%04d", "amount": 450.5, "balance": 450.5,
+			"date": "2026-10-01", "due_date": "2026-10-31", "public_notes": "Thank you for your business",
+			"line_items": [
+				{"product_key": "consulting", "notes": "Consulting", "quantity": 3, "cost": 100},
+				{"product_key": "support", "notes": "Support plan", "quantity": 1, "cost": 120.5},
+				{"product_key": "hosting", "notes": "Hosting", "quantity": 1, "cost": 30}
+			]}`, i, i)
+	}
+	page.WriteString(`], "meta": {"pagination": {"total": 500, "count": 500, "per_page": 500, "current_page": 1, "total_pages": 1}}}`)
+	body := []byte(page.String())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	for _, bm := range []struct {
+		name  string
+		codec Codec
+	}{
+		{"EncodingJSON", stdCodec{}},
+		{"NoDecode", noDecodeCodec{}},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			client := NewClient("test-token", WithBaseURL(server.URL), WithCodec(bm.codec))
+			ctx := context.Background()
+
+			b.SetBytes(int64(len(body)))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := client.Invoices.List(ctx, &InvoiceListOptions{PerPage: 500}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
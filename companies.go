@@ -0,0 +1,175 @@
+package invoiceninja
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// CompaniesService handles company-related API operations. It is read-only.
+type CompaniesService struct {
+	client *Client
+}
+
+// Company is a company in the Invoice Ninja account.
+type Company struct {
+	ID           string          `json:"id,omitempty"`
+	SizeID       string          `json:"size_id,omitempty"`
+	IndustryID   string          `json:"industry_id,omitempty"`
+	Subdomain    string          `json:"subdomain,omitempty"`
+	PortalDomain string          `json:"portal_domain,omitempty"`
+	Settings     CompanySettings `json:"settings"`
+
+	// CustomFields holds the company's custom field configuration, keyed by
+	// entity and number, e.g. "invoice2" or "client1". Values are a label
+	// optionally followed by "|" and the field type. Use CustomFieldLabels
+	// to read them.
+	CustomFields map[string]string `json:"custom_fields,omitempty"`
+
+	IsDeleted  bool  `json:"is_deleted,omitempty"`
+	CreatedAt  int64 `json:"created_at,omitempty"`
+	UpdatedAt  int64 `json:"updated_at,omitempty"`
+	ArchivedAt int64 `json:"archived_at,omitempty"`
+}
+
+// CompanySettings holds the company details from its settings.
+type CompanySettings struct {
+	Name       string `json:"name,omitempty"`
+	Email      string `json:"email,omitempty"`
+	Phone      string `json:"phone,omitempty"`
+	Website    string `json:"website,omitempty"`
+	VatNumber  string `json:"vat_number,omitempty"`
+	IDNumber   string `json:"id_number,omitempty"`
+	CurrencyID string `json:"currency_id,omitempty"`
+	CountryID  string `json:"country_id,omitempty"`
+}
+
+// UnmarshalJSON accepts custom_fields sent as an empty JSON array, which is
+// how the server encodes a company without custom fields.
+func (c *Company) UnmarshalJSON(data []byte) error {
+	type plain Company
+	v := struct {
+		*plain
+		CustomFields json.RawMessage `json:"custom_fields"`
+	}{plain: (*plain)(c)}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	c.CustomFields = nil
+	raw := bytes.TrimSpace(v.CustomFields)
+	if len(raw) == 0 || raw[0] != '{' {
+		return nil
+	}
+	return json.Unmarshal(raw, &c.CustomFields)
+}
+
+// CustomFieldLabels returns the labels the company has given to the custom
+// values of entity, keyed by field name: with an invoice custom field
+// "invoice2" set to "Project Code|single_line_text", the result for
+// "invoice" maps "custom_value2" to "Project Code". entity is the singular
+// entity name, e.g. "client", "contact", "product", "invoice" or "payment";
+// quotes and credits use the "invoice" fields. Unconfigured fields are
+// omitted.
+func (c *Company) CustomFieldLabels(entity string) map[string]string {
+	labels := make(map[string]string)
+	for n := 1; n <= 4; n++ {
+		num := strconv.Itoa(n)
+		value, ok := c.CustomFields[entity+num]
+		if !ok {
+			continue
+		}
+		label, _, _ := strings.Cut(value, "|")
+		if label = strings.TrimSpace(label); label != "" {
+			labels["custom_value"+num] = label
+		}
+	}
+	return labels
+}
+
+// CompanyListOptions specifies the optional parameters for listing companies.
+type CompanyListOptions struct {
+	PerPage   int
+	Page      int
+	Status    string
+	IsDeleted *bool
+	Sort      string
+	Filters   []FilterExpr
+}
+
+// toQuery converts options to URL query parameters.
+func (o *CompanyListOptions) toQuery() url.Values {
+	if o == nil {
+		return nil
+	}
+
+	q := url.Values{}
+	if o.PerPage > 0 {
+		q.Set("per_page", strconv.Itoa(o.PerPage))
+	}
+	if o.Page > 0 {
+		q.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.Status != "" {
+		q.Set("status", o.Status)
+	}
+	if o.IsDeleted != nil {
+		q.Set("is_deleted", strconv.FormatBool(*o.IsDeleted))
+	}
+	if o.Sort != "" {
+		q.Set("sort", o.Sort)
+	}
+
+	applyFilters(q, o.Filters)
+
+	return q
+}
+
+// List retrieves the companies the API token's user belongs to.
+func (s *CompaniesService) List(ctx context.Context, opts *CompanyListOptions) (*ListResponse[Company], error) {
+	var resp ListResponse[Company]
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/companies"), opts.toQuery(), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Iter returns an iterator over all companies matching opts, fetching pages lazily.
+// Paging starts at opts.Page (or page 1) and keeps every other option.
+func (s *CompaniesService) Iter(ctx context.Context, opts *CompanyListOptions) *Iterator[Company] {
+	return newIterator[Company](ctx, s.client, s.client.apiPath("/companies"), opts.toQuery())
+}
+
+// ListAll retrieves all companies matching opts across every page.
+// If a page fails or ctx is done mid-scan, the companies fetched so far are
+// returned together with the error.
+func (s *CompaniesService) ListAll(ctx context.Context, opts *CompanyListOptions) ([]Company, error) {
+	return listAll(s.Iter(ctx, opts))
+}
+
+// Count returns the number of companies matching opts without fetching them,
+// from the pagination total of a one-item page. Paging options are ignored.
+func (s *CompaniesService) Count(ctx context.Context, opts *CompanyListOptions) (int, error) {
+	return count(ctx, s.client, s.client.apiPath("/companies"), opts.toQuery())
+}
+
+// Get retrieves a single company by ID.
+func (s *CompaniesService) Get(ctx context.Context, id string) (*Company, error) {
+	var resp SingleResponse[Company]
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/companies/%s", id), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Current retrieves the company the API token belongs to.
+func (s *CompaniesService) Current(ctx context.Context) (*Company, error) {
+	var resp SingleResponse[Company]
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/companies/current"), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
@@ -0,0 +1,79 @@
+package invoiceninja
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestCompaniesServiceCurrent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/api/v1/companies/current" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {
+			"id": "co1",
+			"settings": {"name": "Acme Ltd", "vat_number": "GB123456789"},
+			"custom_fields": {
+				"invoice1": "",
+				"invoice2": "Project Code|single_line_text",
+				"invoice4": "Region|North,South",
+				"client1": "Account Manager"
+			}
+		}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	company, err := client.Companies.Current(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if company.ID != "co1" || company.Settings.Name != "Acme Ltd" {
+		t.Errorf("unexpected company: %+v", company)
+	}
+
+	want := map[string]string{"custom_value2": "Project Code", "custom_value4": "Region"}
+	if got := company.CustomFieldLabels("invoice"); !reflect.DeepEqual(got, want) {
+		t.Errorf("invoice labels = %v, want %v", got, want)
+	}
+	if got := company.CustomFieldLabels("client"); !reflect.DeepEqual(got, map[string]string{"custom_value1": "Account Manager"}) {
+		t.Errorf("unexpected client labels: %v", got)
+	}
+	if got := company.CustomFieldLabels("vendor"); got == nil || len(got) != 0 {
+		t.Errorf("expected an empty map for an unconfigured entity, got %v", got)
+	}
+}
+
+func TestCompaniesServiceListWithoutCustomFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/companies" {
+			t.Errorf("expected path /api/v1/companies, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": [{"id": "co1", "custom_fields": []}, {"id": "co2", "custom_fields": {"task1": "Sprint"}}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	resp, err := client.Companies.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Data) != 2 {
+		t.Fatalf("expected 2 companies, got %d", len(resp.Data))
+	}
+	if resp.Data[0].CustomFields != nil {
+		t.Errorf("expected no custom fields, got %v", resp.Data[0].CustomFields)
+	}
+	if got := resp.Data[1].CustomFieldLabels("task")["custom_value1"]; got != "Sprint" {
+		t.Errorf("expected Sprint, got %q", got)
+	}
+}
@@ -0,0 +1,232 @@
+package invoiceninja
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+// CompanyGatewaysService handles company gateway-related API operations.
+type CompanyGatewaysService struct {
+	client *Client
+}
+
+// CompanyGateway represents a payment gateway configured for a company.
+type CompanyGateway struct {
+	ID                  string               `json:"id,omitempty"`
+	GatewayKey          string               `json:"gateway_key,omitempty"`
+	AcceptedCreditCards int                  `json:"accepted_credit_cards,omitempty"`
+	RequireCVV          bool                 `json:"require_cvv,omitempty"`
+	RequireBillingAddr  bool                 `json:"require_billing_address,omitempty"`
+	RequireShippingAddr bool                 `json:"require_shipping_address,omitempty"`
+	UpdateDetails       bool                 `json:"update_details,omitempty"`
+	TokenBilling        string               `json:"token_billing,omitempty"`
+	Label               string               `json:"label,omitempty"`
+	TestMode            bool                 `json:"test_mode,omitempty"`
+	Config              string               `json:"config,omitempty"`
+	FeesAndLimits       GatewayFeesAndLimits `json:"fees_and_limits,omitempty"`
+	IsDeleted           bool                 `json:"is_deleted,omitempty"`
+	CreatedAt           int64                `json:"created_at,omitempty"`
+	UpdatedAt           int64                `json:"updated_at,omitempty"`
+	ArchivedAt          int64                `json:"archived_at,omitempty"`
+}
+
+// FeesAndLimits configures a gateway for one gateway type (credit card, bank
+// transfer, PayPal, ...).
+type FeesAndLimits struct {
+	MinLimit         float64 `json:"min_limit,omitempty"`
+	MaxLimit         float64 `json:"max_limit,omitempty"`
+	FeeAmount        float64 `json:"fee_amount,omitempty"`
+	FeePercent       float64 `json:"fee_percent,omitempty"`
+	FeeCap           float64 `json:"fee_cap,omitempty"`
+	FeeTaxName1      string  `json:"fee_tax_name1,omitempty"`
+	FeeTaxRate1      float64 `json:"fee_tax_rate1,omitempty"`
+	FeeTaxName2      string  `json:"fee_tax_name2,omitempty"`
+	FeeTaxRate2      float64 `json:"fee_tax_rate2,omitempty"`
+	FeeTaxName3      string  `json:"fee_tax_name3,omitempty"`
+	FeeTaxRate3      float64 `json:"fee_tax_rate3,omitempty"`
+	AdjustFeePercent bool    `json:"adjust_fee_percent,omitempty"`
+	IsEnabled        bool    `json:"is_enabled,omitempty"`
+}
+
+// GatewayFeesAndLimits maps gateway type IDs (e.g. "1" for credit card) to
+// their configuration.
+type GatewayFeesAndLimits map[string]FeesAndLimits
+
+// UnmarshalJSON accepts an empty JSON array, which the API sends when no
+// gateway types are configured.
+func (g *GatewayFeesAndLimits) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("[]")) {
+		*g = nil
+		return nil
+	}
+
+	var m map[string]FeesAndLimits
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	*g = m
+	return nil
+}
+
+// gatewayTypePaymentTypes maps Invoice Ninja gateway type IDs to the payment
+// type recorded for payments made with them.
+var gatewayTypePaymentTypes = map[int]PaymentType{
+	1:  PaymentTypeCreditCardOther,
+	2:  PaymentTypeACH,
+	3:  PaymentTypePayPal,
+	4:  PaymentTypeCrypto,
+	6:  PaymentTypeAlipay,
+	7:  PaymentTypeSofort,
+	8:  PaymentTypeCreditCardOther, // Apple Pay settles as a card payment
+	9:  PaymentTypeSEPA,
+	10: PaymentTypeCredit,
+	11: PaymentTypeKBC,
+	12: PaymentTypeBancontact,
+	13: PaymentTypeIDEAL,
+	14: PaymentTypeHostedPage,
+	15: PaymentTypeGiropay,
+	16: PaymentTypePrzelewy24,
+	17: PaymentTypeEPS,
+	18: PaymentTypeDirectDebit,
+	19: PaymentTypeACSS,
+	20: PaymentTypeBECS,
+	21: PaymentTypeInstantBankPay,
+	22: PaymentTypeFPX,
+	23: PaymentTypeKlarna,
+	24: PaymentTypeBACS,
+	25: PaymentTypeVenmo,
+}
+
+// creditCardBrands maps AcceptedCreditCards bits to card payment types.
+var creditCardBrands = []struct {
+	bit         int
+	paymentType PaymentType
+}{
+	{1, PaymentTypeVisa},
+	{2, PaymentTypeMasterCard},
+	{4, PaymentTypeAmericanExpress},
+	{8, PaymentTypeDiners},
+	{16, PaymentTypeDiscover},
+}
+
+// gatewayTypeCreditCard is the gateway type ID for credit cards.
+const gatewayTypeCreditCard = 1
+
+// EnabledMethods returns the payment types the gateway accepts, decoded from
+// the enabled entries of FeesAndLimits in gateway type order. When credit
+// cards are enabled and AcceptedCreditCards lists specific brands, the brands
+// are returned instead of PaymentTypeCreditCardOther. Gateway types without a
+// matching payment type (such as custom gateways) are skipped.
+func (g *CompanyGateway) EnabledMethods() []PaymentType {
+	gatewayTypes := make([]int, 0, len(g.FeesAndLimits))
+	for key, limits := range g.FeesAndLimits {
+		id, err := strconv.Atoi(key)
+		if err != nil || !limits.IsEnabled {
+			continue
+		}
+		gatewayTypes = append(gatewayTypes, id)
+	}
+	sort.Ints(gatewayTypes)
+
+	var methods []PaymentType
+	seen := make(map[PaymentType]bool)
+	add := func(t PaymentType) {
+		if !seen[t] {
+			seen[t] = true
+			methods = append(methods, t)
+		}
+	}
+
+	for _, id := range gatewayTypes {
+		if id == gatewayTypeCreditCard && g.AcceptedCreditCards != 0 {
+			for _, brand := range creditCardBrands {
+				if g.AcceptedCreditCards&brand.bit != 0 {
+					add(brand.paymentType)
+				}
+			}
+			continue
+		}
+
+		if t, ok := gatewayTypePaymentTypes[id]; ok {
+			add(t)
+		}
+	}
+
+	return methods
+}
+
+// CompanyGatewayListOptions specifies the optional parameters for listing company gateways.
+type CompanyGatewayListOptions struct {
+	PerPage   int
+	Page      int
+	Status    string
+	IsDeleted *bool
+	Filters   []FilterExpr
+}
+
+// toQuery converts options to URL query parameters.
+func (o *CompanyGatewayListOptions) toQuery() url.Values {
+	if o == nil {
+		return nil
+	}
+
+	q := url.Values{}
+
+	if o.PerPage > 0 {
+		q.Set("per_page", strconv.Itoa(o.PerPage))
+	}
+	if o.Page > 0 {
+		q.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.Status != "" {
+		q.Set("status", o.Status)
+	}
+	if o.IsDeleted != nil {
+		q.Set("is_deleted", strconv.FormatBool(*o.IsDeleted))
+	}
+
+	applyFilters(q, o.Filters)
+
+	return q
+}
+
+// List retrieves a list of company gateways.
+func (s *CompanyGatewaysService) List(ctx context.Context, opts *CompanyGatewayListOptions) (*ListResponse[CompanyGateway], error) {
+	var resp ListResponse[CompanyGateway]
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/company_gateways"), opts.toQuery(), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Iter returns an iterator over all company gateways matching opts, fetching pages lazily.
+// Paging starts at opts.Page (or page 1) and keeps every other option.
+func (s *CompanyGatewaysService) Iter(ctx context.Context, opts *CompanyGatewayListOptions) *Iterator[CompanyGateway] {
+	return newIterator[CompanyGateway](ctx, s.client, s.client.apiPath("/company_gateways"), opts.toQuery())
+}
+
+// ListAll retrieves all company gateways matching opts across every page.
+// If a page fails or ctx is done mid-scan, the gateways fetched so far are
+// returned together with the error.
+func (s *CompanyGatewaysService) ListAll(ctx context.Context, opts *CompanyGatewayListOptions) ([]CompanyGateway, error) {
+	return listAll(s.Iter(ctx, opts))
+}
+
+// Count returns the number of company gateways matching opts without fetching them,
+// from the pagination total of a one-item page. Paging options are ignored.
+func (s *CompanyGatewaysService) Count(ctx context.Context, opts *CompanyGatewayListOptions) (int, error) {
+	return count(ctx, s.client, s.client.apiPath("/company_gateways"), opts.toQuery())
+}
+
+// Get retrieves a single company gateway by ID.
+func (s *CompanyGatewaysService) Get(ctx context.Context, id string) (*CompanyGateway, error) {
+	var resp SingleResponse[CompanyGateway]
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/company_gateways/%s", id), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
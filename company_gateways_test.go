@@ -0,0 +1,96 @@
+package invoiceninja
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestCompanyGatewayEnabledMethods(t *testing.T) {
+	tests := []struct {
+		name     string
+		json     string
+		expected []PaymentType
+	}{
+		{
+			name: "card brands and bank transfer",
+			json: `{
+				"accepted_credit_cards": 3,
+				"fees_and_limits": {
+					"2": {"is_enabled": true},
+					"1": {"is_enabled": true, "fee_percent": 2.9},
+					"3": {"is_enabled": false}
+				}
+			}`,
+			expected: []PaymentType{PaymentTypeVisa, PaymentTypeMasterCard, PaymentTypeACH},
+		},
+		{
+			name:     "credit card without brand list",
+			json:     `{"fees_and_limits": {"1": {"is_enabled": true}, "3": {"is_enabled": true}}}`,
+			expected: []PaymentType{PaymentTypeCreditCardOther, PaymentTypePayPal},
+		},
+		{
+			name:     "unknown gateway types are skipped",
+			json:     `{"fees_and_limits": {"5": {"is_enabled": true}, "99": {"is_enabled": true}, "9": {"is_enabled": true}}}`,
+			expected: []PaymentType{PaymentTypeSEPA},
+		},
+		{
+			name:     "empty array",
+			json:     `{"fees_and_limits": []}`,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gateway CompanyGateway
+			if err := json.Unmarshal([]byte(tt.json), &gateway); err != nil {
+				t.Fatalf("unexpected decode error: %v", err)
+			}
+
+			methods := gateway.EnabledMethods()
+			if !reflect.DeepEqual(methods, tt.expected) {
+				t.Errorf("EnabledMethods() = %v, want %v", methods, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCompanyGatewaysServiceGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/company_gateways/gw1" {
+			t.Errorf("expected path /api/v1/company_gateways/gw1, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {
+			"id": "gw1",
+			"gateway_key": "d14dd26a37cecc30fdd65700bfb55b23",
+			"label": "Stripe",
+			"fees_and_limits": {"1": {"is_enabled": true, "min_limit": 1, "max_limit": 10000}}
+		}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	gateway, err := client.CompanyGateways.Get(context.Background(), "gw1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gateway.Label != "Stripe" {
+		t.Errorf("expected label Stripe, got %s", gateway.Label)
+	}
+
+	if gateway.FeesAndLimits["1"].MaxLimit != 10000 {
+		t.Errorf("expected max limit 10000, got %+v", gateway.FeesAndLimits["1"])
+	}
+
+	if methods := gateway.EnabledMethods(); len(methods) != 1 || methods[0] != PaymentTypeCreditCardOther {
+		t.Errorf("expected credit card method, got %v", methods)
+	}
+}
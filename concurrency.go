@@ -0,0 +1,54 @@
+package invoiceninja
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// WithMaxConcurrency caps the number of requests in flight at once at n, to
+// protect a small server. Further requests block until a slot frees up or
+// their context is done. A request holds its slot until its response body
+// has been read, so downloads count too. Unlike a rate limiter, which caps
+// requests per second, this caps simultaneous requests; the two compose.
+// Copies made with Client.With share the limit. n <= 0 removes the limit.
+func WithMaxConcurrency(n int) ClientOption {
+	return func(c *Client) {
+		if n <= 0 {
+			c.sem = nil
+			return
+		}
+		c.sem = make(chan struct{}, n)
+	}
+}
+
+// acquire takes a concurrency slot, waiting for one if all are in use. The
+// returned func gives the slot back and may be called more than once.
+func (c *Client) acquire(ctx context.Context) (release func(), err error) {
+	if c.sem == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case c.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	var once sync.Once
+	return func() { once.Do(func() { <-c.sem }) }, nil
+}
+
+// releasingBody gives back a concurrency slot, and cancels the default
+// request timeout, when the response body is closed.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+// Close closes the body and runs release.
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
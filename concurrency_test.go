@@ -0,0 +1,76 @@
+package invoiceninja
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithMaxConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "inv1"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithMaxConcurrency(2))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Invoices.Get(context.Background(), "inv1"); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if m := atomic.LoadInt32(&maxInFlight); m != 2 {
+		t.Errorf("expected at most 2 requests in flight, got %d", m)
+	}
+	if len(client.sem) != 0 {
+		t.Errorf("expected every slot to be released, %d still held", len(client.sem))
+	}
+}
+
+func TestWithMaxConcurrencyRespectsContext(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "inv1"}}`))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithMaxConcurrency(1))
+
+	go client.Invoices.Get(context.Background(), "inv1")
+	for len(client.sem) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.Invoices.Get(ctx, "inv1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded while the slot is taken, got %v", err)
+	}
+}
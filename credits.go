@@ -14,60 +14,86 @@ type CreditsService struct {
 
 // Credit represents a credit note in Invoice Ninja.
 type Credit struct {
-	ID                 string     `json:"id,omitempty"`
-	UserID             string     `json:"user_id,omitempty"`
-	AssignedUserID     string     `json:"assigned_user_id,omitempty"`
-	ClientID           string     `json:"client_id,omitempty"`
-	StatusID           string     `json:"status_id,omitempty"`
-	InvoiceID          string     `json:"invoice_id,omitempty"`
-	Number             string     `json:"number,omitempty"`
-	PONumber           string     `json:"po_number,omitempty"`
-	Terms              string     `json:"terms,omitempty"`
-	PublicNotes        string     `json:"public_notes,omitempty"`
-	PrivateNotes       string     `json:"private_notes,omitempty"`
-	Footer             string     `json:"footer,omitempty"`
-	CustomValue1       string     `json:"custom_value1,omitempty"`
-	CustomValue2       string     `json:"custom_value2,omitempty"`
-	CustomValue3       string     `json:"custom_value3,omitempty"`
-	CustomValue4       string     `json:"custom_value4,omitempty"`
-	TaxName1           string     `json:"tax_name1,omitempty"`
-	TaxName2           string     `json:"tax_name2,omitempty"`
-	TaxName3           string     `json:"tax_name3,omitempty"`
-	TaxRate1           float64    `json:"tax_rate1,omitempty"`
-	TaxRate2           float64    `json:"tax_rate2,omitempty"`
-	TaxRate3           float64    `json:"tax_rate3,omitempty"`
-	TotalTaxes         float64    `json:"total_taxes,omitempty"`
-	Amount             float64    `json:"amount,omitempty"`
-	Balance            float64    `json:"balance,omitempty"`
-	PaidToDate         float64    `json:"paid_to_date,omitempty"`
-	Discount           float64    `json:"discount,omitempty"`
-	Partial            float64    `json:"partial,omitempty"`
-	IsAmountDiscount   bool       `json:"is_amount_discount,omitempty"`
-	IsDeleted          bool       `json:"is_deleted,omitempty"`
-	UsesInclusiveTaxes bool       `json:"uses_inclusive_taxes,omitempty"`
-	Date               string     `json:"date,omitempty"`
-	LastSentDate       string     `json:"last_sent_date,omitempty"`
-	NextSendDate       string     `json:"next_send_date,omitempty"`
-	PartialDueDate     string     `json:"partial_due_date,omitempty"`
-	DueDate            string     `json:"due_date,omitempty"`
-	LineItems          []LineItem `json:"line_items,omitempty"`
-	UpdatedAt          int64      `json:"updated_at,omitempty"`
-	ArchivedAt         int64      `json:"archived_at,omitempty"`
-	CreatedAt          int64      `json:"created_at,omitempty"`
+	ID                 string       `json:"id,omitempty"`
+	UserID             string       `json:"user_id,omitempty"`
+	AssignedUserID     string       `json:"assigned_user_id,omitempty"`
+	ClientID           string       `json:"client_id,omitempty"`
+	StatusID           string       `json:"status_id,omitempty"`
+	InvoiceID          string       `json:"invoice_id,omitempty"`
+	Number             string       `json:"number,omitempty"`
+	DesignID           string       `json:"design_id,omitempty"`
+	PONumber           string       `json:"po_number,omitempty"`
+	Terms              string       `json:"terms,omitempty"`
+	PublicNotes        string       `json:"public_notes,omitempty"`
+	PrivateNotes       string       `json:"private_notes,omitempty"`
+	Footer             string       `json:"footer,omitempty"`
+	CustomValue1       string       `json:"custom_value1,omitempty"`
+	CustomValue2       string       `json:"custom_value2,omitempty"`
+	CustomValue3       string       `json:"custom_value3,omitempty"`
+	CustomValue4       string       `json:"custom_value4,omitempty"`
+	TaxName1           string       `json:"tax_name1,omitempty"`
+	TaxName2           string       `json:"tax_name2,omitempty"`
+	TaxName3           string       `json:"tax_name3,omitempty"`
+	TaxRate1           float64      `json:"tax_rate1,omitempty"`
+	TaxRate2           float64      `json:"tax_rate2,omitempty"`
+	TaxRate3           float64      `json:"tax_rate3,omitempty"`
+	TotalTaxes         float64      `json:"total_taxes,omitempty"`
+	Amount             float64      `json:"amount,omitempty"`
+	Balance            float64      `json:"balance,omitempty"`
+	PaidToDate         float64      `json:"paid_to_date,omitempty"`
+	Discount           float64      `json:"discount,omitempty"`
+	Partial            float64      `json:"partial,omitempty"`
+	IsAmountDiscount   bool         `json:"is_amount_discount,omitempty"`
+	IsDeleted          bool         `json:"is_deleted,omitempty"`
+	UsesInclusiveTaxes bool         `json:"uses_inclusive_taxes,omitempty"`
+	Date               string       `json:"date,omitempty"`
+	LastSentDate       string       `json:"last_sent_date,omitempty"`
+	NextSendDate       string       `json:"next_send_date,omitempty"`
+	PartialDueDate     string       `json:"partial_due_date,omitempty"`
+	DueDate            string       `json:"due_date,omitempty"`
+	LineItems          []LineItem   `json:"line_items,omitempty"`
+	Invitations        []Invitation `json:"invitations,omitempty"`
+	Payments           []Payment    `json:"payments,omitempty"`
+	Documents          []Document   `json:"documents,omitempty"`
+	UpdatedAt          int64        `json:"updated_at,omitempty"`
+	ArchivedAt         int64        `json:"archived_at,omitempty"`
+	CreatedAt          int64        `json:"created_at,omitempty"`
+}
+
+// CreditApplication describes a portion of a credit applied to an invoice.
+type CreditApplication struct {
+	// PaymentID is the payment through which the credit was applied.
+	PaymentID string `json:"payment_id"`
+
+	// InvoiceID is the invoice the credit was applied to.
+	InvoiceID string `json:"invoice_id"`
+
+	// Amount is the amount of credit applied to the invoice.
+	Amount float64 `json:"amount"`
+
+	// Date is the date of the applying payment.
+	Date string `json:"date,omitempty"`
+
+	// Remaining is the credit balance left after this application; after the
+	// last one it equals Credit.Balance.
+	Remaining float64 `json:"remaining"`
 }
 
 // CreditListOptions specifies the optional parameters for listing credits.
 type CreditListOptions struct {
-	PerPage   int
-	Page      int
-	Filter    string
-	ClientID  string
-	Status    string
-	CreatedAt string
-	UpdatedAt string
-	IsDeleted *bool
-	Sort      string
-	Include   string
+	PerPage        int
+	Page           int
+	Filter         string
+	ClientID       string
+	Status         string
+	AssignedUserID string
+	CreatedAt      string
+	UpdatedAt      string
+	IsDeleted      *bool
+	Sort           string
+	Include        string
+	Fields         []string
+	Filters        []FilterExpr
 }
 
 // toQuery converts options to URL query parameters.
@@ -93,6 +119,9 @@ func (o *CreditListOptions) toQuery() url.Values {
 	if o.Status != "" {
 		q.Set("status", o.Status)
 	}
+	if o.AssignedUserID != "" {
+		q.Set("assigned_user", o.AssignedUserID)
+	}
 	if o.CreatedAt != "" {
 		q.Set("created_at", o.CreatedAt)
 	}
@@ -109,62 +138,140 @@ func (o *CreditListOptions) toQuery() url.Values {
 		q.Set("include", o.Include)
 	}
 
+	applyFilters(q, o.Filters)
+
 	return q
 }
 
+// fieldList returns the Fields option of possibly nil options.
+func (o *CreditListOptions) fieldList() []string {
+	if o == nil {
+		return nil
+	}
+	return o.Fields
+}
+
 // List retrieves a list of credits.
 func (s *CreditsService) List(ctx context.Context, opts *CreditListOptions) (*ListResponse[Credit], error) {
+	fields, err := newFieldSelection[Credit](opts.fieldList())
+	if err != nil {
+		return nil, err
+	}
+
 	var resp ListResponse[Credit]
-	if err := s.client.doRequest(ctx, "GET", "/api/v1/credits", opts.toQuery(), nil, &resp); err != nil {
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/credits"), opts.toQuery(), nil, &resp); err != nil {
 		return nil, err
 	}
+	fields.apply(resp.Data)
 	return &resp, nil
 }
 
+// Iter returns an iterator over all credits matching opts, fetching pages lazily.
+// Paging starts at opts.Page (or page 1) and keeps every other option.
+func (s *CreditsService) Iter(ctx context.Context, opts *CreditListOptions) *Iterator[Credit] {
+	it := newIterator[Credit](ctx, s.client, s.client.apiPath("/credits"), opts.toQuery())
+	it.fields, it.err = newFieldSelection[Credit](opts.fieldList())
+	return it
+}
+
+// ListAll retrieves all credits matching opts across every page.
+// If a page fails or ctx is done mid-scan, the credits fetched so far are
+// returned together with the error.
+func (s *CreditsService) ListAll(ctx context.Context, opts *CreditListOptions) ([]Credit, error) {
+	return listAll(s.Iter(ctx, opts))
+}
+
+// Count returns the number of credits matching opts without fetching them,
+// from the pagination total of a one-item page. Paging options are ignored.
+func (s *CreditsService) Count(ctx context.Context, opts *CreditListOptions) (int, error) {
+	return count(ctx, s.client, s.client.apiPath("/credits"), opts.toQuery())
+}
+
 // Get retrieves a single credit by ID.
 func (s *CreditsService) Get(ctx context.Context, id string) (*Credit, error) {
+	return s.GetWith(ctx, id)
+}
+
+// GetWith retrieves a single credit by ID together with the related entities
+// named in include, e.g. "payments", in one request.
+func (s *CreditsService) GetWith(ctx context.Context, id string, include ...string) (*Credit, error) {
 	var resp SingleResponse[Credit]
-	if err := s.client.doRequest(ctx, "GET", fmt.Sprintf("/api/v1/credits/%s", id), nil, nil, &resp); err != nil {
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/credits/%s", id), includeQuery(include), nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp.Data, nil
 }
 
+// Documents returns the documents attached to the credit with the given ID,
+// fetching the credit with its documents included.
+func (s *CreditsService) Documents(ctx context.Context, creditID string) ([]Document, error) {
+	credit, err := s.GetWith(ctx, creditID, "documents")
+	if err != nil {
+		return nil, err
+	}
+	return credit.Documents, nil
+}
+
 // Create creates a new credit.
-func (s *CreditsService) Create(ctx context.Context, credit *Credit) (*Credit, error) {
+func (s *CreditsService) Create(ctx context.Context, credit *Credit, opts ...RequestOption) (*Credit, error) {
 	var resp SingleResponse[Credit]
-	if err := s.client.doRequest(ctx, "POST", "/api/v1/credits", nil, credit, &resp); err != nil {
+	if err := s.client.doRequest(ctx, "POST", s.client.apiPath("/credits"), nil, credit, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp.Data, nil
 }
 
 // Update updates an existing credit.
-func (s *CreditsService) Update(ctx context.Context, id string, credit *Credit) (*Credit, error) {
+func (s *CreditsService) Update(ctx context.Context, id string, credit *Credit, opts ...RequestOption) (*Credit, error) {
 	var resp SingleResponse[Credit]
-	if err := s.client.doRequest(ctx, "PUT", fmt.Sprintf("/api/v1/credits/%s", id), nil, credit, &resp); err != nil {
+	if err := s.client.doRequest(ctx, "PUT", s.client.apiPath("/credits/%s", id), nil, credit, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp.Data, nil
 }
 
+// Assign assigns the credit to the user with the given ID by updating only its
+// assigned user.
+func (s *CreditsService) Assign(ctx context.Context, id, userID string, opts ...RequestOption) (*Credit, error) {
+	if err := validateAssignee(userID); err != nil {
+		return nil, err
+	}
+	return s.Update(ctx, id, &Credit{AssignedUserID: userID}, opts...)
+}
+
 // Delete deletes a credit by ID.
-func (s *CreditsService) Delete(ctx context.Context, id string) error {
-	return s.client.doRequest(ctx, "DELETE", fmt.Sprintf("/api/v1/credits/%s", id), nil, nil, nil)
+func (s *CreditsService) Delete(ctx context.Context, id string, opts ...RequestOption) error {
+	return s.client.doRequest(ctx, "DELETE", s.client.apiPath("/credits/%s", id), nil, nil, nil, opts...)
 }
 
 // Bulk performs a bulk action on multiple credits.
-func (s *CreditsService) Bulk(ctx context.Context, action string, ids []string) ([]Credit, error) {
+func (s *CreditsService) Bulk(ctx context.Context, action string, ids []string, opts ...RequestOption) ([]Credit, error) {
 	req := BulkAction{
 		Action: action,
 		IDs:    ids,
 	}
 
-	var resp ListResponse[Credit]
-	if err := s.client.doRequest(ctx, "POST", "/api/v1/credits/bulk", nil, req, &resp); err != nil {
+	return bulkRequest[Credit](ctx, s.client, s.client.apiPath("/credits/bulk"), req, opts...)
+}
+
+// BulkTyped performs a bulk action on multiple credits like Bulk, but first
+// checks that action is one of BulkArchive, BulkRestore, BulkDelete,
+// BulkMarkSent and BulkEmail, returning a *ValidationError otherwise.
+func (s *CreditsService) BulkTyped(ctx context.Context, action BulkActionType, ids []string, opts ...RequestOption) ([]Credit, error) {
+	if err := checkBulkAction("credits", action, BulkMarkSent, BulkEmail); err != nil {
 		return nil, err
 	}
-	return resp.Data, nil
+	return s.Bulk(ctx, string(action), ids, opts...)
+}
+
+// BulkChunked performs a bulk action on a large number of credits by splitting ids
+// into chunks of chunkSize (DefaultBulkChunkSize when chunkSize <= 0) and sending
+// one Bulk request per chunk. It returns the credits echoed by successful chunks along
+// with a joined error of *BulkChunkError values for any chunks that failed.
+func (s *CreditsService) BulkChunked(ctx context.Context, action string, ids []string, chunkSize int, opts ...RequestOption) ([]Credit, error) {
+	return bulkChunked(ctx, ids, chunkSize, func(ctx context.Context, chunk []string) ([]Credit, error) {
+		return s.Bulk(ctx, action, chunk, opts...)
+	})
 }
 
 // Archive archives a credit.
@@ -172,7 +279,7 @@ func (s *CreditsService) Archive(ctx context.Context, id string) (*Credit, error
 	return s.bulkAction(ctx, "archive", id)
 }
 
-// Restore restores an archived credit.
+// Restore restores an archived or deleted credit.
 func (s *CreditsService) Restore(ctx context.Context, id string) (*Credit, error) {
 	return s.bulkAction(ctx, "restore", id)
 }
@@ -194,7 +301,7 @@ func (s *CreditsService) bulkAction(ctx context.Context, action, id string) (*Cr
 		return nil, err
 	}
 	if len(credits) == 0 {
-		return nil, fmt.Errorf("no credit returned from bulk action")
+		return nil, fmt.Errorf("%s credit: %w", action, ErrEmptyBulkResponse)
 	}
 	return &credits[0], nil
 }
@@ -202,8 +309,88 @@ func (s *CreditsService) bulkAction(ctx context.Context, action, id string) (*Cr
 // GetBlank retrieves a blank credit object with default values.
 func (s *CreditsService) GetBlank(ctx context.Context) (*Credit, error) {
 	var resp SingleResponse[Credit]
-	if err := s.client.doRequest(ctx, "GET", "/api/v1/credits/create", nil, nil, &resp); err != nil {
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/credits/create"), nil, nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp.Data, nil
 }
+
+// ApplyToInvoice applies amount from a credit to an invoice and returns the
+// resulting payment. Invoice Ninja models this as a zero-amount payment that
+// references both the credit and the invoice, so it is created through
+// Payments.Create, including any payment validation enabled on the client.
+//
+// The credit is fetched first; if amount exceeds its balance, an
+// *InsufficientCreditError is returned without creating the payment.
+func (s *CreditsService) ApplyToInvoice(ctx context.Context, creditID, invoiceID string, amount float64, opts ...RequestOption) (*Payment, error) {
+	if amount <= 0 {
+		verr := &ValidationError{}
+		verr.add("amount", "must be positive")
+		return nil, verr
+	}
+
+	credit, err := s.Get(ctx, creditID)
+	if err != nil {
+		return nil, err
+	}
+	if amount-credit.Balance > balanceTolerance {
+		return nil, &InsufficientCreditError{
+			CreditID: creditID,
+			Amount:   amount,
+			Balance:  credit.Balance,
+		}
+	}
+
+	return s.client.Payments.Create(ctx, &PaymentRequest{
+		ClientID: credit.ClientID,
+		Invoices: []PaymentInvoice{{InvoiceID: invoiceID, Amount: amount}},
+		Credits:  []PaymentCredit{{CreditID: creditID, Amount: amount}},
+	}, opts...)
+}
+
+// Applications retrieves the invoices a credit has been applied to.
+// Invoice Ninja applies credits through payments, so the credit is fetched with
+// its payments included and each payment's paymentables are correlated: the
+// credit portion of a payment is attributed to the invoices on that payment.
+// Remaining is worked back from the credit's current balance, so the last
+// application leaves exactly Credit.Balance.
+func (s *CreditsService) Applications(ctx context.Context, creditID string) ([]CreditApplication, error) {
+	credit, err := s.GetWith(ctx, creditID, "payments")
+	if err != nil {
+		return nil, err
+	}
+
+	var applications []CreditApplication
+	for _, payment := range credit.Payments {
+		var available float64
+		for _, p := range payment.Paymentables {
+			if p.CreditID == creditID {
+				available += p.Amount - p.Refunded
+			}
+		}
+
+		for _, p := range payment.Paymentables {
+			if p.InvoiceID == "" || available <= 0 {
+				continue
+			}
+
+			applied := min(p.Amount, available)
+			available -= applied
+
+			applications = append(applications, CreditApplication{
+				PaymentID: payment.ID,
+				InvoiceID: p.InvoiceID,
+				Amount:    applied,
+				Date:      payment.Date,
+			})
+		}
+	}
+
+	remaining := credit.Balance
+	for i := len(applications) - 1; i >= 0; i-- {
+		applications[i].Remaining = remaining
+		remaining += applications[i].Amount
+	}
+
+	return applications, nil
+}
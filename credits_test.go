@@ -3,6 +3,7 @@ package invoiceninja
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -243,3 +244,197 @@ func TestCreditListOptionsNilToQuery(t *testing.T) {
 		t.Error("expected nil query for nil options")
 	}
 }
+
+func TestCreditsServiceApplications(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/credits/cred123" {
+			t.Errorf("expected path /api/v1/credits/cred123, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("include") != "payments" {
+			t.Errorf("expected include=payments, got %s", r.URL.Query().Get("include"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"data": {
+				"id": "cred123",
+				"amount": 300.00,
+				"balance": 50.00,
+				"payments": [
+					{
+						"id": "pay1",
+						"date": "2024-01-10",
+						"paymentables": [
+							{"id": "pb1", "credit_id": "cred123", "amount": 100.00},
+							{"id": "pb2", "invoice_id": "inv1", "amount": 100.00}
+						]
+					},
+					{
+						"id": "pay2",
+						"date": "2024-02-10",
+						"paymentables": [
+							{"id": "pb3", "credit_id": "cred123", "amount": 150.00},
+							{"id": "pb4", "invoice_id": "inv2", "amount": 120.00},
+							{"id": "pb5", "invoice_id": "inv3", "amount": 80.00}
+						]
+					}
+				]
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	apps, err := client.Credits.Applications(context.Background(), "cred123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []CreditApplication{
+		{PaymentID: "pay1", InvoiceID: "inv1", Amount: 100, Date: "2024-01-10", Remaining: 200},
+		{PaymentID: "pay2", InvoiceID: "inv2", Amount: 120, Date: "2024-02-10", Remaining: 80},
+		{PaymentID: "pay2", InvoiceID: "inv3", Amount: 30, Date: "2024-02-10", Remaining: 50},
+	}
+
+	if len(apps) != len(expected) {
+		t.Fatalf("expected %d applications, got %d", len(expected), len(apps))
+	}
+
+	for i, want := range expected {
+		if apps[i] != want {
+			t.Errorf("application %d: expected %+v, got %+v", i, want, apps[i])
+		}
+	}
+}
+
+func TestCreditsServiceApplicationsRemainingFromBalance(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		// 20 of the credit was refunded, so amount less applied is not the balance
+		w.Write([]byte(`{
+			"data": {
+				"id": "cred123",
+				"amount": 300.00,
+				"balance": 30.00,
+				"payments": [
+					{
+						"id": "pay1",
+						"date": "2024-01-10",
+						"paymentables": [
+							{"id": "pb1", "credit_id": "cred123", "amount": 250.00},
+							{"id": "pb2", "invoice_id": "inv1", "amount": 200.00},
+							{"id": "pb3", "invoice_id": "inv2", "amount": 50.00}
+						]
+					}
+				]
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	apps, err := client.Credits.Applications(context.Background(), "cred123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []CreditApplication{
+		{PaymentID: "pay1", InvoiceID: "inv1", Amount: 200, Date: "2024-01-10", Remaining: 80},
+		{PaymentID: "pay1", InvoiceID: "inv2", Amount: 50, Date: "2024-01-10", Remaining: 30},
+	}
+	if len(apps) != len(expected) {
+		t.Fatalf("expected %d applications, got %d", len(expected), len(apps))
+	}
+	for i, want := range expected {
+		if apps[i] != want {
+			t.Errorf("application %d: expected %+v, got %+v", i, want, apps[i])
+		}
+	}
+}
+
+func TestCreditsServiceApplicationsNone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "cred123", "amount": 300.00, "balance": 300.00}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	apps, err := client.Credits.Applications(context.Background(), "cred123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(apps) != 0 {
+		t.Errorf("expected no applications, got %d", len(apps))
+	}
+}
+
+func TestCreditsServiceApplyToInvoice(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v1/credits/cred1":
+			w.Write([]byte(`{"data": {"id": "cred1", "client_id": "client1", "amount": 100, "balance": 60}}`))
+		case r.Method == "POST" && r.URL.Path == "/api/v1/payments":
+			var req PaymentRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.ClientID != "client1" || req.Amount != 0 {
+				t.Errorf("unexpected payment request: %+v", req)
+			}
+			if len(req.Invoices) != 1 || req.Invoices[0] != (PaymentInvoice{InvoiceID: "inv1", Amount: 40}) {
+				t.Errorf("unexpected invoices: %+v", req.Invoices)
+			}
+			if len(req.Credits) != 1 || req.Credits[0] != (PaymentCredit{CreditID: "cred1", Amount: 40}) {
+				t.Errorf("unexpected credits: %+v", req.Credits)
+			}
+			w.Write([]byte(`{"data": {"id": "pay1", "client_id": "client1", "amount": 0}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	payment, err := client.Credits.ApplyToInvoice(context.Background(), "cred1", "inv1", 40)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if payment.ID != "pay1" {
+		t.Errorf("expected payment pay1, got %s", payment.ID)
+	}
+}
+
+func TestCreditsServiceApplyToInvoiceExceedsBalance(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "cred1", "client_id": "client1", "balance": 60}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	_, err := client.Credits.ApplyToInvoice(context.Background(), "cred1", "inv1", 75)
+	var credErr *InsufficientCreditError
+	if !errors.As(err, &credErr) {
+		t.Fatalf("expected InsufficientCreditError, got %v", err)
+	}
+	if credErr.Amount != 75 || credErr.Balance != 60 {
+		t.Errorf("unexpected error fields: %+v", credErr)
+	}
+	if requests != 1 {
+		t.Errorf("expected only the credit to be fetched, got %d requests", requests)
+	}
+
+	var verr *ValidationError
+	if _, err := client.Credits.ApplyToInvoice(context.Background(), "cred1", "inv1", 0); !errors.As(err, &verr) {
+		t.Errorf("expected ValidationError for a zero amount, got %v", err)
+	}
+}
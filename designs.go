@@ -0,0 +1,106 @@
+package invoiceninja
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// DesignsService handles the PDF designs used to render invoices, quotes and
+// credits. Select one with the DesignID field of the entity.
+type DesignsService struct {
+	client *Client
+}
+
+// Design is a PDF design. Invoice Ninja ships a set of stock designs; custom
+// designs are created in the web app.
+type Design struct {
+	ID         string `json:"id,omitempty"`
+	Name       string `json:"name,omitempty"`
+	IsCustom   bool   `json:"is_custom,omitempty"`
+	IsActive   bool   `json:"is_active,omitempty"`
+	IsDeleted  bool   `json:"is_deleted,omitempty"`
+	CreatedAt  int64  `json:"created_at,omitempty"`
+	UpdatedAt  int64  `json:"updated_at,omitempty"`
+	ArchivedAt int64  `json:"archived_at,omitempty"`
+}
+
+// DesignListOptions specifies the optional parameters for listing designs.
+type DesignListOptions struct {
+	PerPage   int
+	Page      int
+	Filter    string
+	Status    string
+	IsDeleted *bool
+	Sort      string
+	Filters   []FilterExpr
+}
+
+// toQuery converts options to URL query parameters.
+func (o *DesignListOptions) toQuery() url.Values {
+	if o == nil {
+		return nil
+	}
+
+	q := url.Values{}
+
+	if o.PerPage > 0 {
+		q.Set("per_page", strconv.Itoa(o.PerPage))
+	}
+	if o.Page > 0 {
+		q.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.Filter != "" {
+		q.Set("filter", o.Filter)
+	}
+	if o.Status != "" {
+		q.Set("status", o.Status)
+	}
+	if o.IsDeleted != nil {
+		q.Set("is_deleted", strconv.FormatBool(*o.IsDeleted))
+	}
+	if o.Sort != "" {
+		q.Set("sort", o.Sort)
+	}
+
+	applyFilters(q, o.Filters)
+
+	return q
+}
+
+// List retrieves a list of designs.
+func (s *DesignsService) List(ctx context.Context, opts *DesignListOptions) (*ListResponse[Design], error) {
+	var resp ListResponse[Design]
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/designs"), opts.toQuery(), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Iter returns an iterator over all designs matching opts, fetching pages lazily.
+// Paging starts at opts.Page (or page 1) and keeps every other option.
+func (s *DesignsService) Iter(ctx context.Context, opts *DesignListOptions) *Iterator[Design] {
+	return newIterator[Design](ctx, s.client, s.client.apiPath("/designs"), opts.toQuery())
+}
+
+// ListAll retrieves all designs matching opts across every page.
+// If a page fails or ctx is done mid-scan, the designs fetched so far are
+// returned together with the error.
+func (s *DesignsService) ListAll(ctx context.Context, opts *DesignListOptions) ([]Design, error) {
+	return listAll(s.Iter(ctx, opts))
+}
+
+// Count returns the number of designs matching opts without fetching them,
+// from the pagination total of a one-item page. Paging options are ignored.
+func (s *DesignsService) Count(ctx context.Context, opts *DesignListOptions) (int, error) {
+	return count(ctx, s.client, s.client.apiPath("/designs"), opts.toQuery())
+}
+
+// Get retrieves a single design by ID.
+func (s *DesignsService) Get(ctx context.Context, id string) (*Design, error) {
+	var resp SingleResponse[Design]
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/designs/%s", id), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
@@ -0,0 +1,101 @@
+package invoiceninja
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDesignsServiceList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("expected GET method, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/designs" {
+			t.Errorf("expected path /api/v1/designs, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("per_page") != "50" {
+			t.Errorf("expected per_page=50, got %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{
+				{"id": "d1", "name": "Clean", "is_custom": false},
+				{"id": "d2", "name": "Premium Tier", "is_custom": true},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	resp, err := client.Designs.List(context.Background(), &DesignListOptions{PerPage: 50})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Data) != 2 {
+		t.Fatalf("expected 2 designs, got %d", len(resp.Data))
+	}
+	if resp.Data[0].IsCustom || !resp.Data[1].IsCustom {
+		t.Errorf("expected only the second design to be custom, got %+v", resp.Data)
+	}
+}
+
+func TestDesignsServiceGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/designs/d2" {
+			t.Errorf("expected path /api/v1/designs/d2, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "d2", "name": "Premium Tier", "is_custom": true}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	design, err := client.Designs.Get(context.Background(), "d2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if design.Name != "Premium Tier" || !design.IsCustom {
+		t.Errorf("unexpected design: %+v", design)
+	}
+}
+
+func TestDesignIDIsSent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["design_id"] != "d2" {
+			t.Errorf("%s: expected design_id d2, got %v", r.URL.Path, body["design_id"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "x1", "design_id": "d2"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	invoice, err := client.Invoices.Create(ctx, NewInvoiceBuilder("c1").WithDesign("d2").Build())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if invoice.DesignID != "d2" {
+		t.Errorf("expected invoice design d2, got %q", invoice.DesignID)
+	}
+
+	if _, err := client.Quotes.Create(ctx, &Quote{ClientID: "c1", DesignID: "d2"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Credits.Create(ctx, &Credit{ClientID: "c1", DesignID: "d2"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
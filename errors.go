@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
+	"strings"
+	"unicode/utf8"
 )
 
 // APIError represents an error returned by the Invoice Ninja API.
@@ -17,14 +20,22 @@ type APIError struct {
 
 	// Errors contains field-specific validation errors.
 	Errors map[string][]string `json:"errors,omitempty"`
+
+	// RequestID is the X-Request-Id of the response, if the server sent one.
+	// Include it in support tickets.
+	RequestID string `json:"-"`
 }
 
 // Error implements the error interface.
 func (e *APIError) Error() string {
+	msg := fmt.Sprintf("Invoice Ninja API error (status %d)", e.StatusCode)
 	if e.Message != "" {
-		return fmt.Sprintf("Invoice Ninja API error (status %d): %s", e.StatusCode, e.Message)
+		msg += ": " + e.Message
+	}
+	if e.RequestID != "" {
+		msg += " (request ID " + e.RequestID + ")"
 	}
-	return fmt.Sprintf("Invoice Ninja API error (status %d)", e.StatusCode)
+	return msg
 }
 
 // IsNotFound returns true if the error is a 404 Not Found error.
@@ -57,10 +68,92 @@ func (e *APIError) IsServerError() bool {
 	return e.StatusCode >= 500
 }
 
-// parseAPIError parses an API error response.
-func parseAPIError(statusCode int, body []byte) *APIError {
+// Sentinel errors for recognised API error conditions. An *APIError matches
+// them with errors.Is, so callers can branch without inspecting status codes
+// or messages:
+//
+//	if errors.Is(err, invoiceninja.ErrClientDeleted) {
+//		// restore the client first
+//	}
+var (
+	// ErrNotFound matches 404 Not Found responses.
+	ErrNotFound = errors.New("resource not found")
+
+	// ErrUnauthorized matches 401 Unauthorized responses, usually a bad API token.
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrForbidden matches 403 Forbidden responses.
+	ErrForbidden = errors.New("forbidden")
+
+	// ErrRateLimited matches 429 Too Many Requests responses.
+	ErrRateLimited = errors.New("rate limit exceeded")
+
+	// ErrClientDeleted matches errors about a client that has been deleted.
+	ErrClientDeleted = errors.New("client has been deleted")
+
+	// ErrInsufficientCredit matches errors about a credit balance too low for
+	// the amount applied. An *InsufficientCreditError matches it too.
+	ErrInsufficientCredit = errors.New("insufficient credit balance")
+
+	// ErrInvoiceAlreadyPaid matches errors about paying an invoice that is
+	// already paid.
+	ErrInvoiceAlreadyPaid = errors.New("invoice is already paid")
+)
+
+// apiErrorCondition describes how an *APIError is recognised as a sentinel:
+// by status code, or by a lower-cased phrase in its message or field errors.
+type apiErrorCondition struct {
+	target  error
+	status  int
+	phrases []string
+}
+
+var apiErrorConditions = []apiErrorCondition{
+	{target: ErrNotFound, status: http.StatusNotFound},
+	{target: ErrUnauthorized, status: http.StatusUnauthorized},
+	{target: ErrForbidden, status: http.StatusForbidden},
+	{target: ErrRateLimited, status: http.StatusTooManyRequests},
+	{target: ErrClientDeleted, phrases: []string{"client has been deleted", "client is deleted", "deleted client"}},
+	{target: ErrInsufficientCredit, phrases: []string{"insufficient credit", "exceeds the credit", "more than the credit"}},
+	{target: ErrInvoiceAlreadyPaid, phrases: []string{"already paid", "invoice is paid", "already been paid"}},
+}
+
+// Is reports whether the error matches one of the sentinel errors such as
+// ErrNotFound or ErrClientDeleted, so errors.Is works on API errors.
+func (e *APIError) Is(target error) bool {
+	for _, cond := range apiErrorConditions {
+		if cond.target != target {
+			continue
+		}
+		if cond.status != 0 {
+			return e.StatusCode == cond.status
+		}
+		return e.mentions(cond.phrases)
+	}
+	return false
+}
+
+// mentions reports whether the message or any field error contains one of
+// phrases, ignoring case.
+func (e *APIError) mentions(phrases []string) bool {
+	if containsAny(strings.ToLower(e.Message), phrases) {
+		return true
+	}
+	for _, messages := range e.Errors {
+		for _, msg := range messages {
+			if containsAny(strings.ToLower(msg), phrases) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseAPIError parses an API error response, decoding body with codec.
+func parseAPIError(codec Codec, statusCode int, header http.Header, body []byte) *APIError {
 	apiErr := &APIError{
 		StatusCode: statusCode,
+		RequestID:  header.Get(RequestIDHeader),
 	}
 
 	// Try to parse the error response
@@ -69,7 +162,7 @@ func parseAPIError(statusCode int, body []byte) *APIError {
 			Message string              `json:"message"`
 			Errors  map[string][]string `json:"errors"`
 		}
-		if err := json.Unmarshal(body, &errResp); err == nil {
+		if err := codec.Unmarshal(body, &errResp); err == nil {
 			apiErr.Message = errResp.Message
 			apiErr.Errors = errResp.Errors
 		}
@@ -100,6 +193,269 @@ func parseAPIError(statusCode int, body []byte) *APIError {
 	return apiErr
 }
 
+// FieldError describes a problem with a single field of a request.
+type FieldError struct {
+	// Field is the JSON name of the field (e.g. "contacts[0].email").
+	Field string
+
+	// Message describes the problem.
+	Message string
+}
+
+// ValidationError is returned when a request fails client-side checks before
+// it is sent. It lists every invalid field that was found.
+type ValidationError struct {
+	// Fields contains the individual field problems.
+	Fields []FieldError
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	problems := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		problems[i] = f.Field + ": " + f.Message
+	}
+	return "validation failed: " + strings.Join(problems, "; ")
+}
+
+// add records a field problem.
+func (e *ValidationError) add(field, message string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: message})
+}
+
+// errOrNil returns e if any problems were recorded, otherwise nil.
+func (e *ValidationError) errOrNil() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+// OverApplicationError is returned when a payment would apply more to an
+// invoice than the invoice's outstanding balance.
+type OverApplicationError struct {
+	// InvoiceID is the invoice the payment over-applies to.
+	InvoiceID string
+
+	// Amount is the total amount the payment applies to the invoice.
+	Amount float64
+
+	// Balance is the invoice's outstanding balance.
+	Balance float64
+}
+
+// Error implements the error interface.
+func (e *OverApplicationError) Error() string {
+	return fmt.Sprintf("payment applies %.2f to invoice %s but its balance is %.2f", e.Amount, e.InvoiceID, e.Balance)
+}
+
+// InsufficientCreditError is returned when more is applied from a credit than
+// its remaining balance.
+type InsufficientCreditError struct {
+	// CreditID is the credit being applied.
+	CreditID string
+
+	// Amount is the amount to apply.
+	Amount float64
+
+	// Balance is the credit's remaining balance.
+	Balance float64
+}
+
+// Error implements the error interface.
+func (e *InsufficientCreditError) Error() string {
+	return fmt.Sprintf("cannot apply %.2f from credit %s: its balance is %.2f", e.Amount, e.CreditID, e.Balance)
+}
+
+// Is reports whether target is ErrInsufficientCredit.
+func (e *InsufficientCreditError) Is(target error) bool {
+	return target == ErrInsufficientCredit
+}
+
+// maxSnippetLen is the maximum length of the response body excerpt in a DecodeError.
+const maxSnippetLen = 200
+
+// sensitiveJSONValue matches JSON string values of keys that commonly hold secrets.
+var sensitiveJSONValue = regexp.MustCompile(`(?i)("[a-z_]*(?:token|password|secret|api_key)[a-z_]*"\s*:\s*)"[^"]*"`)
+
+// ErrNoInvitations is returned for an invoice without invitations, either
+// because its client has no contacts or because it was fetched without them.
+var ErrNoInvitations = errors.New("invoice has no invitations")
+
+// ErrInvalidBaseURL is returned by NewClientWithError when WithBaseURL is
+// given a URL without an http or https scheme, without a host or with a
+// query or fragment.
+var ErrInvalidBaseURL = errors.New("invalid base URL")
+
+// ErrEmptyResponse is returned when a request that expects data gets a
+// 200 OK or 201 Created with an empty body. A 204 No Content is not an error:
+// the result is left unchanged, and calls that expect no data, such as
+// Delete, succeed whatever the status.
+var ErrEmptyResponse = errors.New("empty response body")
+
+// Auto-bill failure reasons, matched with errors.Is on an *AutoBillError.
+var (
+	// ErrNoPaymentMethod means the client has no stored payment method to charge.
+	ErrNoPaymentMethod = errors.New("no stored payment method")
+
+	// ErrPaymentDeclined means the gateway declined the charge.
+	ErrPaymentDeclined = errors.New("payment declined")
+)
+
+// AutoBillError is returned by InvoicesService.AutoBill when the server
+// rejects the charge.
+type AutoBillError struct {
+	// InvoiceID is the invoice that could not be billed.
+	InvoiceID string
+
+	// Reason is ErrNoPaymentMethod, ErrPaymentDeclined, or nil if the
+	// server's message could not be classified.
+	Reason error
+
+	// APIError is the server's response.
+	APIError *APIError
+}
+
+// Error implements the error interface.
+func (e *AutoBillError) Error() string {
+	if e.Reason != nil {
+		return fmt.Sprintf("auto-bill of invoice %s failed: %v: %s", e.InvoiceID, e.Reason, e.APIError.Message)
+	}
+	return fmt.Sprintf("auto-bill of invoice %s failed: %s", e.InvoiceID, e.APIError.Message)
+}
+
+// Unwrap returns the reason and the API error, so both errors.Is with
+// ErrPaymentDeclined and IsAPIError work on an *AutoBillError.
+func (e *AutoBillError) Unwrap() []error {
+	if e.Reason == nil {
+		return []error{e.APIError}
+	}
+	return []error{e.Reason, e.APIError}
+}
+
+// Phrases in gateway error messages, lower-cased, that identify auto-bill failures.
+var (
+	noPaymentMethodPhrases = []string{"no payment method", "no valid payment method", "payment method not found", "no gateway token"}
+	declinedPhrases        = []string{"declined", "insufficient funds", "do not honor", "card has expired", "expired card"}
+)
+
+// newAutoBillError classifies a failed auto-bill response.
+func newAutoBillError(invoiceID string, apiErr *APIError) *AutoBillError {
+	msg := strings.ToLower(apiErr.Message)
+	e := &AutoBillError{InvoiceID: invoiceID, APIError: apiErr}
+
+	switch {
+	case containsAny(msg, noPaymentMethodPhrases):
+		e.Reason = ErrNoPaymentMethod
+	case containsAny(msg, declinedPhrases):
+		e.Reason = ErrPaymentDeclined
+	}
+	return e
+}
+
+// containsAny reports whether s contains any of phrases.
+func containsAny(s string, phrases []string) bool {
+	for _, p := range phrases {
+		if strings.Contains(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// DecodeError is returned when a successful response cannot be decoded into
+// the expected type, for example when the server sends a number as a string.
+type DecodeError struct {
+	// Method and Path identify the request.
+	Method string
+	Path   string
+
+	// Field is the JSON path of the offending field (e.g. "data.amount"), if known.
+	Field string
+
+	// Snippet is an excerpt of the response body around the problem, truncated
+	// and with secrets redacted.
+	Snippet string
+
+	// Err is the underlying encoding/json error.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *DecodeError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "failed to unmarshal response from %s %s", e.Method, e.Path)
+	if e.Field != "" {
+		fmt.Fprintf(&b, ": field %q", e.Field)
+	}
+	fmt.Fprintf(&b, ": %v (body: %s)", e.Err, e.Snippet)
+	return b.String()
+}
+
+// Unwrap returns the underlying decode error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// newDecodeError builds a DecodeError for body, redacting secret. The whole
+// body is redacted before the snippet is cut from it, so a secret straddling
+// the snippet's edge cannot leak partly.
+func newDecodeError(method, path string, body []byte, err error, secret string) *DecodeError {
+	decodeErr := &DecodeError{Method: method, Path: path, Err: err}
+
+	offset := -1
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &typeErr):
+		decodeErr.Field = typeErr.Field
+		offset = int(typeErr.Offset)
+	case errors.As(err, &syntaxErr):
+		offset = int(syntaxErr.Offset)
+	}
+
+	redacted := redactBody(string(body), secret)
+	start := 0
+	if offset >= 0 {
+		// Map the offset into the redacted body. A prefix ending inside a
+		// secret is redacted differently than the whole body, so the mapped
+		// offset is only approximate and may run past the end.
+		offset = len(redactBody(string(body[:min(offset, len(body))]), secret))
+		offset = min(offset, len(redacted))
+		start = offset - maxSnippetLen/2
+	}
+	start = max(0, min(start, len(redacted)-maxSnippetLen))
+	end := min(len(redacted), start+maxSnippetLen)
+
+	// Keep multi-byte characters whole
+	for start < end && !utf8.RuneStart(redacted[start]) {
+		start++
+	}
+	for end > start && end < len(redacted) && !utf8.RuneStart(redacted[end]) {
+		end--
+	}
+
+	snippet := redacted[start:end]
+	if start > 0 {
+		snippet = "..." + snippet
+	}
+	if end < len(redacted) {
+		snippet += "..."
+	}
+	decodeErr.Snippet = snippet
+
+	return decodeErr
+}
+
+// redactBody replaces secret and the values of secret-looking JSON keys in
+// body with "[REDACTED]".
+func redactBody(body, secret string) string {
+	if secret != "" {
+		body = strings.ReplaceAll(body, secret, "[REDACTED]")
+	}
+	return sensitiveJSONValue.ReplaceAllString(body, `$1"[REDACTED]"`)
+}
+
 // IsAPIError checks if an error is an APIError and returns it.
 func IsAPIError(err error) (*APIError, bool) {
 	var apiErr *APIError
@@ -1,8 +1,15 @@
 package invoiceninja
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"unicode/utf8"
 )
 
 func TestAPIErrorError(t *testing.T) {
@@ -161,7 +168,7 @@ func TestParseAPIError(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := parseAPIError(tt.statusCode, tt.body)
+			err := parseAPIError(stdCodec{}, tt.statusCode, nil, tt.body)
 
 			if err.StatusCode != tt.statusCode {
 				t.Errorf("StatusCode = %v, want %v", err.StatusCode, tt.statusCode)
@@ -202,3 +209,132 @@ func TestIsAPIError(t *testing.T) {
 		t.Error("expected ok to be false for nil error")
 	}
 }
+
+func TestDecodeErrorIncludesFieldAndSnippet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "inv1", "amount": "12.50", "token": "secret-value", "note": "sent with test-token"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	_, err := client.Invoices.Get(context.Background(), "inv1")
+
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("expected *DecodeError, got %v", err)
+	}
+
+	if decodeErr.Field != "data.amount" {
+		t.Errorf("expected field data.amount, got %q", decodeErr.Field)
+	}
+
+	if decodeErr.Method != "GET" || decodeErr.Path != "/api/v1/invoices/inv1" {
+		t.Errorf("unexpected request in error: %s %s", decodeErr.Method, decodeErr.Path)
+	}
+
+	msg := err.Error()
+	for _, want := range []string{`field "data.amount"`, `"amount": "12.50"`, "/api/v1/invoices/inv1"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("expected error to contain %q, got %s", want, msg)
+		}
+	}
+
+	for _, secret := range []string{"secret-value", "test-token"} {
+		if strings.Contains(msg, secret) {
+			t.Errorf("expected %q to be redacted, got %s", secret, msg)
+		}
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) {
+		t.Error("expected error to unwrap to *json.UnmarshalTypeError")
+	}
+}
+
+func TestDecodeErrorTruncatesSnippet(t *testing.T) {
+	body := []byte(`{"data": [` + strings.Repeat(`{"id": "x"},`, 100) + `{"id": 5}]}`)
+
+	err := newDecodeError("GET", "/api/v1/clients", body, json.Unmarshal(body, &ListResponse[INClient]{}), "")
+
+	if !strings.HasPrefix(err.Snippet, "...") || !strings.HasSuffix(err.Snippet, `{"id": 5}]}`) {
+		t.Errorf("expected snippet around the end of the body, got %q", err.Snippet)
+	}
+
+	if len(err.Snippet) > maxSnippetLen+6 {
+		t.Errorf("expected snippet to be truncated, got %d bytes", len(err.Snippet))
+	}
+}
+
+func TestDecodeErrorRedactsBeforeTruncating(t *testing.T) {
+	// Pad the body so that a snippet cut before redacting would start
+	// inside the token and keep its tail
+	tail := `token-1234", "pad": "%s", "data": [{"id": 5}]}`
+	pad := strings.Repeat("x", maxSnippetLen-len(tail)+2)
+	body := []byte(`{"note": "sent with test-` + fmt.Sprintf(tail, pad))
+
+	err := newDecodeError("GET", "/api/v1/clients", body, json.Unmarshal(body, &ListResponse[INClient]{}), "test-token-1234")
+
+	if strings.Contains(err.Snippet, "1234") {
+		t.Errorf("expected the token to be redacted, got %q", err.Snippet)
+	}
+	if !strings.Contains(err.Snippet, "[REDACTED]") || !strings.HasSuffix(err.Snippet, `{"id": 5}]}`) {
+		t.Errorf("expected a redacted snippet around the error, got %q", err.Snippet)
+	}
+}
+
+func TestDecodeErrorSnippetKeepsRunesWhole(t *testing.T) {
+	for shift := 0; shift < 3; shift++ {
+		body := []byte(`{"data": [{"name": "` + strings.Repeat("x", shift) + strings.Repeat("€", 100) + `", "id": 5}]}`)
+
+		err := newDecodeError("GET", "/api/v1/clients", body, json.Unmarshal(body, &ListResponse[INClient]{}), "")
+
+		if !utf8.ValidString(err.Snippet) {
+			t.Errorf("shift %d: expected valid UTF-8, got %q", shift, err.Snippet)
+		}
+		if !strings.HasSuffix(err.Snippet, `"id": 5}]}`) {
+			t.Errorf("shift %d: expected snippet around the error, got %q", shift, err.Snippet)
+		}
+	}
+}
+
+func TestAPIErrorIs(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    *APIError
+		target error
+		want   bool
+	}{
+		{"not found", &APIError{StatusCode: 404}, ErrNotFound, true},
+		{"unauthorized", &APIError{StatusCode: 401}, ErrUnauthorized, true},
+		{"forbidden", &APIError{StatusCode: 403}, ErrForbidden, true},
+		{"rate limited", &APIError{StatusCode: 429}, ErrRateLimited, true},
+		{"status mismatch", &APIError{StatusCode: 500}, ErrNotFound, false},
+		{"client deleted", &APIError{StatusCode: 400, Message: "Client has been deleted"}, ErrClientDeleted, true},
+		{"credit in field errors", &APIError{StatusCode: 422, Message: "The given data was invalid.", Errors: map[string][]string{
+			"credits.0.amount": {"Insufficient credit balance"},
+		}}, ErrInsufficientCredit, true},
+		{"credit balance mentioned", &APIError{StatusCode: 422, Message: "The credit balance field must be a number."}, ErrInsufficientCredit, false},
+		{"already paid", &APIError{StatusCode: 422, Message: "Invoice is already paid"}, ErrInvoiceAlreadyPaid, true},
+		{"unrelated message", &APIError{StatusCode: 422, Message: "The given data was invalid."}, ErrClientDeleted, false},
+		{"unknown target", &APIError{StatusCode: 404}, errors.New("other"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Wrap the error to check errors.Is unwraps to the APIError
+			err := fmt.Errorf("listing: %w", tt.err)
+			if got := errors.Is(err, tt.target); got != tt.want {
+				t.Errorf("errors.Is(%v, %v) = %v, want %v", tt.err, tt.target, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInsufficientCreditErrorIs(t *testing.T) {
+	err := &InsufficientCreditError{CreditID: "cred1", Amount: 10, Balance: 5}
+	if !errors.Is(err, ErrInsufficientCredit) {
+		t.Error("expected InsufficientCreditError to match ErrInsufficientCredit")
+	}
+}
@@ -55,6 +55,9 @@ func main() {
 	}
 
 	fmt.Printf("Found %d payments\n", len(payments.Data))
+	if payments.HasNextPage() {
+		fmt.Printf("  (more on page %d)\n", payments.Meta.Pagination.NextPage())
+	}
 	for _, p := range payments.Data {
 		fmt.Printf("  - Payment %s: $%.2f (Client: %s)\n", p.Number, p.Amount, p.ClientID)
 	}
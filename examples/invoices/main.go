@@ -31,46 +31,41 @@ func main() {
 		log.Fatal("INVOICE_NINJA_CLIENT_ID environment variable is required")
 	}
 
+	// The currency of the client's invoices, used to format amounts
+	currency := os.Getenv("INVOICE_NINJA_CURRENCY")
+	if currency == "" {
+		currency = "USD"
+	}
+
 	client := invoiceninja.NewClient(token)
 	ctx := context.Background()
 
 	// Create an invoice with line items
 	fmt.Println("=== Creating Invoice ===")
-	invoice, err := client.Invoices.Create(ctx, &invoiceninja.Invoice{
-		ClientID: clientID,
-		Date:     time.Now().Format("2006-01-02"),
-		DueDate:  time.Now().AddDate(0, 0, 30).Format("2006-01-02"),
-		LineItems: []invoiceninja.LineItem{
-			{
-				ProductKey: "Consulting",
-				Notes:      "Professional consulting services",
-				Quantity:   10,
-				Cost:       150.00,
-			},
-			{
-				ProductKey: "Development",
-				Notes:      "Custom software development",
-				Quantity:   20,
-				Cost:       125.00,
-			},
-			{
-				ProductKey: "Support",
-				Notes:      "Technical support hours",
-				Quantity:   5,
-				Cost:       75.00,
-			},
-		},
+	draft := &invoiceninja.Invoice{
+		ClientID:    clientID,
+		Date:        time.Now().Format("2006-01-02"),
+		DueDate:     time.Now().AddDate(0, 0, 30).Format("2006-01-02"),
 		PublicNotes: "Thank you for your business!",
 		Terms:       "Payment due within 30 days",
 		Footer:      "Please make checks payable to Acme Corp",
-	})
+	}
+	draft.AddLineItem(invoiceninja.LineItem{ProductKey: "Consulting", Notes: "Professional consulting services", Quantity: 10, Cost: 150.00})
+	draft.AddLineItem(invoiceninja.LineItem{ProductKey: "Development", Notes: "Custom software development", Quantity: 20, Cost: 125.00})
+	draft.AddLineItem(invoiceninja.LineItem{ProductKey: "Support", Notes: "Technical support hours", Quantity: 5, Cost: 75.00})
+
+	for _, item := range draft.LineItems {
+		fmt.Printf("  %-12s %s\n", item.ProductKey, invoiceninja.FormatMoney(item.LineTotal(), currency))
+	}
+
+	invoice, err := client.Invoices.Create(ctx, draft)
 	if err != nil {
 		log.Fatalf("Error creating invoice: %v", err)
 	}
 
 	fmt.Printf("Created invoice: %s\n", invoice.Number)
-	fmt.Printf("  Amount: $%.2f\n", invoice.Amount)
-	fmt.Printf("  Balance: $%.2f\n", invoice.Balance)
+	fmt.Printf("  Amount: %s\n", invoiceninja.FormatMoney(invoice.Amount, currency))
+	fmt.Printf("  Balance: %s\n", invoiceninja.FormatMoney(invoice.Balance, currency))
 
 	// Get the invoice details
 	fmt.Println("\n=== Getting Invoice Details ===")
@@ -0,0 +1,127 @@
+package invoiceninja
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExportCSV writes every entity produced by it to w as CSV, with a header row
+// followed by one row per entity.
+//
+// columns selects the fields to export by their JSON names, e.g. "number" or
+// "amount". Nested struct fields are addressed with dots, e.g.
+// "webhook_configuration.return_url". Floats are written with two decimals and
+// int64 fields ending in "_at" are written as RFC 3339 UTC timestamps (empty
+// when zero). An unknown column is reported before anything is written.
+//
+//	it := client.Invoices.Iter(ctx, nil)
+//	err := invoiceninja.ExportCSV(ctx, it, os.Stdout, []string{"number", "amount", "due_date"})
+func ExportCSV[T any](ctx context.Context, it *Iterator[T], w io.Writer, columns []string) error {
+	paths := make([][]int, len(columns))
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	for i, column := range columns {
+		path, err := fieldPath(typ, column)
+		if err != nil {
+			return err
+		}
+		paths[i] = path
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+
+	row := make([]string, len(columns))
+	for it.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		v := reflect.ValueOf(it.Value())
+		for i, path := range paths {
+			row[i] = formatCSVValue(v, path, columns[i])
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return err
+	}
+	return it.Err()
+}
+
+// fieldPath resolves a dotted JSON field name to a field index path on typ.
+func fieldPath(typ reflect.Type, column string) ([]int, error) {
+	var path []int
+	for _, name := range strings.Split(column, ".") {
+		for typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("unknown column %q", column)
+		}
+
+		field, ok := jsonField(typ, name)
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q", column)
+		}
+		path = append(path, field.Index[0])
+		typ = field.Type
+	}
+	return path, nil
+}
+
+// jsonField finds the field of typ whose JSON name is name.
+func jsonField(typ reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if tag == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// formatCSVValue follows path from v and formats the field for CSV output.
+func formatCSVValue(v reflect.Value, path []int, column string) string {
+	for _, index := range path {
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return ""
+			}
+			v = v.Elem()
+		}
+		v = v.Field(index)
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return ""
+		}
+		return formatCSVValue(v.Elem(), nil, column)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', 2, 64)
+	case reflect.Int64:
+		if strings.HasSuffix(column, "_at") {
+			if v.Int() == 0 {
+				return ""
+			}
+			return time.Unix(v.Int(), 0).UTC().Format(time.RFC3339)
+		}
+		return strconv.FormatInt(v.Int(), 10)
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
@@ -0,0 +1,77 @@
+package invoiceninja
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestExportCSVInvoices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": [
+			{"id": "inv1", "number": "INV-001", "amount": 100, "balance": 33.333, "created_at": 1700000000},
+			{"id": "inv2", "number": "INV-002, rush", "amount": 12.5}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	err := ExportCSV(ctx, client.Invoices.Iter(ctx, nil), &buf, []string{"number", "amount", "balance", "created_at"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "number,amount,balance,created_at\n" +
+		"INV-001,100.00,33.33,2023-11-14T22:13:20Z\n" +
+		"\"INV-002, rush\",12.50,0.00,\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected CSV:\n%s\nwant:\n%s", buf.String(), expected)
+	}
+}
+
+func TestExportCSVNestedFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": [
+			{"name": "Pro", "webhook_configuration": {"return_url": "https://example.com/done"}},
+			{"name": "Basic"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	err := ExportCSV(ctx, client.Subscriptions.Iter(ctx, nil), &buf, []string{"name", "webhook_configuration.return_url"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "name,webhook_configuration.return_url\nPro,https://example.com/done\nBasic,\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected CSV:\n%s", buf.String())
+	}
+}
+
+func TestExportCSVUnknownColumn(t *testing.T) {
+	client := NewClient("test-token", WithBaseURL("http://127.0.0.1:0"))
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	err := ExportCSV(ctx, client.Invoices.Iter(ctx, nil), &buf, []string{"number", "colour"})
+	if err == nil || !strings.Contains(err.Error(), `unknown column "colour"`) {
+		t.Fatalf("expected unknown column error, got %v", err)
+	}
+
+	if buf.Len() != 0 {
+		t.Errorf("expected nothing written, got %q", buf.String())
+	}
+}
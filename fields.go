@@ -0,0 +1,66 @@
+package invoiceninja
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// fieldSelection zeroes the fields of a T that are not named in a Fields list
+// option. Invoice Ninja has no sparse fieldsets, so the selection is applied
+// after the full entities have been decoded. A nil selection keeps every field.
+type fieldSelection[T any] struct {
+	keep []bool
+}
+
+// newFieldSelection builds a selection of the fields with the given JSON
+// names; "id" is always kept. It returns nil if fields is empty and a
+// *ValidationError if a name is not a field of T.
+func newFieldSelection[T any](fields []string) (*fieldSelection[T], error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	index := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			index[name] = i
+		}
+	}
+
+	sel := &fieldSelection[T]{keep: make([]bool, t.NumField())}
+	if i, ok := index["id"]; ok {
+		sel.keep[i] = true
+	}
+
+	verr := &ValidationError{}
+	for _, name := range fields {
+		i, ok := index[name]
+		if !ok {
+			verr.add("fields", fmt.Sprintf("%q is not a field of %s", name, t.Name()))
+			continue
+		}
+		sel.keep[i] = true
+	}
+	if err := verr.errOrNil(); err != nil {
+		return nil, err
+	}
+	return sel, nil
+}
+
+// apply zeroes the unselected fields of every item.
+func (s *fieldSelection[T]) apply(items []T) {
+	if s == nil {
+		return
+	}
+	for n := range items {
+		v := reflect.ValueOf(&items[n]).Elem()
+		for i, keep := range s.keep {
+			if !keep {
+				v.Field(i).SetZero()
+			}
+		}
+	}
+}
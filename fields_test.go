@@ -0,0 +1,97 @@
+package invoiceninja
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFieldSelection(t *testing.T) {
+	sel, err := newFieldSelection[Invoice]([]string{"number", "balance"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	items := []Invoice{{
+		ID:        "inv1",
+		Number:    "0001",
+		Balance:   50,
+		Amount:    100,
+		ClientID:  "c1",
+		LineItems: []LineItem{{Quantity: 1, Cost: 100}},
+	}}
+	sel.apply(items)
+
+	got := items[0]
+	if got.ID != "inv1" || got.Number != "0001" || got.Balance != 50 {
+		t.Errorf("expected the selected fields and ID to be kept, got %+v", got)
+	}
+	if got.Amount != 0 || got.ClientID != "" || got.LineItems != nil {
+		t.Errorf("expected the other fields to be zeroed, got %+v", got)
+	}
+}
+
+func TestFieldSelectionNone(t *testing.T) {
+	sel, err := newFieldSelection[Invoice](nil)
+	if err != nil || sel != nil {
+		t.Fatalf("expected no selection, got %v, %v", sel, err)
+	}
+
+	items := []Invoice{{ID: "inv1", Amount: 100}}
+	sel.apply(items)
+	if items[0].Amount != 100 {
+		t.Error("expected a nil selection to keep every field")
+	}
+}
+
+func TestFieldSelectionUnknownField(t *testing.T) {
+	_, err := newFieldSelection[Invoice]([]string{"number", "totl"})
+
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected a *ValidationError, got %v", err)
+	}
+}
+
+func TestInvoicesServiceListFields(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Has("fields") {
+			t.Errorf("expected no fields parameter, got %q", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": [{"id": "inv1", "number": "0001", "amount": 100, "balance": 40, "line_items": [{"cost": 100}]}],
+			"meta": {"pagination": {"total": 1, "total_pages": 1}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	ctx := context.Background()
+	opts := &InvoiceListOptions{Fields: []string{"number", "balance"}}
+
+	resp, err := client.Invoices.List(ctx, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	all, err := client.Invoices.ListAll(ctx, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, inv := range []Invoice{resp.Data[0], all[0]} {
+		if inv.ID != "inv1" || inv.Number != "0001" || inv.Balance != 40 || inv.Amount != 0 || inv.LineItems != nil {
+			t.Errorf("unexpected invoice: %+v", inv)
+		}
+	}
+
+	if _, err := client.Invoices.ListAll(ctx, &InvoiceListOptions{Fields: []string{"nope"}}); err == nil {
+		t.Error("expected an error for an unknown field")
+	}
+	if requests != 2 {
+		t.Errorf("expected no request for an unknown field, got %d requests", requests)
+	}
+}
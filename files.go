@@ -3,12 +3,18 @@ package invoiceninja
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 )
 
 // DownloadsService handles file download operations.
@@ -18,47 +24,265 @@ type DownloadsService struct {
 
 // DownloadInvoicePDF downloads an invoice PDF by invitation key.
 func (s *DownloadsService) DownloadInvoicePDF(ctx context.Context, invitationKey string) ([]byte, error) {
-	return s.downloadFile(ctx, fmt.Sprintf("/api/v1/invoice/%s/download", invitationKey))
+	return s.downloadFile(ctx, s.client.apiPath("/invoice/%s/download", invitationKey))
 }
 
 // DownloadInvoiceDeliveryNote downloads an invoice delivery note PDF.
 func (s *DownloadsService) DownloadInvoiceDeliveryNote(ctx context.Context, invoiceID string) ([]byte, error) {
-	return s.downloadFile(ctx, fmt.Sprintf("/api/v1/invoices/%s/delivery_note", invoiceID))
+	return s.downloadFile(ctx, s.client.apiPath("/invoices/%s/delivery_note", invoiceID))
 }
 
 // DownloadCreditPDF downloads a credit PDF by invitation key.
 func (s *DownloadsService) DownloadCreditPDF(ctx context.Context, invitationKey string) ([]byte, error) {
-	return s.downloadFile(ctx, fmt.Sprintf("/api/v1/credit/%s/download", invitationKey))
+	return s.downloadFile(ctx, s.client.apiPath("/credit/%s/download", invitationKey))
 }
 
 // DownloadQuotePDF downloads a quote PDF by invitation key.
 func (s *DownloadsService) DownloadQuotePDF(ctx context.Context, invitationKey string) ([]byte, error) {
-	return s.downloadFile(ctx, fmt.Sprintf("/api/v1/quote/%s/download", invitationKey))
+	return s.downloadFile(ctx, s.client.apiPath("/quote/%s/download", invitationKey))
 }
 
-// downloadFile performs a file download request.
+// DownloadInvoiceEInvoice downloads the e-invoice XML of the invoice with the
+// given ID, in the format set by the company's e-invoice settings (e.g.
+// Factur-X, ZUGFeRD or UBL). E-invoicing must be enabled for the company.
+// E-invoices are served per invitation, so the invoice is fetched first and its
+// first invitation is used; an error matching ErrNoInvitations is returned if
+// it has none.
+func (s *DownloadsService) DownloadInvoiceEInvoice(ctx context.Context, invoiceID string) ([]byte, error) {
+	invoice, err := s.client.Invoices.GetWith(ctx, invoiceID, "invitations")
+	if err != nil {
+		return nil, err
+	}
+	if len(invoice.Invitations) == 0 || invoice.Invitations[0].Key == "" {
+		return nil, fmt.Errorf("invoice %s: %w", invoiceID, ErrNoInvitations)
+	}
+	path := s.client.apiPath("/invoice/%s/download_e_invoice", invoice.Invitations[0].Key)
+	return s.download(ctx, path, "application/xml", "text/xml")
+}
+
+// zipPollInterval is the wait between checks of a pending zip archive URL.
+var zipPollInterval = 2 * time.Second
+
+// zipMaxPolls is the number of checks of a pending zip archive URL after which
+// DownloadInvoicesZip gives up, five minutes at the default interval.
+var zipMaxPolls = 150
+
+// zipNotFoundRetries is the number of 404 answers tolerated while a zip
+// archive is being written to storage; another 404 is returned as an error.
+const zipNotFoundRetries = 3
+
+// ErrDownloadNotReady is returned by DownloadInvoicesZip when the archive is
+// still not ready after the polling limit.
+var ErrDownloadNotReady = errors.New("download is not ready")
+
+// DownloadInvoicesZip downloads the PDFs of several invoices as one zip archive
+// using the invoices bulk "download" action.
+//
+// The archive is returned directly when the server streams it. If the server
+// prepares it in the background and answers with a download URL instead, the
+// URL is polled until the archive is ready, ctx is done or about five minutes
+// have passed, when an error matching ErrDownloadNotReady is returned. A URL
+// that keeps answering 404 Not Found fails after a few attempts. A background
+// job hash is likewise waited for with Client.WaitForJob. The archive counts
+// against WithMaxDownloadBytes, not WithMaxResponseBytes.
+func (s *DownloadsService) DownloadInvoicesZip(ctx context.Context, invoiceIDs []string) ([]byte, error) {
+	if len(invoiceIDs) == 0 {
+		return nil, fmt.Errorf("no invoice IDs to download")
+	}
+
+	path := s.client.apiPath("/invoices/bulk")
+	body, err := s.client.jsonCodec().Marshal(BulkAction{Action: "download", IDs: invoiceIDs})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	resp, err := s.client.sendLimited(ctx, "POST", s.client.BaseURL()+path, body, s.client.maxDownloadBytes,
+		WithHeader("Accept", "application/zip, application/json"))
+	if err != nil {
+		return nil, err
+	}
+	if resp.statusCode >= 400 {
+		return nil, parseAPIError(s.client.jsonCodec(), resp.statusCode, resp.header, resp.body)
+	}
+	if job := pendingJob(s.client.jsonCodec(), resp); job != nil {
+		if resp.body, err = s.client.WaitForJob(ctx, job.Hash, zipPollInterval); err != nil {
+			return nil, err
+		}
+	}
+	if isZip(resp.body) {
+		return resp.body, nil
+	}
+
+	var pending struct {
+		URL         string `json:"url"`
+		DownloadURL string `json:"download_url"`
+		Message     string `json:"message"`
+	}
+	if err := s.client.jsonCodec().Unmarshal(resp.body, &pending); err != nil {
+		return nil, newDecodeError("POST", path, resp.body, err, s.client.apiToken)
+	}
+
+	archiveURL := pending.URL
+	if archiveURL == "" {
+		archiveURL = pending.DownloadURL
+	}
+	if archiveURL == "" {
+		msg := "server returned neither a zip archive nor a download URL"
+		if pending.Message != "" {
+			msg += ": " + pending.Message
+		}
+		return nil, &APIError{StatusCode: resp.statusCode, Message: msg}
+	}
+
+	return s.pollZip(ctx, archiveURL)
+}
+
+// pollZip fetches a zip archive from rawURL, retrying every zipPollInterval
+// while the server reports it as not ready yet, up to zipMaxPolls times.
+func (s *DownloadsService) pollZip(ctx context.Context, rawURL string) ([]byte, error) {
+	base, err := url.Parse(s.client.BaseURL())
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+	ref, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid download URL %q: %w", rawURL, err)
+	}
+	target := base.ResolveReference(ref)
+
+	// Credentials are only sent to the API host, not to external storage
+	authenticate := target.Host == base.Host
+
+	notFound := 0
+	for attempt := 1; ; attempt++ {
+		data, err := s.fetchZip(ctx, target.String(), authenticate)
+		if err != nil {
+			// The archive may not be visible in storage right away
+			apiErr, ok := IsAPIError(err)
+			if !ok || !apiErr.IsNotFound() || notFound >= zipNotFoundRetries {
+				return nil, err
+			}
+			notFound++
+		} else if data != nil {
+			return data, nil
+		}
+
+		if attempt >= zipMaxPolls {
+			return nil, fmt.Errorf("zip archive %s: %w after %d attempts", rawURL, ErrDownloadNotReady, attempt)
+		}
+		select {
+		case <-s.client.timeSource().After(zipPollInterval):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// fetchZip makes a single attempt to download a zip archive. It returns nil
+// data and a nil error if the server answers 202 Accepted.
+func (s *DownloadsService) fetchZip(ctx context.Context, rawURL string, authenticate bool) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if authenticate {
+		s.client.setDefaultHeaders(req)
+		setRequestID(req)
+		req.Header.Set("X-API-TOKEN", s.client.apiToken)
+		req.Header.Set("X-Requested-With", "XMLHttpRequest")
+	}
+	req.Header.Set("Accept", "application/zip")
+	req.Header.Set("User-Agent", s.client.userAgentHeader())
+
+	resp, err := s.client.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := readLimited(resp.Body, s.client.maxDownloadBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusAccepted:
+		return nil, nil
+	case resp.StatusCode >= 400:
+		return nil, parseAPIError(s.client.jsonCodec(), resp.StatusCode, resp.Header, body)
+	case !isZip(body):
+		return nil, &APIError{
+			StatusCode: resp.StatusCode,
+			Message:    fmt.Sprintf("unexpected content type %q, expected application/zip", resp.Header.Get("Content-Type")),
+		}
+	}
+	return body, nil
+}
+
+// isZip reports whether data starts with a zip file signature.
+func isZip(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("PK\x03\x04")) || bytes.HasPrefix(data, []byte("PK\x05\x06"))
+}
+
+// downloadFile performs a PDF download request. Redirects are followed by the
+// HTTP client. A successful response that is not a PDF, such as the HTML login
+// page some self-hosted instances serve for an invalid token, is reported as
+// an *APIError instead of being returned as file content.
 func (s *DownloadsService) downloadFile(ctx context.Context, path string) ([]byte, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", s.client.baseURL+path, nil)
+	return s.download(ctx, path, "application/pdf")
+}
+
+// download performs a file download request accepting the given media types,
+// the first of which is sent as the Accept header. Other successful responses
+// are reported as an *APIError.
+func (s *DownloadsService) download(ctx context.Context, path string, mediaTypes ...string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", s.client.BaseURL()+path, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	s.client.setDefaultHeaders(req)
+	setRequestID(req)
 	req.Header.Set("X-API-TOKEN", s.client.apiToken)
 	req.Header.Set("X-Requested-With", "XMLHttpRequest")
-	req.Header.Set("Accept", "application/pdf")
+	req.Header.Set("Accept", mediaTypes[0])
+	req.Header.Set("User-Agent", s.client.userAgentHeader())
 
-	resp, err := s.client.httpClient.Do(req)
+	resp, err := s.client.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, parseAPIError(resp.StatusCode, body)
+		body, _ := readLimited(resp.Body, s.client.maxResponseBytes)
+		return nil, parseAPIError(s.client.jsonCodec(), resp.StatusCode, resp.Header, body)
+	}
+
+	if err := checkContentType(resp, mediaTypes...); err != nil {
+		return nil, err
+	}
+
+	return readLimited(resp.Body, s.client.maxDownloadBytes)
+}
+
+// checkContentType returns an *APIError if the response media type is none of
+// expected. The body is drained so the connection can be reused.
+func checkContentType(resp *http.Response, expected ...string) error {
+	contentType := resp.Header.Get("Content-Type")
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err == nil && slices.Contains(expected, mediaType) {
+		return nil
 	}
 
-	return io.ReadAll(resp.Body)
+	io.Copy(io.Discard, resp.Body) //nolint:errcheck // draining for connection reuse
+
+	msg := fmt.Sprintf("unexpected content type %q, expected %s", contentType, strings.Join(expected, " or "))
+	if mediaType == "text/html" {
+		msg += " (the server returned an HTML page; check the API token and base URL)"
+	}
+	return &APIError{StatusCode: resp.StatusCode, Message: msg}
 }
 
 // UploadsService handles file upload operations.
@@ -68,32 +292,32 @@ type UploadsService struct {
 
 // UploadDocument uploads a document to an entity.
 func (s *UploadsService) UploadDocument(ctx context.Context, entityType, entityID string, filePath string) error {
-	return s.uploadFile(ctx, fmt.Sprintf("/api/v1/%s/%s/upload", entityType, entityID), filePath)
+	return s.uploadFile(ctx, s.client.apiPath("/%s/%s/upload", entityType, entityID), filePath)
 }
 
 // UploadInvoiceDocument uploads a document to an invoice.
 func (s *UploadsService) UploadInvoiceDocument(ctx context.Context, invoiceID string, filePath string) error {
-	return s.uploadFile(ctx, fmt.Sprintf("/api/v1/invoices/%s/upload", invoiceID), filePath)
+	return s.uploadFile(ctx, s.client.apiPath("/invoices/%s/upload", invoiceID), filePath)
 }
 
 // UploadPaymentDocument uploads a document to a payment.
 func (s *UploadsService) UploadPaymentDocument(ctx context.Context, paymentID string, filePath string) error {
-	return s.uploadFile(ctx, fmt.Sprintf("/api/v1/payments/%s/upload", paymentID), filePath)
+	return s.uploadFile(ctx, s.client.apiPath("/payments/%s/upload", paymentID), filePath)
 }
 
 // UploadClientDocument uploads a document to a client.
 func (s *UploadsService) UploadClientDocument(ctx context.Context, clientID string, filePath string) error {
-	return s.uploadFile(ctx, fmt.Sprintf("/api/v1/clients/%s/upload", clientID), filePath)
+	return s.uploadFile(ctx, s.client.apiPath("/clients/%s/upload", clientID), filePath)
 }
 
 // UploadCreditDocument uploads a document to a credit.
 func (s *UploadsService) UploadCreditDocument(ctx context.Context, creditID string, filePath string) error {
-	return s.uploadFile(ctx, fmt.Sprintf("/api/v1/credits/%s/upload", creditID), filePath)
+	return s.uploadFile(ctx, s.client.apiPath("/credits/%s/upload", creditID), filePath)
 }
 
 // UploadDocumentFromReader uploads a document from an io.Reader.
 func (s *UploadsService) UploadDocumentFromReader(ctx context.Context, entityType, entityID, filename string, reader io.Reader) error {
-	return s.uploadFromReader(ctx, fmt.Sprintf("/api/v1/%s/%s/upload", entityType, entityID), filename, reader)
+	return s.uploadFromReader(ctx, s.client.apiPath("/%s/%s/upload", entityType, entityID), filename, reader)
 }
 
 // uploadFile uploads a file from the filesystem.
@@ -107,11 +331,34 @@ func (s *UploadsService) uploadFile(ctx context.Context, path, filePath string)
 	return s.uploadFromReader(ctx, path, filepath.Base(filePath), file)
 }
 
-// uploadFromReader uploads a file from an io.Reader.
+// uploadFromReader uploads a file from an io.Reader. The multipart body is
+// streamed through a pipe as the request is sent, so large files are never
+// held in memory.
 func (s *UploadsService) uploadFromReader(ctx context.Context, path, filename string, reader io.Reader) error {
-	var buf bytes.Buffer
-	writer := multipart.NewWriter(&buf)
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+
+	writeErr := make(chan error, 1)
+	go func() {
+		err := writeMultipart(writer, filename, reader)
+		pw.CloseWithError(err)
+		writeErr <- err
+	}()
 
+	err := s.sendUpload(ctx, path, writer.FormDataContentType(), pr)
+
+	// Unblock the writer if the transport stopped reading early, then prefer
+	// its error: a failing source reader also fails the request.
+	pr.Close()
+	if werr := <-writeErr; werr != nil && !errors.Is(werr, io.ErrClosedPipe) {
+		return werr
+	}
+	return err
+}
+
+// writeMultipart writes the upload form, with the file content copied from
+// reader, and closes the multipart writer.
+func writeMultipart(writer *multipart.Writer, filename string, reader io.Reader) error {
 	// Add _method field for PUT override
 	if err := writer.WriteField("_method", "PUT"); err != nil {
 		return fmt.Errorf("failed to write method field: %w", err)
@@ -123,32 +370,39 @@ func (s *UploadsService) uploadFromReader(ctx context.Context, path, filename st
 		return fmt.Errorf("failed to create form file: %w", err)
 	}
 
-	if _, copyErr := io.Copy(part, reader); copyErr != nil {
-		return fmt.Errorf("failed to copy file content: %w", copyErr)
+	if _, err := io.Copy(part, reader); err != nil {
+		return fmt.Errorf("failed to copy file content: %w", err)
 	}
 
-	if closeErr := writer.Close(); closeErr != nil {
-		return fmt.Errorf("failed to close multipart writer: %w", closeErr)
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to close multipart writer: %w", err)
 	}
+	return nil
+}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", s.client.baseURL+path, &buf)
+// sendUpload posts a multipart upload body.
+func (s *UploadsService) sendUpload(ctx context.Context, path, contentType string, body io.Reader) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", s.client.BaseURL()+path, body)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
+	s.client.setDefaultHeaders(req)
+	setRequestID(req)
 	req.Header.Set("X-API-TOKEN", s.client.apiToken)
 	req.Header.Set("X-Requested-With", "XMLHttpRequest")
-	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("User-Agent", s.client.userAgentHeader())
 
-	resp, err := s.client.httpClient.Do(req)
+	resp, err := s.client.do(req)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return parseAPIError(resp.StatusCode, body)
+		body, _ := readLimited(resp.Body, s.client.maxResponseBytes)
+		return parseAPIError(s.client.jsonCodec(), resp.StatusCode, resp.Header, body)
 	}
 
 	return nil
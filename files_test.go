@@ -3,11 +3,17 @@ package invoiceninja
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"runtime"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"testing/iotest"
+	"time"
 )
 
 func TestDownloadsServiceDownloadInvoicePDF(t *testing.T) {
@@ -73,6 +79,119 @@ func TestDownloadsServiceDownloadError(t *testing.T) {
 	}
 }
 
+func TestDownloadsServiceRejectsHTMLPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=UTF-8")
+		w.Write([]byte("<html><body>Login</body></html>"))
+	}))
+	defer server.Close()
+
+	client := NewClient("wrong-token", WithBaseURL(server.URL))
+
+	pdf, err := client.Downloads.DownloadInvoicePDF(context.Background(), "inv-key-123")
+	if pdf != nil {
+		t.Errorf("expected no content, got %q", pdf)
+	}
+
+	apiErr, ok := IsAPIError(err)
+	if !ok {
+		t.Fatalf("expected APIError, got %v", err)
+	}
+
+	if !strings.Contains(apiErr.Message, `unexpected content type "text/html; charset=UTF-8"`) || !strings.Contains(apiErr.Message, "HTML page") {
+		t.Errorf("unexpected message: %s", apiErr.Message)
+	}
+}
+
+func TestDownloadsServiceFollowsRedirect(t *testing.T) {
+	expectedPDF := []byte("%PDF-1.4 redirected")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/invoice/key/download", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/storage/invoice.pdf", http.StatusFound)
+	})
+	mux.HandleFunc("/storage/invoice.pdf", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write(expectedPDF)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	pdf, err := client.Invoices.Download(context.Background(), "key")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !bytes.Equal(pdf, expectedPDF) {
+		t.Errorf("expected redirected PDF content, got %q", pdf)
+	}
+}
+
+func TestDownloadsServiceDownloadInvoiceEInvoice(t *testing.T) {
+	expectedXML := []byte(`<?xml version="1.0" encoding="UTF-8"?><Invoice/>`)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/invoices/inv1":
+			if got := r.URL.Query().Get("include"); got != "invitations" {
+				t.Errorf("expected include=invitations, got %q", got)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"data": {"id": "inv1", "invitations": [{"key": "key1"}]}}`))
+		case "/api/v1/invoice/key1/download_e_invoice":
+			if r.Header.Get("Accept") != "application/xml" {
+				t.Errorf("expected Accept: application/xml, got %q", r.Header.Get("Accept"))
+			}
+			w.Header().Set("Content-Type", "text/xml; charset=UTF-8")
+			w.Write(expectedXML)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	xml, err := client.Downloads.DownloadInvoiceEInvoice(context.Background(), "inv1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(xml, expectedXML) {
+		t.Errorf("expected XML content to match, got %q", xml)
+	}
+}
+
+func TestDownloadsServiceDownloadInvoiceEInvoiceErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/invoices/bare":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"data": {"id": "bare"}}`))
+		case "/api/v1/invoices/pdf":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"data": {"id": "pdf", "invitations": [{"key": "key2"}]}}`))
+		default:
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Write([]byte("%PDF-1.4"))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	if _, err := client.Downloads.DownloadInvoiceEInvoice(ctx, "bare"); !errors.Is(err, ErrNoInvitations) {
+		t.Errorf("expected ErrNoInvitations, got %v", err)
+	}
+
+	var apiErr *APIError
+	if _, err := client.Downloads.DownloadInvoiceEInvoice(ctx, "pdf"); !errors.As(err, &apiErr) {
+		t.Errorf("expected an *APIError for a PDF response, got %v", err)
+	}
+}
+
 func TestUploadsServiceUploadFromReader(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != "POST" {
@@ -151,3 +270,286 @@ func TestUploadsServiceUploadError(t *testing.T) {
 		t.Errorf("expected IsValidationError to be true")
 	}
 }
+
+// patternReader yields an endless stream of a repeating byte.
+type patternReader struct{}
+
+func (patternReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 'x'
+	}
+	return len(p), nil
+}
+
+func TestUploadsServiceUploadStreamsLargeFile(t *testing.T) {
+	const size = 50 << 20
+
+	var received int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mr, err := r.MultipartReader()
+		if err != nil {
+			t.Errorf("failed to read multipart body: %v", err)
+			return
+		}
+		for {
+			part, err := mr.NextPart()
+			if err != nil {
+				break
+			}
+			if part.FormName() == "documents[]" {
+				received, _ = io.Copy(io.Discard, part)
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	err := client.Uploads.UploadDocumentFromReader(context.Background(), "invoices", "inv123", "large.bin", io.LimitReader(patternReader{}, size))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	runtime.ReadMemStats(&after)
+
+	if received != size {
+		t.Errorf("expected %d bytes to be received, got %d", size, received)
+	}
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > size/4 {
+		t.Errorf("expected the upload to be streamed, but %d bytes were allocated", allocated)
+	}
+}
+
+func TestUploadsServiceUploadReaderError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	readErr := errors.New("disk read failed")
+	reader := io.MultiReader(strings.NewReader("partial content"), iotest.ErrReader(readErr))
+
+	err := client.Uploads.UploadDocumentFromReader(context.Background(), "invoices", "inv123", "test.pdf", reader)
+	if !errors.Is(err, readErr) {
+		t.Errorf("expected reader error to propagate, got %v", err)
+	}
+}
+
+func TestDownloadsServiceDownloadInvoicesZip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/v1/invoices/bulk" {
+			t.Errorf("expected POST /api/v1/invoices/bulk, got %s %s", r.Method, r.URL.Path)
+		}
+
+		var req BulkAction
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Action != "download" || len(req.IDs) != 2 {
+			t.Errorf("unexpected bulk request: %+v", req)
+		}
+
+		w.Header().Set("Content-Type", "application/zip")
+		w.Write([]byte("PK\x03\x04archive"))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	data, err := client.Downloads.DownloadInvoicesZip(context.Background(), []string{"inv1", "inv2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != "PK\x03\x04archive" {
+		t.Errorf("unexpected archive content: %q", data)
+	}
+}
+
+func TestDownloadsServiceDownloadInvoicesZipPollsURL(t *testing.T) {
+	defer func(d time.Duration) { zipPollInterval = d }(zipPollInterval)
+	zipPollInterval = time.Millisecond
+
+	var polls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/invoices/bulk":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"message":"Processing","url":"/storage/zips/invoices.zip"}`))
+		case "/storage/zips/invoices.zip":
+			polls++
+			if r.Header.Get("X-API-TOKEN") != "test-token" {
+				t.Errorf("expected API token on same-host download URL")
+			}
+			if polls < 3 {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "application/zip")
+			w.Write([]byte("PK\x03\x04archive"))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	data, err := client.Downloads.DownloadInvoicesZip(context.Background(), []string{"inv1", "inv2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != "PK\x03\x04archive" {
+		t.Errorf("unexpected archive content: %q", data)
+	}
+	if polls != 3 {
+		t.Errorf("expected 3 polls, got %d", polls)
+	}
+}
+
+func TestDownloadsServiceDownloadInvoicesZipPollLimits(t *testing.T) {
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/invoices/bulk":
+			var req BulkAction
+			json.NewDecoder(r.Body).Decode(&req)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"url":"/storage/` + req.IDs[0] + `.zip"}`))
+		case "/storage/missing.zip":
+			atomic.AddInt32(&polls, 1)
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer server.Close()
+
+	clock := newFakeClock()
+	client := NewClient("test-token", WithBaseURL(server.URL), WithClock(clock))
+	ctx := context.Background()
+
+	// A download URL that stays missing fails after a few attempts
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.Downloads.DownloadInvoicesZip(ctx, []string{"missing"})
+		done <- err
+	}()
+	for i := 0; i < zipNotFoundRetries; i++ {
+		clock.waitForWaiters(t, 1)
+		clock.Advance(zipPollInterval)
+	}
+	err := <-done
+	if apiErr, ok := IsAPIError(err); !ok || !apiErr.IsNotFound() {
+		t.Errorf("expected a not found APIError, got %v", err)
+	}
+	if polls := atomic.LoadInt32(&polls); polls != zipNotFoundRetries+1 {
+		t.Errorf("expected %d polls, got %d", zipNotFoundRetries+1, polls)
+	}
+
+	// An archive that is never ready fails after zipMaxPolls attempts
+	defer func(n int) { zipMaxPolls = n }(zipMaxPolls)
+	zipMaxPolls = 4
+
+	go func() {
+		_, err := client.Downloads.DownloadInvoicesZip(ctx, []string{"pending"})
+		done <- err
+	}()
+	for i := 1; i < zipMaxPolls; i++ {
+		clock.waitForWaiters(t, 1)
+		clock.Advance(zipPollInterval)
+	}
+	if err := <-done; !errors.Is(err, ErrDownloadNotReady) {
+		t.Errorf("expected ErrDownloadNotReady, got %v", err)
+	}
+}
+
+func TestDownloadsServiceDownloadInvoicesZipSizeLimit(t *testing.T) {
+	archive := "PK\x03\x04" + strings.Repeat("x", 100)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		w.Write([]byte(archive))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+
+	// The archive is limited as a download, not as an API response
+	client := NewClient("test-token", WithBaseURL(server.URL), WithMaxResponseBytes(10))
+	if data, err := client.Downloads.DownloadInvoicesZip(ctx, []string{"inv1"}); err != nil || string(data) != archive {
+		t.Errorf("expected the archive despite WithMaxResponseBytes, got %d bytes, %v", len(data), err)
+	}
+
+	client = NewClient("test-token", WithBaseURL(server.URL), WithMaxDownloadBytes(10))
+	if _, err := client.Downloads.DownloadInvoicesZip(ctx, []string{"inv1"}); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("expected ErrResponseTooLarge, got %v", err)
+	}
+}
+
+func TestDownloadsServiceDownloadInvoicesZipExternalURL(t *testing.T) {
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-TOKEN") != "" {
+			t.Errorf("API token must not be sent to an external download URL")
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write([]byte("PK\x03\x04archive"))
+	}))
+	defer storage.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"download_url": storage.URL + "/invoices.zip"})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	if _, err := client.Downloads.DownloadInvoicesZip(context.Background(), []string{"inv1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDownloadsServiceDownloadInvoicesZipErrors(t *testing.T) {
+	defer func(d time.Duration) { zipPollInterval = d }(zipPollInterval)
+	zipPollInterval = time.Millisecond
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/invoices/bulk":
+			var req BulkAction
+			json.NewDecoder(r.Body).Decode(&req)
+			w.Header().Set("Content-Type", "application/json")
+			if req.IDs[0] == "emailed" {
+				w.Write([]byte(`{"message":"The archive will be emailed to you"}`))
+				return
+			}
+			w.Write([]byte(`{"url":"/pending.zip"}`))
+		default:
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	if _, err := client.Downloads.DownloadInvoicesZip(context.Background(), nil); err == nil {
+		t.Error("expected error for empty invoice IDs")
+	}
+
+	_, err := client.Downloads.DownloadInvoicesZip(context.Background(), []string{"emailed"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !strings.Contains(apiErr.Message, "emailed") {
+		t.Errorf("expected *APIError with server message, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.Downloads.DownloadInvoicesZip(ctx, []string{"inv1"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded while pending, got %v", err)
+	}
+}
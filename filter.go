@@ -0,0 +1,154 @@
+package invoiceninja
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Comparison operators understood by Invoice Ninja's numeric and date filters.
+const (
+	FilterOpEq  = "eq"
+	FilterOpGt  = "gt"
+	FilterOpGte = "gte"
+	FilterOpLt  = "lt"
+	FilterOpLte = "lte"
+)
+
+// FilterExpr is a single list filter: a query parameter and its encoded
+// value, such as balance=gt:1000. Build them with a Filter and pass them in
+// the Filters field of any list options.
+type FilterExpr struct {
+	// Field is the query parameter name, e.g. "balance".
+	Field string
+
+	// Value is the encoded parameter value, e.g. "gt:1000".
+	Value string
+}
+
+// String returns the expression in query form, e.g. "balance=gt:1000".
+func (e FilterExpr) String() string {
+	return e.Field + "=" + e.Value
+}
+
+// Filter builds filter expressions for list requests, encoding the operator
+// syntax of Invoice Ninja's query filters:
+//
+//	opts := &invoiceninja.InvoiceListOptions{
+//		Filters: invoiceninja.NewFilter().
+//			Gt("balance", 1000).
+//			In("client_status", "unpaid", "overdue").
+//			Exprs(),
+//	}
+//
+// Values are formatted without exponents for floats and as YYYY-MM-DD for
+// time.Time; other values use their default format.
+type Filter struct {
+	exprs []FilterExpr
+}
+
+// NewFilter returns an empty Filter.
+func NewFilter() *Filter {
+	return &Filter{}
+}
+
+// Eq adds a filter matching field equal to value (field=eq:value).
+func (f *Filter) Eq(field string, value interface{}) *Filter {
+	return f.op(field, FilterOpEq, value)
+}
+
+// Gt adds a filter matching field greater than value (field=gt:value).
+func (f *Filter) Gt(field string, value interface{}) *Filter {
+	return f.op(field, FilterOpGt, value)
+}
+
+// Gte adds a filter matching field greater than or equal to value (field=gte:value).
+func (f *Filter) Gte(field string, value interface{}) *Filter {
+	return f.op(field, FilterOpGte, value)
+}
+
+// Lt adds a filter matching field less than value (field=lt:value).
+func (f *Filter) Lt(field string, value interface{}) *Filter {
+	return f.op(field, FilterOpLt, value)
+}
+
+// Lte adds a filter matching field less than or equal to value (field=lte:value).
+func (f *Filter) Lte(field string, value interface{}) *Filter {
+	return f.op(field, FilterOpLte, value)
+}
+
+// Between adds a filter matching field within the inclusive range from a to b
+// (field=between:a,b).
+func (f *Filter) Between(field string, a, b interface{}) *Filter {
+	return f.add(field, "between:"+formatFilterValue(a)+","+formatFilterValue(b))
+}
+
+// In adds a filter matching any of values (field=v1,v2), as used by
+// parameters such as status and client_status.
+func (f *Filter) In(field string, values ...interface{}) *Filter {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = formatFilterValue(v)
+	}
+	return f.add(field, strings.Join(parts, ","))
+}
+
+// Exprs returns the filter expressions added so far.
+func (f *Filter) Exprs() []FilterExpr {
+	return append([]FilterExpr(nil), f.exprs...)
+}
+
+// String returns the filters as an encoded query string, e.g.
+// "balance=gt%3A1000&client_status=unpaid%2Coverdue".
+func (f *Filter) String() string {
+	q := url.Values{}
+	applyFilters(q, f.exprs)
+	return q.Encode()
+}
+
+// op adds a filter with a comparison operator.
+func (f *Filter) op(field, operator string, value interface{}) *Filter {
+	return f.add(field, operator+":"+formatFilterValue(value))
+}
+
+// add appends a filter expression.
+func (f *Filter) add(field, value string) *Filter {
+	f.exprs = append(f.exprs, FilterExpr{Field: field, Value: value})
+	return f
+}
+
+// formatFilterValue formats a filter operand for a query string.
+func formatFilterValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case time.Time:
+		return v.Format("2006-01-02")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// applyFilters sets the filter expressions on q. A filter replaces any value
+// already set for its field, and a later filter on the same field replaces an
+// earlier one.
+func applyFilters(q url.Values, exprs []FilterExpr) {
+	for _, e := range exprs {
+		q.Set(e.Field, e.Value)
+	}
+}
+
+// includeQuery returns the query for fetching a single entity with the given
+// related entities, or nil if there are none.
+func includeQuery(include []string) url.Values {
+	if len(include) == 0 {
+		return nil
+	}
+	return url.Values{"include": {strings.Join(include, ",")}}
+}
@@ -0,0 +1,124 @@
+package invoiceninja
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestFilterOperators(t *testing.T) {
+	date := time.Date(2024, 3, 1, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		filter   *Filter
+		expected string
+	}{
+		{"eq", NewFilter().Eq("number", "INV-0001"), "number=eq:INV-0001"},
+		{"gt", NewFilter().Gt("balance", 1000), "balance=gt:1000"},
+		{"gte float", NewFilter().Gte("amount", 99.5), "amount=gte:99.5"},
+		{"lt large float", NewFilter().Lt("balance", 1e7), "balance=lt:10000000"},
+		{"lte date", NewFilter().Lte("date", date), "date=lte:2024-03-01"},
+		{"between", NewFilter().Between("amount", 100, 250.75), "amount=between:100,250.75"},
+		{"between dates", NewFilter().Between("due_date", date, date.AddDate(0, 1, 0)), "due_date=between:2024-03-01,2024-04-01"},
+		{"in", NewFilter().In("client_status", "unpaid", "overdue"), "client_status=unpaid,overdue"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprs := tt.filter.Exprs()
+			if len(exprs) != 1 {
+				t.Fatalf("expected 1 expression, got %d", len(exprs))
+			}
+			if exprs[0].String() != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, exprs[0])
+			}
+		})
+	}
+}
+
+func TestFilterString(t *testing.T) {
+	f := NewFilter().Gt("balance", 1000).In("client_status", "unpaid", "overdue")
+
+	expected := "balance=gt%3A1000&client_status=unpaid%2Coverdue"
+	if f.String() != expected {
+		t.Errorf("expected %s, got %s", expected, f.String())
+	}
+}
+
+func TestListOptionsFilters(t *testing.T) {
+	opts := &InvoiceListOptions{
+		Status:  "active",
+		PerPage: 50,
+		Filters: NewFilter().Gt("balance", 1000).In("status", "active", "archived").Exprs(),
+	}
+
+	q := opts.toQuery()
+	if q.Get("balance") != "gt:1000" {
+		t.Errorf("expected balance gt:1000, got %s", q.Get("balance"))
+	}
+	if q.Get("status") != "active,archived" {
+		t.Errorf("expected the filter to replace status, got %s", q.Get("status"))
+	}
+	if q.Get("per_page") != "50" {
+		t.Errorf("expected per_page 50, got %s", q.Get("per_page"))
+	}
+
+	// Every service's options accept filters
+	if (&PaymentListOptions{Filters: NewFilter().Lt("amount", 5).Exprs()}).toQuery().Get("amount") != "lt:5" {
+		t.Error("expected payment list filter to be applied")
+	}
+	if (&ProductListOptions{Filters: NewFilter().Gte("price", 10).Exprs()}).toQuery().Get("price") != "gte:10" {
+		t.Error("expected product list filter to be applied")
+	}
+}
+
+func TestAssignedUserListFilter(t *testing.T) {
+	queries := map[string]url.Values{
+		"invoices": (&InvoiceListOptions{AssignedUserID: "user7"}).toQuery(),
+		"payments": (&PaymentListOptions{AssignedUserID: "user7"}).toQuery(),
+		"clients":  (&ClientListOptions{AssignedUserID: "user7"}).toQuery(),
+		"credits":  (&CreditListOptions{AssignedUserID: "user7"}).toQuery(),
+	}
+
+	for name, q := range queries {
+		if got := q.Get("assigned_user"); got != "user7" {
+			t.Errorf("%s: expected assigned_user=user7, got %q", name, got)
+		}
+	}
+}
+
+func TestListOptionsStatusAndIsDeleted(t *testing.T) {
+	deleted := true
+	queries := map[string]url.Values{
+		"bank transactions":  (&BankTransactionListOptions{Status: "archived", IsDeleted: &deleted}).toQuery(),
+		"clients":            (&ClientListOptions{Status: "archived", IsDeleted: &deleted}).toQuery(),
+		"companies":          (&CompanyListOptions{Status: "archived", IsDeleted: &deleted}).toQuery(),
+		"company gateways":   (&CompanyGatewayListOptions{Status: "archived", IsDeleted: &deleted}).toQuery(),
+		"credits":            (&CreditListOptions{Status: "archived", IsDeleted: &deleted}).toQuery(),
+		"designs":            (&DesignListOptions{Status: "archived", IsDeleted: &deleted}).toQuery(),
+		"invoices":           (&InvoiceListOptions{Status: "archived", IsDeleted: &deleted}).toQuery(),
+		"payment terms":      (&PaymentTermListOptions{Status: "archived", IsDeleted: &deleted}).toQuery(),
+		"payments":           (&PaymentListOptions{Status: "archived", IsDeleted: &deleted}).toQuery(),
+		"products":           (&ProductListOptions{Status: "archived", IsDeleted: &deleted}).toQuery(),
+		"quotes":             (&QuoteListOptions{Status: "archived", IsDeleted: &deleted}).toQuery(),
+		"recurring invoices": (&RecurringInvoiceListOptions{Status: "archived", IsDeleted: &deleted}).toQuery(),
+		"subscriptions":      (&SubscriptionListOptions{Status: "archived", IsDeleted: &deleted}).toQuery(),
+		"users":              (&UserListOptions{Status: "archived", IsDeleted: &deleted}).toQuery(),
+		"webhooks":           (&WebhookListOptions{Status: "archived", IsDeleted: &deleted}).toQuery(),
+	}
+
+	for name, q := range queries {
+		if got := q.Get("status"); got != "archived" {
+			t.Errorf("%s: expected status=archived, got %q", name, got)
+		}
+		if got := q.Get("is_deleted"); got != "true" {
+			t.Errorf("%s: expected is_deleted=true, got %q", name, got)
+		}
+	}
+
+	// Unset filters are left to the server's defaults
+	if q := (&PaymentTermListOptions{}).toQuery(); q.Has("status") || q.Has("is_deleted") {
+		t.Errorf("expected no status filters, got %s", q.Encode())
+	}
+}
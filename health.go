@@ -0,0 +1,38 @@
+package invoiceninja
+
+import (
+	"context"
+	"net/http"
+)
+
+// Ping verifies connectivity and token validity by calling the lightweight
+// ping endpoint. It returns nil on a 2xx response and an *APIError otherwise,
+// so it can be used at startup without fetching real data.
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.ping(ctx)
+	return err
+}
+
+// HealthCheck pings the server like Ping and returns the server version from
+// the X-App-Version response header. The version is empty if the server does
+// not send the header.
+func (c *Client) HealthCheck(ctx context.Context) (string, error) {
+	resp, err := c.ping(ctx)
+	if err != nil {
+		return "", err
+	}
+	return resp.header.Get("X-App-Version"), nil
+}
+
+// ping calls the ping endpoint and converts error statuses to an *APIError.
+func (c *Client) ping(ctx context.Context) (*rawResponse, error) {
+	resp, err := c.send(ctx, http.MethodGet, c.BaseURL()+c.apiPath("/ping"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.statusCode >= 400 {
+		return nil, parseAPIError(c.jsonCodec(), resp.statusCode, resp.header, resp.body)
+	}
+	return resp, nil
+}
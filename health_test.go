@@ -0,0 +1,88 @@
+package invoiceninja
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientPing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("expected GET method, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/ping" {
+			t.Errorf("expected path /api/v1/ping, got %s", r.URL.Path)
+		}
+		if r.Header.Get("X-API-TOKEN") != "test-token" {
+			t.Errorf("expected API token header, got %s", r.Header.Get("X-API-TOKEN"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"company_name": "Acme", "user_name": "Jane"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	if err := client.Ping(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestClientPingUnauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"message": "Invalid token"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("bad-token", WithBaseURL(server.URL))
+
+	err := client.Ping(context.Background())
+
+	apiErr, ok := IsAPIError(err)
+	if !ok {
+		t.Fatalf("expected APIError, got %v", err)
+	}
+
+	if !apiErr.IsUnauthorized() || apiErr.Message != "Invalid token" {
+		t.Errorf("expected unauthorized error with message, got %v", apiErr)
+	}
+}
+
+func TestClientHealthCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{name: "with version", header: "5.10.30", expected: "5.10.30"},
+		{name: "without version", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.header != "" {
+					w.Header().Set("X-App-Version", tt.header)
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			client := NewClient("test-token", WithBaseURL(server.URL))
+
+			version, err := client.HealthCheck(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if version != tt.expected {
+				t.Errorf("expected version %q, got %q", tt.expected, version)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package invoiceninja
+
+import "net/http"
+
+// RequestHook is called before every API request is sent. It may return a
+// replacement request, for example one whose context carries a tracing span;
+// returning nil keeps the original request.
+type RequestHook func(req *http.Request) *http.Request
+
+// ResponseHook is called after every API request completes. resp is nil when
+// err is non-nil. Hooks must not read or close the response body.
+type ResponseHook func(req *http.Request, resp *http.Response, err error)
+
+// WithRequestHook registers a hook that runs before each request is sent.
+// Hooks run in the order they were registered.
+func WithRequestHook(hook RequestHook) ClientOption {
+	return func(c *Client) {
+		c.requestHooks = append(c.requestHooks, hook)
+	}
+}
+
+// WithResponseHook registers a hook that runs after each request completes.
+// Hooks run in the order they were registered.
+func WithResponseHook(hook ResponseHook) ClientOption {
+	return func(c *Client) {
+		c.responseHooks = append(c.responseHooks, hook)
+	}
+}
+
+// do executes req through the HTTP client, running the registered hooks. With
+// WithMaxConcurrency it first waits for a slot, which is released when the
+// response body is closed; the default request timeout also lasts until then.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	ctx, cancel := c.requestContext(req.Context())
+	req = req.WithContext(ctx)
+
+	acquired, err := c.acquire(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	release := func() {
+		acquired()
+		cancel()
+	}
+
+	for _, hook := range c.requestHooks {
+		if next := hook(req); next != nil {
+			req = next
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+
+	for _, hook := range c.responseHooks {
+		hook(req, resp, err)
+	}
+
+	if err != nil {
+		release()
+		return nil, err
+	}
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
@@ -0,0 +1,105 @@
+package invoiceninja
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type hookContextKey struct{}
+
+func TestRequestAndResponseHooks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "inv123"}}`))
+	}))
+	defer server.Close()
+
+	var order []string
+	var gotStatus int
+	var gotValue interface{}
+
+	client := NewClient("test-token",
+		WithBaseURL(server.URL),
+		WithRequestHook(func(req *http.Request) *http.Request {
+			order = append(order, "request1")
+			return req.WithContext(context.WithValue(req.Context(), hookContextKey{}, "span"))
+		}),
+		WithRequestHook(func(req *http.Request) *http.Request {
+			order = append(order, "request2")
+			return nil
+		}),
+		WithResponseHook(func(req *http.Request, resp *http.Response, err error) {
+			order = append(order, "response")
+			gotStatus = resp.StatusCode
+			gotValue = req.Context().Value(hookContextKey{})
+		}),
+	)
+
+	if _, err := client.Invoices.Get(context.Background(), "inv123"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Join(order, ",") != "request1,request2,response" {
+		t.Errorf("unexpected hook order: %v", order)
+	}
+
+	if gotStatus != http.StatusOK {
+		t.Errorf("expected status 200 in response hook, got %d", gotStatus)
+	}
+
+	if gotValue != "span" {
+		t.Errorf("expected response hook to see context from request hook, got %v", gotValue)
+	}
+}
+
+func TestResponseHookReceivesTransportError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	var gotErr error
+	var gotResp *http.Response
+
+	client := NewClient("test-token",
+		WithBaseURL(url),
+		WithResponseHook(func(req *http.Request, resp *http.Response, err error) {
+			gotResp = resp
+			gotErr = err
+		}),
+	)
+
+	if err := client.Request(context.Background(), "GET", "/api/v1/ping", nil, nil); err == nil {
+		t.Fatal("expected error against closed server")
+	}
+
+	if gotErr == nil || gotResp != nil {
+		t.Errorf("expected hook to receive error and nil response, got %v / %v", gotErr, gotResp)
+	}
+}
+
+func TestHooksRunForDownloads(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write([]byte("%PDF"))
+	}))
+	defer server.Close()
+
+	calls := 0
+	client := NewClient("test-token",
+		WithBaseURL(server.URL),
+		WithResponseHook(func(req *http.Request, resp *http.Response, err error) {
+			calls++
+		}),
+	)
+
+	if _, err := client.Downloads.DownloadInvoicePDF(context.Background(), "key"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("expected response hook to run once, got %d", calls)
+	}
+}
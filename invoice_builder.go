@@ -0,0 +1,174 @@
+package invoiceninja
+
+import (
+	"strings"
+	"time"
+)
+
+// ReverseChargeTaxName names the 0% tax that WithReverseCharge applies.
+const ReverseChargeTaxName = "Reverse Charge"
+
+// DefaultReverseChargeNote is the note WithReverseCharge adds when given none.
+const DefaultReverseChargeNote = "Reverse charge: VAT to be accounted for by the recipient " +
+	"(Article 196, Council Directive 2006/112/EC)."
+
+// InvoiceBuilder assembles an Invoice step by step:
+//
+//	invoice := invoiceninja.NewInvoiceBuilder(clientID).
+//		AddLineItem("consulting", 10, 150).
+//		WithTax("VAT", 20).
+//		WithDiscount(5, true).
+//		DueInDays(30).
+//		Build()
+//
+//	created, err := client.Invoices.Create(ctx, invoice)
+//
+// Dates are formatted as YYYY-MM-DD. The invoice date defaults to today in
+// the local time zone.
+type InvoiceBuilder struct {
+	invoice Invoice
+	date    time.Time
+	dueDays int
+	hasDue  bool
+
+	reverseCharge     bool
+	reverseChargeNote string
+}
+
+// NewInvoiceBuilder starts an invoice for the client with the given ID.
+func NewInvoiceBuilder(clientID string) *InvoiceBuilder {
+	return &InvoiceBuilder{invoice: Invoice{ClientID: clientID}}
+}
+
+// AddLineItem adds a line item for quantity units of a product at cost each.
+func (b *InvoiceBuilder) AddLineItem(productKey string, quantity, cost float64) *InvoiceBuilder {
+	return b.AddItem(LineItem{ProductKey: productKey, Quantity: quantity, Cost: cost})
+}
+
+// AddItem adds a fully specified line item, e.g. one with notes, its own
+// discount or line-level taxes.
+func (b *InvoiceBuilder) AddItem(item LineItem) *InvoiceBuilder {
+	b.invoice.LineItems = append(b.invoice.LineItems, item)
+	return b
+}
+
+// WithTax adds an invoice-level tax of rate percent. Invoice Ninja supports
+// three invoice-level taxes; further calls are ignored.
+func (b *InvoiceBuilder) WithTax(name string, rate float64) *InvoiceBuilder {
+	inv := &b.invoice
+	switch {
+	case inv.TaxName1 == "" && inv.TaxRate1 == 0:
+		inv.TaxName1, inv.TaxRate1 = name, rate
+	case inv.TaxName2 == "" && inv.TaxRate2 == 0:
+		inv.TaxName2, inv.TaxRate2 = name, rate
+	case inv.TaxName3 == "" && inv.TaxRate3 == 0:
+		inv.TaxName3, inv.TaxRate3 = name, rate
+	}
+	return b
+}
+
+// WithDiscount sets an invoice-level discount, either a percentage of the
+// subtotal or a fixed amount.
+func (b *InvoiceBuilder) WithDiscount(amount float64, isPercent bool) *InvoiceBuilder {
+	b.invoice.Discount = amount
+	isAmount := !isPercent
+	b.invoice.IsAmountDiscount = &isAmount
+	return b
+}
+
+// WithDate sets the invoice date.
+func (b *InvoiceBuilder) WithDate(date time.Time) *InvoiceBuilder {
+	b.date = date
+	return b
+}
+
+// DueInDays sets the due date n days after the invoice date.
+func (b *InvoiceBuilder) DueInDays(n int) *InvoiceBuilder {
+	b.dueDays = n
+	b.hasDue = true
+	return b
+}
+
+// WithPONumber sets the purchase order number.
+func (b *InvoiceBuilder) WithPONumber(number string) *InvoiceBuilder {
+	b.invoice.PONumber = number
+	return b
+}
+
+// WithNotes sets the public notes shown to the client.
+func (b *InvoiceBuilder) WithNotes(notes string) *InvoiceBuilder {
+	b.invoice.PublicNotes = notes
+	return b
+}
+
+// WithDesign sets the PDF design, see DesignsService.
+func (b *InvoiceBuilder) WithDesign(designID string) *InvoiceBuilder {
+	b.invoice.DesignID = designID
+	return b
+}
+
+// WithReverseCharge marks the invoice as an EU B2B reverse-charge supply:
+// every invoice and line item tax is replaced by a single 0% tax named
+// ReverseChargeTaxName, and note (DefaultReverseChargeNote if empty) is added
+// to the public notes. Invoice Ninja has no dedicated reverse-charge flag.
+// The client must have a VAT number; check it with ValidateFor.
+func (b *InvoiceBuilder) WithReverseCharge(note string) *InvoiceBuilder {
+	if note == "" {
+		note = DefaultReverseChargeNote
+	}
+	b.reverseCharge = true
+	b.reverseChargeNote = note
+	return b
+}
+
+// ValidateFor checks the invoice against the client it is for: the client
+// IDs must match and, with WithReverseCharge, the client must have a VAT
+// number. It returns a *ValidationError listing every problem found, or nil.
+func (b *InvoiceBuilder) ValidateFor(client *INClient) error {
+	verr := &ValidationError{}
+
+	if client.ID != b.invoice.ClientID {
+		verr.add("client_id", "does not match the client")
+	}
+	if b.reverseCharge && strings.TrimSpace(client.VatNumber) == "" {
+		verr.add("vat_number", "the client needs a VAT number for a reverse-charge invoice")
+	}
+
+	return verr.errOrNil()
+}
+
+// Build returns the assembled invoice. The builder can keep being used; later
+// changes do not affect invoices already built.
+func (b *InvoiceBuilder) Build() *Invoice {
+	inv := b.invoice
+	inv.LineItems = append([]LineItem(nil), b.invoice.LineItems...)
+
+	if b.reverseCharge {
+		inv.TaxName1, inv.TaxRate1 = ReverseChargeTaxName, 0
+		inv.TaxName2, inv.TaxRate2 = "", 0
+		inv.TaxName3, inv.TaxRate3 = "", 0
+		for i := range inv.LineItems {
+			item := &inv.LineItems[i]
+			item.TaxName1, item.TaxRate1 = "", 0
+			item.TaxName2, item.TaxRate2 = "", 0
+			item.TaxName3, item.TaxRate3 = "", 0
+		}
+
+		if inv.PublicNotes == "" {
+			inv.PublicNotes = b.reverseChargeNote
+		} else {
+			inv.PublicNotes += "\n\n" + b.reverseChargeNote
+		}
+	}
+
+	date := b.date
+	if date.IsZero() {
+		date = time.Now()
+	}
+	inv.Date = date.Format("2006-01-02")
+	if b.hasDue {
+		inv.DueDate = date.AddDate(0, 0, b.dueDays).Format("2006-01-02")
+	}
+
+	return &inv
+}
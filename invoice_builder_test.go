@@ -0,0 +1,112 @@
+package invoiceninja
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInvoiceBuilder(t *testing.T) {
+	date := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	inv := NewInvoiceBuilder("client1").
+		AddLineItem("consulting", 10, 150).
+		AddItem(LineItem{ProductKey: "hosting", Quantity: 1, Cost: 20, Notes: "January"}).
+		WithTax("VAT", 20).
+		WithTax("City", 1.5).
+		WithDiscount(50, false).
+		WithDate(date).
+		DueInDays(30).
+		WithPONumber("PO-7").
+		Build()
+
+	if inv.ClientID != "client1" || inv.PONumber != "PO-7" {
+		t.Errorf("unexpected invoice: %+v", inv)
+	}
+	if len(inv.LineItems) != 2 || inv.LineItems[0].Cost != 150 || inv.LineItems[1].Notes != "January" {
+		t.Errorf("unexpected line items: %+v", inv.LineItems)
+	}
+	if inv.TaxName1 != "VAT" || inv.TaxRate1 != 20 || inv.TaxName2 != "City" || inv.TaxRate2 != 1.5 || inv.TaxName3 != "" {
+		t.Errorf("unexpected taxes: %+v", inv)
+	}
+	if inv.Discount != 50 || !boolValue(inv.IsAmountDiscount) {
+		t.Errorf("expected a fixed discount of 50, got %v (amount %v)", inv.Discount, boolValue(inv.IsAmountDiscount))
+	}
+	if inv.Date != "2024-01-15" || inv.DueDate != "2024-02-14" {
+		t.Errorf("expected dates 2024-01-15 and 2024-02-14, got %s and %s", inv.Date, inv.DueDate)
+	}
+}
+
+func TestInvoiceBuilderDefaults(t *testing.T) {
+	b := NewInvoiceBuilder("client1").AddLineItem("widget", 1, 5).WithDiscount(10, true)
+	inv := b.Build()
+
+	if inv.Date != time.Now().Format("2006-01-02") {
+		t.Errorf("expected today's date, got %s", inv.Date)
+	}
+	if inv.DueDate != "" {
+		t.Errorf("expected no due date, got %s", inv.DueDate)
+	}
+	if inv.IsAmountDiscount == nil || *inv.IsAmountDiscount {
+		t.Error("expected a percentage discount to set is_amount_discount to false")
+	}
+
+	// Built invoices are independent of later builder changes
+	b.AddLineItem("gadget", 1, 7)
+	if len(inv.LineItems) != 1 {
+		t.Errorf("expected 1 line item in the built invoice, got %d", len(inv.LineItems))
+	}
+}
+
+func TestInvoiceBuilderTaxLimit(t *testing.T) {
+	inv := NewInvoiceBuilder("client1").
+		WithTax("A", 1).
+		WithTax("B", 2).
+		WithTax("C", 3).
+		WithTax("D", 4).
+		Build()
+
+	if inv.TaxName3 != "C" || inv.TaxRate3 != 3 {
+		t.Errorf("expected the fourth tax to be ignored, got %s %v", inv.TaxName3, inv.TaxRate3)
+	}
+}
+
+func TestInvoiceBuilderReverseCharge(t *testing.T) {
+	b := NewInvoiceBuilder("client1").
+		AddItem(LineItem{ProductKey: "consulting", Quantity: 1, Cost: 1000, TaxName1: "VAT", TaxRate1: 21}).
+		WithTax("VAT", 21).
+		WithNotes("Thank you").
+		WithReverseCharge("")
+	inv := b.Build()
+
+	if inv.TaxName1 != ReverseChargeTaxName || inv.TaxRate1 != 0 || inv.TaxName2 != "" {
+		t.Errorf("expected a single 0%% reverse-charge tax, got %q %v / %q", inv.TaxName1, inv.TaxRate1, inv.TaxName2)
+	}
+	if item := inv.LineItems[0]; item.TaxName1 != "" || item.TaxRate1 != 0 {
+		t.Errorf("expected line item taxes to be cleared, got %q %v", item.TaxName1, item.TaxRate1)
+	}
+	if inv.PublicNotes != "Thank you\n\n"+DefaultReverseChargeNote {
+		t.Errorf("unexpected public notes %q", inv.PublicNotes)
+	}
+
+	inv.ComputeTotals()
+	if inv.Amount != 1000 || inv.TotalTaxes != 0 {
+		t.Errorf("expected no tax on a reverse-charge invoice, got amount %v and taxes %v", inv.Amount, inv.TotalTaxes)
+	}
+
+	if err := b.ValidateFor(&INClient{ID: "client1", VatNumber: "NL123456789B01"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	var verr *ValidationError
+	if err := b.ValidateFor(&INClient{ID: "client1"}); !errors.As(err, &verr) || !strings.Contains(err.Error(), "vat_number") {
+		t.Errorf("expected a vat_number ValidationError, got %v", err)
+	}
+	if err := b.ValidateFor(&INClient{ID: "client2", VatNumber: "NL123456789B01"}); err == nil {
+		t.Error("expected an error for another client")
+	}
+	if err := NewInvoiceBuilder("client1").ValidateFor(&INClient{ID: "client1"}); err != nil {
+		t.Errorf("expected no VAT number to be needed without reverse charge, got %v", err)
+	}
+}
@@ -0,0 +1,151 @@
+package invoiceninjatest_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	invoiceninja "github.com/AshkanYarmoradi/go-invoice-ninja"
+	"github.com/AshkanYarmoradi/go-invoice-ninja/invoiceninjatest"
+)
+
+func TestServerOn(t *testing.T) {
+	srv := invoiceninjatest.NewServer()
+	defer srv.Close()
+
+	srv.On("GET", "/api/v1/invoices", invoiceninjatest.Fixture("invoices_list.json"))
+
+	resp, err := srv.Client.Invoices.List(context.Background(), &invoiceninja.InvoiceListOptions{PerPage: 20})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Data) != 2 {
+		t.Errorf("expected 2 invoices, got %d", len(resp.Data))
+	}
+
+	if resp.Data[0].Number != "INV-0001" {
+		t.Errorf("expected first invoice number INV-0001, got %s", resp.Data[0].Number)
+	}
+
+	requests := srv.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 recorded request, got %d", len(requests))
+	}
+
+	if requests[0].Query.Get("per_page") != "20" {
+		t.Errorf("expected per_page=20, got %s", requests[0].Query.Get("per_page"))
+	}
+
+	if requests[0].Header.Get("X-API-TOKEN") != invoiceninjatest.Token {
+		t.Errorf("expected X-API-TOKEN %s, got %s", invoiceninjatest.Token, requests[0].Header.Get("X-API-TOKEN"))
+	}
+}
+
+func TestServerRecordsBody(t *testing.T) {
+	srv := invoiceninjatest.NewServer()
+	defer srv.Close()
+
+	srv.On("POST", "/api/v1/clients", invoiceninjatest.Fixture("client.json"))
+
+	client, err := srv.Client.Clients.Create(context.Background(), &invoiceninja.INClient{Name: "Acme Corporation"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if client.ID != "test-client-id" {
+		t.Errorf("expected client ID test-client-id, got %s", client.ID)
+	}
+
+	var body invoiceninja.INClient
+	if err := json.Unmarshal(srv.Requests()[0].Body, &body); err != nil {
+		t.Fatalf("failed to decode recorded body: %v", err)
+	}
+
+	if body.Name != "Acme Corporation" {
+		t.Errorf("expected recorded name Acme Corporation, got %s", body.Name)
+	}
+}
+
+func TestServerOnStatus(t *testing.T) {
+	srv := invoiceninjatest.NewServer()
+	defer srv.Close()
+
+	srv.OnStatus("GET", "/api/v1/payments/missing", http.StatusNotFound, []byte(`{"message": "Payment not found"}`))
+
+	_, err := srv.Client.Payments.Get(context.Background(), "missing")
+
+	apiErr, ok := invoiceninja.IsAPIError(err)
+	if !ok {
+		t.Fatalf("expected APIError, got %T", err)
+	}
+
+	if !apiErr.IsNotFound() || apiErr.Message != "Payment not found" {
+		t.Errorf("unexpected error: %v", apiErr)
+	}
+}
+
+func TestServerOnFunc(t *testing.T) {
+	srv := invoiceninjatest.NewServer()
+	defer srv.Close()
+
+	srv.OnFunc("POST", "/api/v1/invoices/bulk", func(w http.ResponseWriter, r *http.Request) {
+		var body invoiceninja.BulkAction
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode body in handler: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]string{{"id": body.IDs[0], "status_id": "4"}},
+		})
+	})
+
+	invoice, err := srv.Client.Invoices.MarkPaid(context.Background(), "inv1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if invoice.ID != "inv1" || invoice.StatusID != "4" {
+		t.Errorf("unexpected invoice: %+v", invoice)
+	}
+}
+
+func TestServerUnregisteredRoute(t *testing.T) {
+	srv := invoiceninjatest.NewServer()
+	defer srv.Close()
+
+	_, err := srv.Client.Clients.Get(context.Background(), "abc")
+
+	apiErr, ok := invoiceninja.IsAPIError(err)
+	if !ok || !apiErr.IsNotFound() {
+		t.Errorf("expected 404 APIError for unregistered route, got %v", err)
+	}
+}
+
+func TestFixturesDecode(t *testing.T) {
+	fixtures := map[string]interface{}{
+		"invoice.json":       &invoiceninja.SingleResponse[invoiceninja.Invoice]{},
+		"invoices_list.json": &invoiceninja.ListResponse[invoiceninja.Invoice]{},
+		"client.json":        &invoiceninja.SingleResponse[invoiceninja.INClient]{},
+		"clients_list.json":  &invoiceninja.ListResponse[invoiceninja.INClient]{},
+		"payment.json":       &invoiceninja.SingleResponse[invoiceninja.Payment]{},
+		"payments_list.json": &invoiceninja.ListResponse[invoiceninja.Payment]{},
+	}
+
+	for name, target := range fixtures {
+		if err := json.Unmarshal(invoiceninjatest.Fixture(name), target); err != nil {
+			t.Errorf("fixture %s: failed to decode: %v", name, err)
+		}
+	}
+}
+
+func TestFixtureUnknownPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for unknown fixture")
+		}
+	}()
+
+	invoiceninjatest.Fixture("does-not-exist.json")
+}
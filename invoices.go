@@ -2,11 +2,17 @@ package invoiceninja
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strconv"
 )
 
+// ErrNoNextNumber is returned by NextNumber when the server does not fill in
+// the number of a blank invoice.
+var ErrNoNextNumber = errors.New("server did not provide the next invoice number")
+
 // InvoicesService handles invoice-related API operations.
 type InvoicesService struct {
 	client *Client
@@ -26,6 +32,9 @@ type InvoiceListOptions struct {
 	// ClientID filters by client.
 	ClientID string
 
+	// AssignedUserID filters by the user the invoice is assigned to.
+	AssignedUserID string
+
 	// Status filters by status (comma-separated: active, archived, deleted).
 	Status string
 
@@ -43,6 +52,14 @@ type InvoiceListOptions struct {
 
 	// Include specifies related entities to include.
 	Include string
+
+	// Fields limits the decoded invoices to these JSON fields, e.g. "number"
+	// and "balance"; ID is always kept and the rest are zeroed. Invoice Ninja
+	// has no sparse fieldsets, so this saves memory, not bandwidth.
+	Fields []string
+
+	// Filters are additional query filters built with NewFilter.
+	Filters []FilterExpr
 }
 
 // toQuery converts options to URL query parameters.
@@ -68,6 +85,9 @@ func (o *InvoiceListOptions) toQuery() url.Values {
 	if o.Status != "" {
 		q.Set("status", o.Status)
 	}
+	if o.AssignedUserID != "" {
+		q.Set("assigned_user", o.AssignedUserID)
+	}
 	if o.CreatedAt != "" {
 		q.Set("created_at", o.CreatedAt)
 	}
@@ -84,48 +104,144 @@ func (o *InvoiceListOptions) toQuery() url.Values {
 		q.Set("include", o.Include)
 	}
 
+	applyFilters(q, o.Filters)
+
 	return q
 }
 
+// fieldList returns the Fields option of possibly nil options.
+func (o *InvoiceListOptions) fieldList() []string {
+	if o == nil {
+		return nil
+	}
+	return o.Fields
+}
+
 // List retrieves a list of invoices.
 func (s *InvoicesService) List(ctx context.Context, opts *InvoiceListOptions) (*ListResponse[Invoice], error) {
+	fields, err := newFieldSelection[Invoice](opts.fieldList())
+	if err != nil {
+		return nil, err
+	}
+
 	var resp ListResponse[Invoice]
-	if err := s.client.doRequest(ctx, "GET", "/api/v1/invoices", opts.toQuery(), nil, &resp); err != nil {
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/invoices"), opts.toQuery(), nil, &resp); err != nil {
 		return nil, err
 	}
+	fields.apply(resp.Data)
 	return &resp, nil
 }
 
+// Iter returns an iterator over all invoices matching opts, fetching pages lazily.
+// Paging starts at opts.Page (or page 1) and keeps every other option.
+func (s *InvoicesService) Iter(ctx context.Context, opts *InvoiceListOptions) *Iterator[Invoice] {
+	it := newIterator[Invoice](ctx, s.client, s.client.apiPath("/invoices"), opts.toQuery())
+	it.fields, it.err = newFieldSelection[Invoice](opts.fieldList())
+	return it
+}
+
+// ListAll retrieves all invoices matching opts across every page.
+// If a page fails or ctx is done mid-scan, the invoices fetched so far are
+// returned together with the error.
+func (s *InvoicesService) ListAll(ctx context.Context, opts *InvoiceListOptions) ([]Invoice, error) {
+	return listAll(s.Iter(ctx, opts))
+}
+
+// Count returns the number of invoices matching opts without fetching them,
+// from the pagination total of a one-item page. Paging options are ignored.
+func (s *InvoicesService) Count(ctx context.Context, opts *InvoiceListOptions) (int, error) {
+	return count(ctx, s.client, s.client.apiPath("/invoices"), opts.toQuery())
+}
+
 // Get retrieves a single invoice by ID.
 func (s *InvoicesService) Get(ctx context.Context, id string) (*Invoice, error) {
+	return s.GetWith(ctx, id)
+}
+
+// GetWith retrieves a single invoice by ID together with the related entities
+// named in include, e.g. "payments", "invitations", in one request.
+func (s *InvoicesService) GetWith(ctx context.Context, id string, include ...string) (*Invoice, error) {
 	var resp SingleResponse[Invoice]
-	if err := s.client.doRequest(ctx, "GET", fmt.Sprintf("/api/v1/invoices/%s", id), nil, nil, &resp); err != nil {
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/invoices/%s", id), includeQuery(include), nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp.Data, nil
 }
 
+// Documents returns the documents attached to the invoice with the given ID,
+// fetching the invoice with its documents included.
+func (s *InvoicesService) Documents(ctx context.Context, invoiceID string) ([]Document, error) {
+	invoice, err := s.GetWith(ctx, invoiceID, "documents")
+	if err != nil {
+		return nil, err
+	}
+	return invoice.Documents, nil
+}
+
 // Create creates a new invoice.
-func (s *InvoicesService) Create(ctx context.Context, invoice *Invoice) (*Invoice, error) {
+// With WithClientValidation, Invoice.Validate is checked before sending.
+func (s *InvoicesService) Create(ctx context.Context, invoice *Invoice, opts ...RequestOption) (*Invoice, error) {
+	if s.client.validateRequests {
+		if err := invoice.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	var resp SingleResponse[Invoice]
-	if err := s.client.doRequest(ctx, "POST", "/api/v1/invoices", nil, invoice, &resp); err != nil {
+	if err := s.client.doRequest(ctx, "POST", s.client.apiPath("/invoices"), nil, invoice, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp.Data, nil
 }
 
+// CreateBatch creates invoices, e.g. rows of an import, reporting each result
+// separately: created[i] and errs[i] belong to invoices[i], and one bad row does
+// not stop the rest. With opts.Concurrency above 1, that many creates run at
+// once. If ctx is done, the invoices not yet sent fail with its error.
+func (s *InvoicesService) CreateBatch(ctx context.Context, invoices []*Invoice, opts *BatchOptions) (created []*Invoice, errs []error) {
+	return createBatch(ctx, s.client, invoices, opts, func(ctx context.Context, invoice *Invoice) (*Invoice, error) {
+		return s.Create(ctx, invoice)
+	})
+}
+
+// Preview returns a copy of invoice with Amount, TotalTaxes and Balance
+// filled in as they would be on save, e.g. to show a live total before the
+// invoice is created. Invoice Ninja has no endpoint that calculates totals
+// without saving (its live preview renders a PDF), so Preview makes no request
+// and computes the totals with ComputeTotals; invoice is not modified.
+func (s *InvoicesService) Preview(ctx context.Context, invoice *Invoice) (*Invoice, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	preview := *invoice
+	preview.LineItems = append([]LineItem(nil), invoice.LineItems...)
+	preview.ComputeTotals()
+	preview.Balance = roundCents(preview.Amount - preview.PaidToDate)
+	return &preview, nil
+}
+
 // Update updates an existing invoice.
-func (s *InvoicesService) Update(ctx context.Context, id string, invoice *Invoice) (*Invoice, error) {
+func (s *InvoicesService) Update(ctx context.Context, id string, invoice *Invoice, opts ...RequestOption) (*Invoice, error) {
 	var resp SingleResponse[Invoice]
-	if err := s.client.doRequest(ctx, "PUT", fmt.Sprintf("/api/v1/invoices/%s", id), nil, invoice, &resp); err != nil {
+	if err := s.client.doRequest(ctx, "PUT", s.client.apiPath("/invoices/%s", id), nil, invoice, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp.Data, nil
 }
 
+// Assign assigns the invoice to the user with the given ID by updating only its
+// assigned user.
+func (s *InvoicesService) Assign(ctx context.Context, id, userID string, opts ...RequestOption) (*Invoice, error) {
+	if err := validateAssignee(userID); err != nil {
+		return nil, err
+	}
+	return s.Update(ctx, id, &Invoice{AssignedUserID: userID}, opts...)
+}
+
 // Delete deletes an invoice by ID.
-func (s *InvoicesService) Delete(ctx context.Context, id string) error {
-	return s.client.doRequest(ctx, "DELETE", fmt.Sprintf("/api/v1/invoices/%s", id), nil, nil, nil)
+func (s *InvoicesService) Delete(ctx context.Context, id string, opts ...RequestOption) error {
+	return s.client.doRequest(ctx, "DELETE", s.client.apiPath("/invoices/%s", id), nil, nil, nil, opts...)
 }
 
 // Archive archives an invoice.
@@ -133,7 +249,7 @@ func (s *InvoicesService) Archive(ctx context.Context, id string) (*Invoice, err
 	return s.bulkAction(ctx, "archive", id)
 }
 
-// Restore restores an archived invoice.
+// Restore restores an archived or deleted invoice.
 func (s *InvoicesService) Restore(ctx context.Context, id string) (*Invoice, error) {
 	return s.bulkAction(ctx, "restore", id)
 }
@@ -143,28 +259,217 @@ func (s *InvoicesService) MarkPaid(ctx context.Context, id string) (*Invoice, er
 	return s.bulkAction(ctx, "mark_paid", id)
 }
 
+// MarkPaidOptions controls the payment recorded by MarkPaidWithOptions.
+type MarkPaidOptions struct {
+	// Date is the payment date (YYYY-MM-DD); the server uses today if empty.
+	Date string
+
+	// Type is the payment type; the server's default is used if zero.
+	Type PaymentType
+
+	// TransactionReference is stored on the payment, e.g. a bank reference.
+	TransactionReference string
+
+	// SendEmail emails a payment receipt to the client.
+	SendEmail bool
+}
+
+// MarkPaidWithOptions pays an invoice's full balance like MarkPaid, but
+// records the payment with the given date, type and reference, and can email
+// a receipt. The mark_paid bulk action cannot express these, so a payment is
+// created for the balance instead. It returns the updated invoice and the
+// created payment.
+//
+// If the invoice has no balance left, the error matches ErrInvoiceAlreadyPaid.
+func (s *InvoicesService) MarkPaidWithOptions(ctx context.Context, id string, opts MarkPaidOptions) (*Invoice, *Payment, error) {
+	invoice, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if invoice.Balance <= balanceTolerance {
+		return nil, nil, fmt.Errorf("invoice %s: %w", id, ErrInvoiceAlreadyPaid)
+	}
+
+	req := &PaymentRequest{
+		ClientID:       invoice.ClientID,
+		Date:           opts.Date,
+		TransactionRef: opts.TransactionReference,
+		Amount:         invoice.Balance,
+		Invoices:       []PaymentInvoice{{InvoiceID: id, Amount: invoice.Balance}},
+	}
+	if opts.Type != 0 {
+		req.SetType(opts.Type)
+	}
+
+	payment, err := s.client.Payments.CreateWithEmailReceipt(ctx, req, opts.SendEmail)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	invoice, err = s.Get(ctx, id)
+	if err != nil {
+		return nil, payment, err
+	}
+	return invoice, payment, nil
+}
+
 // MarkSent marks an invoice as sent.
 func (s *InvoicesService) MarkSent(ctx context.Context, id string) (*Invoice, error) {
 	return s.bulkAction(ctx, "mark_sent", id)
 }
 
+// Cancel cancels a sent or partially paid invoice (InvoiceStatusCancelled).
+// The remaining balance is no longer owed; payments already received are
+// kept.
+func (s *InvoicesService) Cancel(ctx context.Context, id string) (*Invoice, error) {
+	return s.bulkAction(ctx, "cancel", id)
+}
+
+// Reverse reverses a paid or partially paid invoice (InvoiceStatusReversed).
+// Its payments are detached and credited back to the client, and the
+// invoice balance is cleared.
+func (s *InvoicesService) Reverse(ctx context.Context, id string) (*Invoice, error) {
+	return s.bulkAction(ctx, "reverse", id)
+}
+
+// AutoBill charges the client's stored payment method for the invoice's
+// balance and returns the updated invoice, which is paid if the charge went
+// through. Depending on the server's queue configuration the charge may run
+// in the background, so check the returned invoice's Status.
+//
+// If the server rejects the charge, the error is an *AutoBillError whose
+// Reason distinguishes a missing payment method (ErrNoPaymentMethod) from a
+// gateway decline (ErrPaymentDeclined):
+//
+//	_, err := client.Invoices.AutoBill(ctx, id)
+//	if errors.Is(err, invoiceninja.ErrPaymentDeclined) {
+//		// schedule the next dunning attempt
+//	}
+func (s *InvoicesService) AutoBill(ctx context.Context, id string) (*Invoice, error) {
+	invoice, err := s.bulkAction(ctx, "auto_bill", id)
+	if apiErr, ok := IsAPIError(err); ok && (apiErr.StatusCode == http.StatusBadRequest || apiErr.StatusCode == http.StatusUnprocessableEntity) {
+		return nil, newAutoBillError(id, apiErr)
+	}
+	return invoice, err
+}
+
 // Email sends an invoice via email.
 func (s *InvoicesService) Email(ctx context.Context, id string) (*Invoice, error) {
 	return s.bulkAction(ctx, "email", id)
 }
 
+// EmailOptions customizes an email sent with InvoicesService.EmailWithOptions.
+type EmailOptions struct {
+	// Template is the email template (default "email_template_invoice").
+	Template string
+
+	// Subject and Body override the template's subject and body when set.
+	Subject string
+	Body    string
+
+	// CCEmail is an additional recipient.
+	CCEmail string
+
+	// AttachPDF overrides the company's PDF attachment setting for this send:
+	// true attaches the PDF, false sends only the portal link. When nil, the
+	// server configuration applies.
+	AttachPDF *bool
+}
+
+// emailRequest is the body of a POST to the emails endpoint.
+type emailRequest struct {
+	Entity    string `json:"entity"`
+	EntityID  string `json:"entity_id"`
+	Template  string `json:"template"`
+	Subject   string `json:"subject,omitempty"`
+	Body      string `json:"body,omitempty"`
+	CCEmail   string `json:"cc_email,omitempty"`
+	AttachPDF *bool  `json:"pdf_email_attachment,omitempty"`
+}
+
+// EmailWithOptions emails an invoice to the client's contacts like Email,
+// with a custom template, subject, body or PDF attachment setting. A nil opts
+// is equivalent to the default template.
+func (s *InvoicesService) EmailWithOptions(ctx context.Context, id string, opts *EmailOptions) (*Invoice, error) {
+	req := emailRequest{
+		Entity:   "invoice",
+		EntityID: id,
+		Template: "email_template_invoice",
+	}
+	if opts != nil {
+		if opts.Template != "" {
+			req.Template = opts.Template
+		}
+		req.Subject = opts.Subject
+		req.Body = opts.Body
+		req.CCEmail = opts.CCEmail
+		req.AttachPDF = opts.AttachPDF
+	}
+
+	var resp SingleResponse[Invoice]
+	if err := s.client.doRequest(ctx, "POST", s.client.apiPath("/emails"), nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Remind emails the next payment reminder for an invoice: the first, second
+// or third reminder template, depending on which the invoice has not been sent
+// yet, and the endless reminder after that. The invoice is fetched first to
+// read its Reminder1Sent to Reminder3Sent dates, and the server records the
+// send in them and in ReminderLastSent. It returns the updated invoice.
+func (s *InvoicesService) Remind(ctx context.Context, id string) (*Invoice, error) {
+	invoice, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.EmailWithOptions(ctx, id, &EmailOptions{Template: nextReminderTemplate(invoice)})
+}
+
+// nextReminderTemplate returns the email template of the reminder to send next
+// for invoice.
+func nextReminderTemplate(invoice *Invoice) string {
+	switch {
+	case invoice.Reminder1Sent == "":
+		return "email_template_reminder1"
+	case invoice.Reminder2Sent == "":
+		return "email_template_reminder2"
+	case invoice.Reminder3Sent == "":
+		return "email_template_reminder3"
+	default:
+		return "email_template_reminder_endless"
+	}
+}
+
 // Bulk performs a bulk action on multiple invoices.
-func (s *InvoicesService) Bulk(ctx context.Context, action string, ids []string) ([]Invoice, error) {
+func (s *InvoicesService) Bulk(ctx context.Context, action string, ids []string, opts ...RequestOption) ([]Invoice, error) {
 	req := BulkAction{
 		Action: action,
 		IDs:    ids,
 	}
 
-	var resp ListResponse[Invoice]
-	if err := s.client.doRequest(ctx, "POST", "/api/v1/invoices/bulk", nil, req, &resp); err != nil {
+	return bulkRequest[Invoice](ctx, s.client, s.client.apiPath("/invoices/bulk"), req, opts...)
+}
+
+// BulkTyped performs a bulk action on multiple invoices like Bulk, but first
+// checks that action is one of BulkArchive, BulkRestore, BulkDelete,
+// BulkMarkSent, BulkEmail, BulkMarkPaid, BulkCancel, BulkReverse and
+// BulkAutoBill, returning a *ValidationError otherwise.
+func (s *InvoicesService) BulkTyped(ctx context.Context, action BulkActionType, ids []string, opts ...RequestOption) ([]Invoice, error) {
+	if err := checkBulkAction("invoices", action, BulkMarkSent, BulkEmail, BulkMarkPaid, BulkCancel, BulkReverse, BulkAutoBill); err != nil {
 		return nil, err
 	}
-	return resp.Data, nil
+	return s.Bulk(ctx, string(action), ids, opts...)
+}
+
+// BulkChunked performs a bulk action on a large number of invoices by splitting ids
+// into chunks of chunkSize (DefaultBulkChunkSize when chunkSize <= 0) and sending
+// one Bulk request per chunk. It returns the invoices echoed by successful chunks along
+// with a joined error of *BulkChunkError values for any chunks that failed.
+func (s *InvoicesService) BulkChunked(ctx context.Context, action string, ids []string, chunkSize int, opts ...RequestOption) ([]Invoice, error) {
+	return bulkChunked(ctx, ids, chunkSize, func(ctx context.Context, chunk []string) ([]Invoice, error) {
+		return s.Bulk(ctx, action, chunk, opts...)
+	})
 }
 
 // bulkAction performs a single-item bulk action.
@@ -174,23 +479,124 @@ func (s *InvoicesService) bulkAction(ctx context.Context, action, id string) (*I
 		return nil, err
 	}
 	if len(invoices) == 0 {
-		return nil, fmt.Errorf("no invoice returned from bulk action")
+		return nil, fmt.Errorf("%s invoice: %w", action, ErrEmptyBulkResponse)
 	}
 	return &invoices[0], nil
 }
 
-// GetBlank retrieves a blank invoice object with default values.
+// GetBlank retrieves a blank invoice object with default values. Depending on
+// the server version and the company's numbering settings, its Number may
+// already hold the next invoice number; see NextNumber.
 func (s *InvoicesService) GetBlank(ctx context.Context) (*Invoice, error) {
 	var resp SingleResponse[Invoice]
-	if err := s.client.doRequest(ctx, "GET", "/api/v1/invoices/create", nil, nil, &resp); err != nil {
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/invoices/create"), nil, nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp.Data, nil
 }
 
-// Download downloads an invoice PDF.
+// NextNumber returns the number the next invoice is expected to get, taken
+// from the blank invoice. It is a preview, not a reservation: an invoice
+// created in the meantime takes the number, and the server assigns the
+// actual number on save. It returns ErrNoNextNumber if the server leaves the
+// blank invoice's number empty.
+func (s *InvoicesService) NextNumber(ctx context.Context) (string, error) {
+	blank, err := s.GetBlank(ctx)
+	if err != nil {
+		return "", err
+	}
+	if blank.Number == "" {
+		return "", ErrNoNextNumber
+	}
+	return blank.Number, nil
+}
+
+// ResolveProducts fills in line items from the product catalog. For each line
+// item with a ProductKey, the matching product is looked up and the item's
+// Cost (from the product price), Notes and tax fields are set if they are
+// empty. Fields the caller already set are left unchanged.
+//
+// Each product key is looked up once. Line items whose key matches no product
+// are left as is and reported in the returned error, which wraps ErrProductNotFound.
+func (s *InvoicesService) ResolveProducts(ctx context.Context, inv *Invoice) error {
+	products := make(map[string]*Product)
+	var errs []error
+
+	for i := range inv.LineItems {
+		item := &inv.LineItems[i]
+		if item.ProductKey == "" {
+			continue
+		}
+
+		product, seen := products[item.ProductKey]
+		if !seen {
+			var err error
+			product, err = s.client.Products.GetByKey(ctx, item.ProductKey)
+			if err != nil && !errors.Is(err, ErrProductNotFound) {
+				return err
+			}
+			if err != nil {
+				errs = append(errs, err)
+			}
+			products[item.ProductKey] = product
+		}
+		if product == nil {
+			continue
+		}
+
+		if item.Cost == 0 {
+			item.Cost = product.Price
+		}
+		if item.Notes == "" {
+			item.Notes = product.Notes
+		}
+		if item.TaxName1 == "" && item.TaxRate1 == 0 {
+			item.TaxName1, item.TaxRate1 = product.TaxName1, product.TaxRate1
+		}
+		if item.TaxName2 == "" && item.TaxRate2 == 0 {
+			item.TaxName2, item.TaxRate2 = product.TaxName2, product.TaxRate2
+		}
+		if item.TaxName3 == "" && item.TaxRate3 == 0 {
+			item.TaxName3, item.TaxRate3 = product.TaxName3, product.TaxRate3
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// PortalLink returns the client portal URL of the invoice, where the client
+// can view and pay it, for its first invitation. baseURL is the portal's
+// address, e.g. "https://invoicing.example.com"; on self-hosted instances it
+// can differ from the API host. The invitations must have been fetched, see
+// Invitations; otherwise an error matching ErrNoInvitations is returned.
+func (inv *Invoice) PortalLink(baseURL string) (string, error) {
+	if len(inv.Invitations) == 0 || inv.Invitations[0].Key == "" {
+		return "", fmt.Errorf("invoice %s: %w", inv.ID, ErrNoInvitations)
+	}
+
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("invalid portal base URL %q", baseURL)
+	}
+	return u.JoinPath("client", "invoice", inv.Invitations[0].Key).String(), nil
+}
+
+// Download downloads an invoice PDF by invitation key.
+// It is equivalent to Downloads.DownloadInvoicePDF.
 func (s *InvoicesService) Download(ctx context.Context, invitationKey string) ([]byte, error) {
-	// This would need special handling for binary response
-	// For now, we'll return the raw bytes
-	return nil, fmt.Errorf("not implemented - use client.Request with custom handling")
+	return s.client.Downloads.DownloadInvoicePDF(ctx, invitationKey)
+}
+
+// DownloadByID downloads an invoice PDF by invoice ID, using the key of the
+// invoice's first invitation. It returns an error matching ErrNoInvitations
+// if the invoice's client has no contacts.
+func (s *InvoicesService) DownloadByID(ctx context.Context, id string) ([]byte, error) {
+	invoice, err := s.GetWith(ctx, id, "invitations")
+	if err != nil {
+		return nil, err
+	}
+	if len(invoice.Invitations) == 0 {
+		return nil, fmt.Errorf("invoice %s: %w", id, ErrNoInvitations)
+	}
+	return s.Download(ctx, invoice.Invitations[0].Key)
 }
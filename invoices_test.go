@@ -3,8 +3,12 @@ package invoiceninja
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -107,13 +111,17 @@ func TestInvoicesServiceCreate(t *testing.T) {
 		if body.ClientID != "client123" {
 			t.Errorf("expected client_id to be 'client123', got '%s'", body.ClientID)
 		}
+		if !boolValue(body.UsesInclusiveTaxes) {
+			t.Error("expected uses_inclusive_taxes to be sent")
+		}
 
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"data": map[string]interface{}{
-				"id":        "newinv123",
-				"client_id": "client123",
-				"number":    "INV003",
+				"id":                   "newinv123",
+				"client_id":            "client123",
+				"number":               "INV003",
+				"uses_inclusive_taxes": true,
 			},
 		})
 	}))
@@ -121,8 +129,10 @@ func TestInvoicesServiceCreate(t *testing.T) {
 
 	client := NewClient("test-token", WithBaseURL(server.URL))
 
+	inclusive := true
 	req := &Invoice{
-		ClientID: "client123",
+		ClientID:           "client123",
+		UsesInclusiveTaxes: &inclusive,
 		LineItems: []LineItem{
 			{ProductKey: "Product A", Quantity: 2, Cost: 100.00},
 		},
@@ -136,6 +146,9 @@ func TestInvoicesServiceCreate(t *testing.T) {
 	if invoice.ID != "newinv123" {
 		t.Errorf("expected invoice ID to be 'newinv123', got '%s'", invoice.ID)
 	}
+	if !boolValue(invoice.UsesInclusiveTaxes) {
+		t.Error("expected uses_inclusive_taxes to be decoded")
+	}
 }
 
 func TestInvoicesServiceUpdate(t *testing.T) {
@@ -173,6 +186,38 @@ func TestInvoicesServiceUpdate(t *testing.T) {
 	}
 }
 
+func TestInvoicesServiceUpdateSendsFalseFlags(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		for _, key := range []string{"uses_inclusive_taxes", "is_amount_discount"} {
+			if v, ok := body[key]; !ok || v != false {
+				t.Errorf("expected %s to be sent as false, got %v", key, v)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "inv123", "uses_inclusive_taxes": false}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	req := NewInvoiceBuilder("client123").WithDiscount(10, true).Build()
+	exclusive := false
+	req.UsesInclusiveTaxes = &exclusive
+
+	invoice, err := client.Invoices.Update(context.Background(), "inv123", req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if invoice.UsesInclusiveTaxes == nil || *invoice.UsesInclusiveTaxes {
+		t.Errorf("expected uses_inclusive_taxes to decode as false, got %v", invoice.UsesInclusiveTaxes)
+	}
+}
+
 func TestInvoicesServiceDelete(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != "DELETE" {
@@ -256,6 +301,47 @@ func TestInvoicesServiceMarkPaid(t *testing.T) {
 	}
 }
 
+func TestInvoicesServiceCancelAndReverse(t *testing.T) {
+	tests := []struct {
+		action string
+		call   func(*InvoicesService) (*Invoice, error)
+		status InvoiceStatus
+	}{
+		{"cancel", func(s *InvoicesService) (*Invoice, error) { return s.Cancel(context.Background(), "inv123") }, InvoiceStatusCancelled},
+		{"reverse", func(s *InvoicesService) (*Invoice, error) { return s.Reverse(context.Background(), "inv123") }, InvoiceStatusReversed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.action, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v1/invoices/bulk" {
+					t.Errorf("expected path /api/v1/invoices/bulk, got %s", r.URL.Path)
+				}
+
+				var body BulkAction
+				json.NewDecoder(r.Body).Decode(&body)
+				if body.Action != tt.action || len(body.IDs) != 1 || body.IDs[0] != "inv123" {
+					t.Errorf("unexpected bulk body: %+v", body)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintf(w, `{"data": [{"id": "inv123", "status_id": "%d"}]}`, tt.status)
+			}))
+			defer server.Close()
+
+			client := NewClient("test-token", WithBaseURL(server.URL))
+
+			invoice, err := tt.call(client.Invoices)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if invoice.Status() != tt.status {
+				t.Errorf("expected status %s, got %s", tt.status, invoice.Status())
+			}
+		})
+	}
+}
+
 func TestInvoiceListOptionsToQuery(t *testing.T) {
 	isDeleted := false
 	opts := &InvoiceListOptions{
@@ -297,3 +383,584 @@ func TestInvoiceListOptionsNilToQuery(t *testing.T) {
 		t.Error("expected nil query for nil options")
 	}
 }
+
+func TestInvoicesServiceResolveProducts(t *testing.T) {
+	lookups := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/products" {
+			t.Errorf("expected path /api/v1/products, got %s", r.URL.Path)
+		}
+
+		key := r.URL.Query().Get("product_key")
+		lookups[key]++
+
+		w.Header().Set("Content-Type", "application/json")
+		switch key {
+		case "WIDGET":
+			w.Write([]byte(`{"data": [{"product_key": "WIDGET", "price": 12.5, "cost": 4, "notes": "Blue widget", "tax_name1": "VAT", "tax_rate1": 20}]}`))
+		default:
+			w.Write([]byte(`{"data": []}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	inv := &Invoice{
+		LineItems: []LineItem{
+			{ProductKey: "WIDGET", Quantity: 2},
+			{ProductKey: "WIDGET", Quantity: 1, Cost: 10, Notes: "Discounted"},
+			{Notes: "Custom work", Cost: 100},
+			{ProductKey: "UNKNOWN", Quantity: 1},
+		},
+	}
+
+	err := client.Invoices.ResolveProducts(context.Background(), inv)
+	if !errors.Is(err, ErrProductNotFound) {
+		t.Fatalf("expected ErrProductNotFound for unknown key, got %v", err)
+	}
+
+	first := inv.LineItems[0]
+	if first.Cost != 12.5 || first.Notes != "Blue widget" || first.TaxName1 != "VAT" || first.TaxRate1 != 20 {
+		t.Errorf("expected first item filled from product, got %+v", first)
+	}
+
+	second := inv.LineItems[1]
+	if second.Cost != 10 || second.Notes != "Discounted" || second.TaxName1 != "VAT" {
+		t.Errorf("expected second item to keep its cost and notes, got %+v", second)
+	}
+
+	if inv.LineItems[2].Cost != 100 || inv.LineItems[3].Cost != 0 {
+		t.Errorf("expected items without a matching product untouched, got %+v", inv.LineItems[2:])
+	}
+
+	if lookups["WIDGET"] != 1 || lookups["UNKNOWN"] != 1 || len(lookups) != 2 {
+		t.Errorf("expected one lookup per product key, got %v", lookups)
+	}
+}
+
+func TestInvoicesServiceEmailWithOptions(t *testing.T) {
+	attach, noAttach := true, false
+
+	tests := []struct {
+		name     string
+		opts     *EmailOptions
+		expected string
+	}{
+		{"server default", nil, `{"entity":"invoice","entity_id":"inv1","template":"email_template_invoice"}`},
+		{"attach", &EmailOptions{AttachPDF: &attach}, `{"entity":"invoice","entity_id":"inv1","template":"email_template_invoice","pdf_email_attachment":true}`},
+		{"link only", &EmailOptions{Template: "email_template_reminder1", Subject: "Reminder", AttachPDF: &noAttach}, `{"entity":"invoice","entity_id":"inv1","template":"email_template_reminder1","subject":"Reminder","pdf_email_attachment":false}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "POST" || r.URL.Path != "/api/v1/emails" {
+					t.Errorf("expected POST /api/v1/emails, got %s %s", r.Method, r.URL.Path)
+				}
+
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != tt.expected {
+					t.Errorf("expected body %s, got %s", tt.expected, body)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"data": {"id": "inv1", "status_id": "2"}}`))
+			}))
+			defer server.Close()
+
+			client := NewClient("test-token", WithBaseURL(server.URL))
+
+			invoice, err := client.Invoices.EmailWithOptions(context.Background(), "inv1", tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if invoice.Status() != InvoiceStatusSent {
+				t.Errorf("expected sent status, got %s", invoice.Status())
+			}
+		})
+	}
+}
+
+func TestInvoicesServiceRemind(t *testing.T) {
+	tests := []struct {
+		name     string
+		invoice  string
+		template string
+	}{
+		{"first", `{"id": "inv1"}`, "email_template_reminder1"},
+		{"second", `{"id": "inv1", "reminder1_sent": "2026-10-01"}`, "email_template_reminder2"},
+		{"third", `{"id": "inv1", "reminder1_sent": "2026-10-01", "reminder2_sent": "2026-10-08"}`, "email_template_reminder3"},
+		{"endless", `{"id": "inv1", "reminder1_sent": "2026-10-01", "reminder2_sent": "2026-10-08", "reminder3_sent": "2026-10-15"}`, "email_template_reminder_endless"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				switch r.Method + " " + r.URL.Path {
+				case "GET /api/v1/invoices/inv1":
+					w.Write([]byte(`{"data": ` + tt.invoice + `}`))
+				case "POST /api/v1/emails":
+					var body emailRequest
+					json.NewDecoder(r.Body).Decode(&body)
+					if body.Entity != "invoice" || body.EntityID != "inv1" || body.Template != tt.template {
+						t.Errorf("expected %s for invoice inv1, got %+v", tt.template, body)
+					}
+					w.Write([]byte(`{"data": {"id": "inv1", "reminder1_sent": "2026-10-16", "reminder_last_sent": "2026-10-16"}}`))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			}))
+			defer server.Close()
+
+			client := NewClient("test-token", WithBaseURL(server.URL))
+
+			invoice, err := client.Invoices.Remind(context.Background(), "inv1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if invoice.ReminderLastSent != "2026-10-16" {
+				t.Errorf("expected ReminderLastSent 2026-10-16, got %q", invoice.ReminderLastSent)
+			}
+		})
+	}
+}
+
+func TestInvoicesServiceAutoBill(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body BulkAction
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Action != "auto_bill" {
+			t.Errorf("expected action auto_bill, got %s", body.Action)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": [{"id": "inv123", "status_id": "4", "balance": 0}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	invoice, err := client.Invoices.AutoBill(context.Background(), "inv123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if invoice.Status() != InvoiceStatusPaid {
+		t.Errorf("expected paid invoice, got %s", invoice.Status())
+	}
+}
+
+func TestInvoicesServiceAutoBillErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		message string
+		reason  error
+	}{
+		{"no payment method", http.StatusBadRequest, "No payment methods on file for this client", ErrNoPaymentMethod},
+		{"no valid payment method", http.StatusBadRequest, "Client has no valid payment method", ErrNoPaymentMethod},
+		{"declined", http.StatusBadRequest, "Your card was declined.", ErrPaymentDeclined},
+		{"insufficient funds", http.StatusUnprocessableEntity, "Insufficient funds", ErrPaymentDeclined},
+		{"unclassified", http.StatusBadRequest, "Gateway unavailable", nil},
+		{"card saved is not declined", http.StatusBadRequest, "Card was saved but the gateway is unavailable", nil},
+		{"missing auth token is not a payment method", http.StatusBadRequest, "No token provided in the request", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				json.NewEncoder(w).Encode(map[string]string{"message": tt.message})
+			}))
+			defer server.Close()
+
+			client := NewClient("test-token", WithBaseURL(server.URL))
+
+			_, err := client.Invoices.AutoBill(context.Background(), "inv123")
+
+			var abErr *AutoBillError
+			if !errors.As(err, &abErr) {
+				t.Fatalf("expected AutoBillError, got %v", err)
+			}
+			if abErr.InvoiceID != "inv123" || abErr.Reason != tt.reason {
+				t.Errorf("unexpected AutoBillError: %+v", abErr)
+			}
+			if tt.reason != nil && !errors.Is(err, tt.reason) {
+				t.Errorf("expected errors.Is(err, %v)", tt.reason)
+			}
+			if apiErr, ok := IsAPIError(err); !ok || apiErr.StatusCode != tt.status {
+				t.Errorf("expected wrapped APIError with status %d, got %v", tt.status, err)
+			}
+		})
+	}
+}
+
+func TestInvoicesServiceAutoBillServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	_, err := client.Invoices.AutoBill(context.Background(), "inv123")
+	var abErr *AutoBillError
+	if errors.As(err, &abErr) {
+		t.Errorf("expected a plain APIError for a server error, got %v", err)
+	}
+}
+
+func TestInvoicesServiceMarkPaidWithOptions(t *testing.T) {
+	paid := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v1/invoices/inv1":
+			if paid {
+				w.Write([]byte(`{"data": {"id": "inv1", "client_id": "c1", "status_id": "4", "balance": 0}}`))
+			} else {
+				w.Write([]byte(`{"data": {"id": "inv1", "client_id": "c1", "status_id": "2", "balance": 125.5}}`))
+			}
+		case r.Method == "POST" && r.URL.Path == "/api/v1/payments":
+			if r.URL.Query().Get("email_receipt") != "true" {
+				t.Errorf("expected email_receipt=true, got %s", r.URL.RawQuery)
+			}
+			var req PaymentRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.ClientID != "c1" || req.Amount != 125.5 || req.Date != "2024-03-01" || req.TransactionRef != "BANK-42" || req.Type() != PaymentTypeBankTransfer {
+				t.Errorf("unexpected payment request: %+v", req)
+			}
+			if len(req.Invoices) != 1 || req.Invoices[0] != (PaymentInvoice{InvoiceID: "inv1", Amount: 125.5}) {
+				t.Errorf("unexpected invoices: %+v", req.Invoices)
+			}
+			paid = true
+			w.Write([]byte(`{"data": {"id": "pay1", "amount": 125.5}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	invoice, payment, err := client.Invoices.MarkPaidWithOptions(context.Background(), "inv1", MarkPaidOptions{
+		Date:                 "2024-03-01",
+		Type:                 PaymentTypeBankTransfer,
+		TransactionReference: "BANK-42",
+		SendEmail:            true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if payment.ID != "pay1" {
+		t.Errorf("expected payment pay1, got %s", payment.ID)
+	}
+	if invoice.Status() != InvoiceStatusPaid {
+		t.Errorf("expected the refreshed invoice to be paid, got %s", invoice.Status())
+	}
+}
+
+func TestInvoicesServiceMarkPaidWithOptionsAlreadyPaid(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("expected no payment to be created, got %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "inv1", "client_id": "c1", "balance": 0}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	_, _, err := client.Invoices.MarkPaidWithOptions(context.Background(), "inv1", MarkPaidOptions{})
+	if !errors.Is(err, ErrInvoiceAlreadyPaid) {
+		t.Errorf("expected ErrInvoiceAlreadyPaid, got %v", err)
+	}
+}
+
+func TestInvoicesServiceAssign(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/api/v1/invoices/inv1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if len(body) != 1 || body["assigned_user_id"] != "user7" {
+			t.Errorf("expected only assigned_user_id to be sent, got %v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "inv1", "assigned_user_id": "user7"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	invoice, err := client.Invoices.Assign(context.Background(), "inv1", "user7")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if invoice.AssignedUserID != "user7" {
+		t.Errorf("expected assigned user user7, got %q", invoice.AssignedUserID)
+	}
+
+	var verr *ValidationError
+	if _, err := client.Invoices.Assign(context.Background(), "inv1", ""); !errors.As(err, &verr) {
+		t.Errorf("expected a ValidationError for an empty user ID, got %v", err)
+	}
+}
+
+func TestInvoicesServicePreview(t *testing.T) {
+	client := NewClient("test-token", WithBaseURL("http://127.0.0.1:0"))
+
+	invoice := NewInvoiceBuilder("c1").AddLineItem("consulting", 10, 150).WithTax("VAT", 20).Build()
+
+	preview, err := client.Invoices.Preview(context.Background(), invoice)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if preview.Amount != 1800 || preview.TotalTaxes != 300 || preview.Balance != 1800 {
+		t.Errorf("expected amount 1800, taxes 300 and balance 1800, got %v, %v and %v", preview.Amount, preview.TotalTaxes, preview.Balance)
+	}
+	if invoice.Amount != 0 {
+		t.Error("expected the original invoice to be left unchanged")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.Invoices.Preview(ctx, invoice); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestInvoicesServiceDownloadByID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/invoices/inv1", "/api/v1/invoices/inv2":
+			if r.URL.Query().Get("include") != "invitations" {
+				t.Errorf("expected include=invitations, got %s", r.URL.RawQuery)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Path == "/api/v1/invoices/inv2" {
+				w.Write([]byte(`{"data": {"id": "inv2", "invitations": []}}`))
+				return
+			}
+			w.Write([]byte(`{"data": {"id": "inv1", "invitations": [
+				{"id": "i1", "client_contact_id": "cc1", "key": "KEY1", "link": "https://portal.example.com/client/invoice/KEY1", "sent_date": "2024-03-01 10:00:00", "viewed_date": ""},
+				{"id": "i2", "client_contact_id": "cc2", "key": "KEY2"}
+			]}}`))
+		case "/api/v1/invoice/KEY1/download":
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Write([]byte("%PDF-1.4"))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	pdf, err := client.Invoices.DownloadByID(context.Background(), "inv1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(pdf) != "%PDF-1.4" {
+		t.Errorf("unexpected PDF content %q", pdf)
+	}
+
+	if _, err := client.Invoices.DownloadByID(context.Background(), "inv2"); !errors.Is(err, ErrNoInvitations) {
+		t.Errorf("expected ErrNoInvitations, got %v", err)
+	}
+}
+
+func TestInvoiceInvitationsDecode(t *testing.T) {
+	var invoice Invoice
+	body := `{"id": "inv1", "invitations": [{"id": "i1", "client_contact_id": "cc1", "key": "KEY1", "link": "https://portal.example.com/client/invoice/KEY1", "sent_date": "2024-03-01 10:00:00", "viewed_date": "2024-03-02 09:30:00"}]}`
+	if err := json.Unmarshal([]byte(body), &invoice); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := Invitation{
+		ID:              "i1",
+		ClientContactID: "cc1",
+		Key:             "KEY1",
+		Link:            "https://portal.example.com/client/invoice/KEY1",
+		SentDate:        "2024-03-01 10:00:00",
+		ViewedDate:      "2024-03-02 09:30:00",
+	}
+	if len(invoice.Invitations) != 1 || invoice.Invitations[0] != want {
+		t.Errorf("unexpected invitations: %+v", invoice.Invitations)
+	}
+}
+
+func TestInvoicePortalLink(t *testing.T) {
+	invoice := &Invoice{ID: "inv1", Invitations: []Invitation{{Key: "KEY1"}, {Key: "KEY2"}}}
+
+	tests := []struct {
+		baseURL string
+		want    string
+	}{
+		{"https://invoicing.example.com", "https://invoicing.example.com/client/invoice/KEY1"},
+		{"https://example.com/ninja/", "https://example.com/ninja/client/invoice/KEY1"},
+	}
+	for _, tt := range tests {
+		got, err := invoice.PortalLink(tt.baseURL)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.baseURL, err)
+		} else if got != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.baseURL, tt.want, got)
+		}
+	}
+
+	if _, err := invoice.PortalLink("invoicing.example.com"); err == nil {
+		t.Error("expected an error for a base URL without a scheme")
+	}
+	if _, err := (&Invoice{ID: "inv2"}).PortalLink("https://invoicing.example.com"); !errors.Is(err, ErrNoInvitations) {
+		t.Errorf("expected ErrNoInvitations, got %v", err)
+	}
+}
+
+func TestInvoicesServiceNextNumber(t *testing.T) {
+	number := "INV-0042"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/api/v1/invoices/create" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"data": {"id": "", "number": %q, "status_id": "1"}}`, number)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	got, err := client.Invoices.NextNumber(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "INV-0042" {
+		t.Errorf("expected INV-0042, got %q", got)
+	}
+
+	number = ""
+	if _, err := client.Invoices.NextNumber(context.Background()); !errors.Is(err, ErrNoNextNumber) {
+		t.Errorf("expected ErrNoNextNumber for a blank invoice without a number, got %v", err)
+	}
+}
+
+func TestInvoicesServiceGetWith(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/invoices/inv1" {
+			t.Errorf("expected path /api/v1/invoices/inv1, got %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("include"); got != "payments,invitations" {
+			t.Errorf("expected include=payments,invitations, got %q", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {
+			"id": "inv1",
+			"payments": [{"id": "pay1", "amount": 50}],
+			"invitations": [{"id": "i1", "key": "abc"}]
+		}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	invoice, err := client.Invoices.GetWith(context.Background(), "inv1", "payments", "invitations")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(invoice.Payments) != 1 || invoice.Payments[0].ID != "pay1" || invoice.Payments[0].Amount != 50 {
+		t.Errorf("unexpected payments: %+v", invoice.Payments)
+	}
+	if len(invoice.Invitations) != 1 || invoice.Invitations[0].Key != "abc" {
+		t.Errorf("unexpected invitations: %+v", invoice.Invitations)
+	}
+}
+
+func TestGetWithoutIncludeSendsNoQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawQuery != "" {
+			t.Errorf("expected no query for %s, got %q", r.URL.Path, r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "x1"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	if _, err := client.Invoices.Get(ctx, "x1"); err != nil {
+		t.Errorf("invoices: %v", err)
+	}
+	if _, err := client.Clients.GetWith(ctx, "x1"); err != nil {
+		t.Errorf("clients: %v", err)
+	}
+	if _, err := client.Payments.Get(ctx, "x1"); err != nil {
+		t.Errorf("payments: %v", err)
+	}
+	if _, err := client.Credits.GetWith(ctx, "x1"); err != nil {
+		t.Errorf("credits: %v", err)
+	}
+}
+
+func TestEntityDocuments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("include"); got != "documents" {
+			t.Errorf("expected include=documents for %s, got %q", r.URL.Path, got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/empty") {
+			w.Write([]byte(`{"data": {"id": "empty", "documents": []}}`))
+			return
+		}
+		w.Write([]byte(`{"data": {"id": "x1", "documents": [
+			{"id": "doc1", "name": "contract.pdf", "type": "pdf", "size": 20480, "url": "https://example.com/documents/doc1", "is_public": true},
+			{"id": "doc2", "name": "logo.png", "type": "png", "size": 512, "width": 64, "height": 64}
+		]}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		documents func(id string) ([]Document, error)
+	}{
+		{"clients", func(id string) ([]Document, error) { return client.Clients.Documents(ctx, id) }},
+		{"invoices", func(id string) ([]Document, error) { return client.Invoices.Documents(ctx, id) }},
+		{"payments", func(id string) ([]Document, error) { return client.Payments.Documents(ctx, id) }},
+		{"credits", func(id string) ([]Document, error) { return client.Credits.Documents(ctx, id) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			docs, err := tt.documents("x1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(docs) != 2 {
+				t.Fatalf("expected 2 documents, got %d", len(docs))
+			}
+			if docs[0].ID != "doc1" || docs[0].Name != "contract.pdf" || docs[0].Size != 20480 || !docs[0].IsPublic {
+				t.Errorf("unexpected first document: %+v", docs[0])
+			}
+			if docs[1].Type != "png" || docs[1].Width != 64 || docs[1].Height != 64 {
+				t.Errorf("unexpected second document: %+v", docs[1])
+			}
+
+			docs, err = tt.documents("empty")
+			if err != nil || len(docs) != 0 {
+				t.Errorf("expected no documents, got %+v, %v", docs, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package invoiceninja
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// jobPollInterval is the wait between job status checks when WaitForJob is
+// given no interval.
+var jobPollInterval = 2 * time.Second
+
+// ErrJobFailed is returned by WaitForJob when the server reports that the
+// background job failed.
+var ErrJobFailed = errors.New("background job failed")
+
+// JobPendingError is returned when the server accepts a request with
+// 202 Accepted and a job hash instead of the result, as it does for large
+// exports and downloads. Pass Hash to Client.WaitForJob to get the result.
+type JobPendingError struct {
+	Hash    string
+	Message string
+}
+
+func (e *JobPendingError) Error() string {
+	return fmt.Sprintf("request is running as background job %s; use WaitForJob to get the result", e.Hash)
+}
+
+// jobStatus is the part of a job response used to tell a pending, failed or
+// finished job apart.
+type jobStatus struct {
+	Hash    string `json:"hash"`
+	JobHash string `json:"job_hash"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// parseJobStatus decodes body as a job response with codec. It returns false
+// if body is not a JSON object.
+func parseJobStatus(codec Codec, body []byte) (jobStatus, bool) {
+	var st jobStatus
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || trimmed[0] != '{' || codec.Unmarshal(trimmed, &st) != nil {
+		return jobStatus{}, false
+	}
+	if st.Hash == "" {
+		st.Hash = st.JobHash
+	}
+	return st, true
+}
+
+// pendingJob returns a *JobPendingError if resp is a 202 Accepted naming a
+// background job, and nil otherwise.
+func pendingJob(codec Codec, resp *rawResponse) *JobPendingError {
+	if resp.statusCode != http.StatusAccepted {
+		return nil
+	}
+	st, ok := parseJobStatus(codec, resp.body)
+	if !ok || st.Hash == "" {
+		return nil
+	}
+	return &JobPendingError{Hash: st.Hash, Message: st.Message}
+}
+
+// WaitForJob polls /jobs/{jobHash} every poll (two seconds if poll is not
+// positive) until the background job finishes, fails or ctx is done, so
+// callers should pass a context with a deadline.
+//
+// While the job runs the server answers 202 Accepted or reports a status of
+// "pending", "queued", "running" or "processing". Once it is done the response
+// body is returned as is: the payload itself, such as a CSV file, or JSON
+// holding a download URL. Since it may be a file, it is limited by
+// WithMaxDownloadBytes rather than WithMaxResponseBytes. A failed job is
+// reported as an error matching ErrJobFailed.
+func (c *Client) WaitForJob(ctx context.Context, jobHash string, poll time.Duration) ([]byte, error) {
+	if jobHash == "" {
+		return nil, fmt.Errorf("no job hash to wait for")
+	}
+	if poll <= 0 {
+		poll = jobPollInterval
+	}
+
+	rawURL := c.BaseURL() + c.apiPath("/jobs/%s", jobHash)
+	for {
+		resp, err := c.sendLimited(ctx, "GET", rawURL, nil, c.maxDownloadBytes, WithHeader("Accept", "application/json, */*"))
+		if err != nil {
+			return nil, err
+		}
+		if resp.statusCode >= 400 {
+			return nil, parseAPIError(c.jsonCodec(), resp.statusCode, resp.header, resp.body)
+		}
+
+		if resp.statusCode != http.StatusAccepted {
+			st, _ := parseJobStatus(c.jsonCodec(), resp.body)
+			switch strings.ToLower(st.Status) {
+			case "pending", "queued", "running", "processing":
+			case "failed", "error":
+				if st.Message != "" {
+					return nil, fmt.Errorf("job %s: %w: %s", jobHash, ErrJobFailed, st.Message)
+				}
+				return nil, fmt.Errorf("job %s: %w", jobHash, ErrJobFailed)
+			default:
+				return resp.body, nil
+			}
+		}
+
+		select {
+		case <-c.timeSource().After(poll):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
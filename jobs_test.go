@@ -0,0 +1,158 @@
+package invoiceninja
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClientWaitForJob(t *testing.T) {
+	var polls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/api/v1/jobs/abc123" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		polls++
+		switch polls {
+		case 1:
+			w.WriteHeader(http.StatusAccepted)
+		case 2:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"status": "processing"}`))
+		default:
+			w.Header().Set("Content-Type", "text/csv")
+			w.Write([]byte("number,amount\nINV-1,100\n"))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	data, err := client.WaitForJob(context.Background(), "abc123", time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != "number,amount\nINV-1,100\n" {
+		t.Errorf("unexpected payload: %q", data)
+	}
+	if polls != 3 {
+		t.Errorf("expected 3 polls, got %d", polls)
+	}
+}
+
+func TestClientWaitForJobDownloadLimit(t *testing.T) {
+	payload := strings.Repeat("number,amount\nINV-1,100\n", 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write([]byte(payload))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithMaxResponseBytes(10))
+	if data, err := client.WaitForJob(ctx, "abc123", time.Millisecond); err != nil || string(data) != payload {
+		t.Errorf("expected the payload despite WithMaxResponseBytes, got %d bytes, %v", len(data), err)
+	}
+
+	client = NewClient("test-token", WithBaseURL(server.URL), WithMaxDownloadBytes(10))
+	if _, err := client.WaitForJob(ctx, "abc123", time.Millisecond); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("expected ErrResponseTooLarge, got %v", err)
+	}
+}
+
+func TestClientWaitForJobFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/jobs/failed":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"status": "failed", "message": "out of memory"}`))
+		case "/api/v1/jobs/slow":
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "job not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	if _, err := client.WaitForJob(ctx, "failed", time.Millisecond); !errors.Is(err, ErrJobFailed) {
+		t.Errorf("expected ErrJobFailed, got %v", err)
+	}
+
+	if _, err := client.WaitForJob(ctx, "unknown", time.Millisecond); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.WaitForJob(timeoutCtx, "slow", time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestRequestReturnsJobPendingError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"message": "Processing", "hash": "abc123"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	var result map[string]interface{}
+	err := client.Request(context.Background(), "POST", "/api/v1/reports/invoices", map[string]string{}, &result)
+
+	var pending *JobPendingError
+	if !errors.As(err, &pending) {
+		t.Fatalf("expected a *JobPendingError, got %v", err)
+	}
+	if pending.Hash != "abc123" || pending.Message != "Processing" {
+		t.Errorf("unexpected pending job: %+v", pending)
+	}
+}
+
+func TestDownloadsServiceDownloadInvoicesZipWaitsForJob(t *testing.T) {
+	defer func(d time.Duration) { zipPollInterval = d }(zipPollInterval)
+	zipPollInterval = time.Millisecond
+
+	var polls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/invoices/bulk":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"message": "Processing", "job_hash": "zip1"}`))
+		case "/api/v1/jobs/zip1":
+			polls++
+			if polls < 2 {
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+			w.Header().Set("Content-Type", "application/zip")
+			w.Write([]byte("PK\x03\x04archive"))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	data, err := client.Downloads.DownloadInvoicesZip(context.Background(), []string{"inv1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != "PK\x03\x04archive" {
+		t.Errorf("unexpected archive content: %q", data)
+	}
+}
@@ -0,0 +1,52 @@
+package invoiceninja
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DefaultMaxResponseBytes is the largest API response body read by default.
+const DefaultMaxResponseBytes = 64 << 20
+
+// ErrResponseTooLarge is returned when a response body exceeds the limit set
+// with WithMaxResponseBytes or WithMaxDownloadBytes.
+var ErrResponseTooLarge = errors.New("response body too large")
+
+// WithMaxResponseBytes limits how much of an API response body is read, so a
+// misbehaving server cannot exhaust memory. Larger responses fail with an
+// error matching ErrResponseTooLarge. The default is DefaultMaxResponseBytes;
+// n <= 0 removes the limit. PDF and zip downloads and the results of
+// Client.WaitForJob are limited separately by WithMaxDownloadBytes.
+func WithMaxResponseBytes(n int64) ClientOption {
+	return func(c *Client) {
+		c.maxResponseBytes = n
+	}
+}
+
+// WithMaxDownloadBytes limits the size of downloaded PDFs and zip archives,
+// and of the background job results returned by Client.WaitForJob.
+// Larger downloads fail with an error matching ErrResponseTooLarge. By
+// default downloads are not limited; n <= 0 removes the limit.
+func WithMaxDownloadBytes(n int64) ClientOption {
+	return func(c *Client) {
+		c.maxDownloadBytes = n
+	}
+}
+
+// readLimited reads r to the end, failing once more than limit bytes have
+// been read. A limit <= 0 reads everything.
+func readLimited(r io.Reader, limit int64) ([]byte, error) {
+	if limit <= 0 {
+		return io.ReadAll(r)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrResponseTooLarge, limit)
+	}
+	return data, nil
+}
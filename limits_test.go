@@ -0,0 +1,64 @@
+package invoiceninja
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithMaxResponseBytes(t *testing.T) {
+	notes := strings.Repeat("x", 2048)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"data": {"id": "inv1", "public_notes": %q}}`, notes)
+	}))
+	defer server.Close()
+
+	limited := NewClient("test-token", WithBaseURL(server.URL), WithMaxResponseBytes(1024))
+	if _, err := limited.Invoices.Get(context.Background(), "inv1"); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("expected ErrResponseTooLarge, got %v", err)
+	}
+
+	for _, client := range []*Client{
+		NewClient("test-token", WithBaseURL(server.URL)),
+		NewClient("test-token", WithBaseURL(server.URL), WithMaxResponseBytes(0)),
+		limited.With(WithMaxResponseBytes(4096)),
+	} {
+		invoice, err := client.Invoices.Get(context.Background(), "inv1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if invoice.PublicNotes != notes {
+			t.Errorf("expected the full notes, got %d bytes", len(invoice.PublicNotes))
+		}
+	}
+}
+
+func TestWithMaxDownloadBytes(t *testing.T) {
+	pdf := append([]byte("%PDF-1.4\n"), bytes.Repeat([]byte("0"), 4096)...)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write(pdf)
+	}))
+	defer server.Close()
+
+	// Downloads are not subject to the API response limit
+	client := NewClient("test-token", WithBaseURL(server.URL), WithMaxResponseBytes(1024))
+	data, err := client.Downloads.DownloadInvoicePDF(context.Background(), "key")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(data) != len(pdf) {
+		t.Errorf("expected %d bytes, got %d", len(pdf), len(data))
+	}
+
+	client = NewClient("test-token", WithBaseURL(server.URL), WithMaxDownloadBytes(1024))
+	if _, err := client.Downloads.DownloadInvoicePDF(context.Background(), "key"); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("expected ErrResponseTooLarge, got %v", err)
+	}
+}
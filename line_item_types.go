@@ -0,0 +1,63 @@
+package invoiceninja
+
+import "fmt"
+
+// LineItemType distinguishes product, task, fee and expense lines, as carried
+// in LineItem.TypeID. The client portal and PDFs label and group lines by
+// type, so lines billing tracked time must be LineItemTask.
+type LineItemType string
+
+// Line item types defined by Invoice Ninja.
+const (
+	LineItemProduct        LineItemType = "1"
+	LineItemTask           LineItemType = "2"
+	LineItemGatewayFee     LineItemType = "3" // added for an unpaid gateway fee
+	LineItemPaidGatewayFee LineItemType = "4" // gateway fee after payment
+	LineItemLateFee        LineItemType = "5"
+	LineItemExpense        LineItemType = "6"
+)
+
+var lineItemTypeNames = map[LineItemType]string{
+	LineItemProduct:        "product",
+	LineItemTask:           "task",
+	LineItemGatewayFee:     "gateway fee",
+	LineItemPaidGatewayFee: "paid gateway fee",
+	LineItemLateFee:        "late fee",
+	LineItemExpense:        "expense",
+}
+
+// String returns the type name, e.g. "task".
+func (t LineItemType) String() string {
+	if name, ok := lineItemTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("LineItemType(%q)", string(t))
+}
+
+// Valid reports whether t is one of the line item types defined by Invoice
+// Ninja.
+func (t LineItemType) Valid() bool {
+	_, ok := lineItemTypeNames[t]
+	return ok
+}
+
+// Type returns the line item's TypeID as a LineItemType. An empty TypeID is
+// reported as LineItemProduct, which the server assumes.
+func (li *LineItem) Type() LineItemType {
+	if li.TypeID == "" {
+		return LineItemProduct
+	}
+	return LineItemType(li.TypeID)
+}
+
+// SetType sets the line item's TypeID to t. It returns a *ValidationError and
+// leaves TypeID unchanged if t is not a known line item type.
+func (li *LineItem) SetType(t LineItemType) error {
+	if !t.Valid() {
+		verr := &ValidationError{}
+		verr.add("type_id", fmt.Sprintf("%q is not a line item type", string(t)))
+		return verr
+	}
+	li.TypeID = string(t)
+	return nil
+}
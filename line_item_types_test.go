@@ -0,0 +1,58 @@
+package invoiceninja
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestLineItemType(t *testing.T) {
+	tests := []struct {
+		typeID   string
+		expected LineItemType
+		name     string
+	}{
+		{"", LineItemProduct, "product"},
+		{"1", LineItemProduct, "product"},
+		{"2", LineItemTask, "task"},
+		{"3", LineItemGatewayFee, "gateway fee"},
+		{"4", LineItemPaidGatewayFee, "paid gateway fee"},
+		{"5", LineItemLateFee, "late fee"},
+		{"6", LineItemExpense, "expense"},
+		{"9", "9", `LineItemType("9")`},
+	}
+
+	for _, tt := range tests {
+		item := &LineItem{TypeID: tt.typeID}
+		if got := item.Type(); got != tt.expected {
+			t.Errorf("TypeID %q: expected %q, got %q", tt.typeID, tt.expected, got)
+		}
+		if got := item.Type().String(); got != tt.name {
+			t.Errorf("TypeID %q: expected name %q, got %q", tt.typeID, tt.name, got)
+		}
+	}
+}
+
+func TestLineItemSetType(t *testing.T) {
+	item := LineItem{Notes: "Design review", Quantity: 2.5, Cost: 120}
+	if err := item.SetType(LineItemTask); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	body, err := json.Marshal(item)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var sent map[string]interface{}
+	json.Unmarshal(body, &sent)
+	if sent["type_id"] != "2" {
+		t.Errorf("expected type_id \"2\" on the wire, got %v", sent["type_id"])
+	}
+
+	got := fieldNames(t, item.SetType("task"))
+	if len(got) != 1 || got[0] != "type_id" {
+		t.Errorf("unexpected fields: %v", got)
+	}
+	if item.Type() != LineItemTask {
+		t.Errorf("expected an invalid type to leave TypeID unchanged, got %q", item.TypeID)
+	}
+}
@@ -0,0 +1,110 @@
+package invoiceninja
+
+import (
+	"strings"
+	"time"
+)
+
+// MetricsObserver receives the outcome of every API request, e.g. to feed
+// request-rate counters and latency histograms. The SDK ships no adapter, so
+// it does not depend on a metrics library; one for Prometheus takes a few
+// lines:
+//
+//	type promObserver struct{ latency *prometheus.HistogramVec }
+//
+//	func (o promObserver) ObserveRequest(method, path string, status int, dur time.Duration) {
+//		o.latency.WithLabelValues(method, path, strconv.Itoa(status)).Observe(dur.Seconds())
+//	}
+//
+//	latency := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+//		Name: "invoiceninja_request_duration_seconds",
+//		Help: "Invoice Ninja API request latency.",
+//	}, []string{"method", "path", "status"})
+//	prometheus.MustRegister(latency)
+//
+//	client := invoiceninja.NewClient(token, invoiceninja.WithMetrics(promObserver{latency}))
+//
+// The histogram's count doubles as the request counter.
+type MetricsObserver interface {
+	// ObserveRequest is called once a request completes. path is the
+	// endpoint as returned by NormalizePath, such as
+	// "/api/v1/invoices/{id}", so it is safe to use as a label.
+	// status is the HTTP status code, or 0 if no response was received.
+	// It is called concurrently by concurrent requests.
+	ObserveRequest(method, path string, status int, dur time.Duration)
+}
+
+// WithMetrics sets an observer notified of the method, endpoint, status and
+// duration of every API request made through the services, Request and Do.
+// Each attempt of a retried request is observed; file downloads and uploads
+// are not.
+func WithMetrics(observer MetricsObserver) ClientOption {
+	return func(c *Client) {
+		c.metrics = observer
+	}
+}
+
+// observeRequest reports a request that started at start to the metrics
+// observer, if any. resp is nil if no response was received.
+func (c *Client) observeRequest(method, path string, resp *rawResponse, start time.Time) {
+	if c.metrics == nil {
+		return
+	}
+	status := 0
+	if resp != nil {
+		status = resp.statusCode
+	}
+	c.metrics.ObserveRequest(method, NormalizePath(path), status, time.Since(start))
+}
+
+// NormalizePath strips the query from an API request path and replaces
+// entity IDs with "{id}", so the result is low-cardinality and safe to use as
+// a metric label or span name. Segments made only of lowercase letters and
+// underscores, such as "invoices", "bulk" or "download_e_invoice", are
+// endpoint names and are kept, as are API versions such as "v1"; all others
+// are taken to be hashed IDs, invitation keys or job hashes.
+//
+//	NormalizePath("/api/v1/invoices/VolejRejNm/delivery_note?t=1")
+//	// "/api/v1/invoices/{id}/delivery_note"
+func NormalizePath(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return "/"
+	}
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if !isEndpointSegment(segment) && !isVersionSegment(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// isEndpointSegment reports whether a path segment looks like a static route
+// name.
+func isEndpointSegment(segment string) bool {
+	if segment == "" {
+		return false
+	}
+	for _, r := range segment {
+		if (r < 'a' || r > 'z') && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// isVersionSegment reports whether a path segment is an API version such as
+// "v1".
+func isVersionSegment(segment string) bool {
+	if len(segment) < 2 || segment[0] != 'v' {
+		return false
+	}
+	for _, r := range segment[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
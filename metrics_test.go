@@ -0,0 +1,104 @@
+package invoiceninja
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingObserver records every observed request.
+type recordingObserver struct {
+	mu       sync.Mutex
+	observed []observedRequest
+}
+
+type observedRequest struct {
+	method, path string
+	status       int
+	dur          time.Duration
+}
+
+func (o *recordingObserver) ObserveRequest(method, path string, status int, dur time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.observed = append(o.observed, observedRequest{method, path, status, dur})
+}
+
+func TestWithMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/invoices/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "not found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "VolejRejNm"}}`))
+	}))
+	defer server.Close()
+
+	observer := &recordingObserver{}
+	client := NewClient("test-token", WithBaseURL(server.URL), WithMetrics(observer))
+	ctx := context.Background()
+
+	if _, err := client.Invoices.Get(ctx, "VolejRejNm"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Invoices.Get(ctx, "missing"); err == nil {
+		t.Fatal("expected an error for the missing invoice")
+	}
+	if _, err := client.Invoices.Email(ctx, "Wpmbk5ezJn"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := client.Request(ctx, "GET", "/api/v1/clients?per_page=5", nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []observedRequest{
+		{method: "GET", path: "/api/v1/invoices/{id}", status: http.StatusOK},
+		{method: "GET", path: "/api/v1/invoices/missing", status: http.StatusNotFound},
+		{method: "POST", path: "/api/v1/invoices/bulk", status: http.StatusOK},
+		{method: "GET", path: "/api/v1/clients", status: http.StatusOK},
+	}
+	if len(observer.observed) != len(want) {
+		t.Fatalf("expected %d observations, got %+v", len(want), observer.observed)
+	}
+	for i, got := range observer.observed {
+		if got.method != want[i].method || got.path != want[i].path || got.status != want[i].status {
+			t.Errorf("observation %d: expected %+v, got %+v", i, want[i], got)
+		}
+		if got.dur <= 0 {
+			t.Errorf("observation %d: expected a positive duration, got %v", i, got.dur)
+		}
+	}
+
+	client.SetBaseURL("http://127.0.0.1:1")
+	client.With().Invoices.Get(ctx, "VolejRejNm")
+	if last := observer.observed[len(observer.observed)-1]; last.status != 0 {
+		t.Errorf("expected status 0 without a response, got %+v", last)
+	}
+}
+
+func TestNormalizePath(t *testing.T) {
+	tests := map[string]string{
+		"":                        "/",
+		"/":                       "/",
+		"/?page=2":                "/",
+		"/api/v1/invoices":        "/api/v1/invoices",
+		"/api/v1/invoices/bulk":   "/api/v1/invoices/bulk",
+		"/api/v1/invoices/create": "/api/v1/invoices/create",
+		"/api/v1/invoices/VolejRejNm?include=payments":  "/api/v1/invoices/{id}",
+		"/api/v1/invoices/VolejRejNm/delivery_note":     "/api/v1/invoices/{id}/delivery_note",
+		"/api/v1/invoice/a1b2c3d4e5/download_e_invoice": "/api/v1/invoice/{id}/download_e_invoice",
+		"/api/v1/clients/Wpmbk5ezJn/VolejRejNm/merge":   "/api/v1/clients/{id}/{id}/merge",
+		"/api/v1/jobs/9f8e7d":                           "/api/v1/jobs/{id}",
+		"/api/v2/payments/Opnel5aKBz":                   "/api/v2/payments/{id}",
+	}
+	for in, want := range tests {
+		if got := NormalizePath(in); got != want {
+			t.Errorf("NormalizePath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
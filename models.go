@@ -10,6 +10,7 @@ type Payment struct {
 	ClientContactID    string           `json:"client_contact_id,omitempty"`
 	UserID             string           `json:"user_id,omitempty"`
 	TypeID             string           `json:"type_id,omitempty"`
+	StatusID           string           `json:"status_id,omitempty"`
 	Date               string           `json:"date,omitempty"`
 	TransactionRef     string           `json:"transaction_reference,omitempty"`
 	AssignedUserID     string           `json:"assigned_user_id,omitempty"`
@@ -33,6 +34,7 @@ type Payment struct {
 	Paymentables       []Paymentable    `json:"paymentables,omitempty"`
 	Invoices           []PaymentInvoice `json:"invoices,omitempty"`
 	Credits            []PaymentCredit  `json:"credits,omitempty"`
+	Documents          []Document       `json:"documents,omitempty"`
 }
 
 // PaymentRequest represents a request to create or update a payment.
@@ -74,45 +76,61 @@ type Paymentable struct {
 	CreatedAt int64   `json:"created_at,omitempty"`
 }
 
-// Invoice represents an invoice in Invoice Ninja.
+// Invoice represents an invoice in Invoice Ninja. IsAmountDiscount and
+// UsesInclusiveTaxes are pointers so that an update can turn them off; nil
+// leaves the server's value unchanged.
 type Invoice struct {
-	ID             string     `json:"id,omitempty"`
-	UserID         string     `json:"user_id,omitempty"`
-	AssignedUserID string     `json:"assigned_user_id,omitempty"`
-	ClientID       string     `json:"client_id,omitempty"`
-	StatusID       string     `json:"status_id,omitempty"`
-	Number         string     `json:"number,omitempty"`
-	PONumber       string     `json:"po_number,omitempty"`
-	Terms          string     `json:"terms,omitempty"`
-	PublicNotes    string     `json:"public_notes,omitempty"`
-	PrivateNotes   string     `json:"private_notes,omitempty"`
-	Footer         string     `json:"footer,omitempty"`
-	CustomValue1   string     `json:"custom_value1,omitempty"`
-	CustomValue2   string     `json:"custom_value2,omitempty"`
-	CustomValue3   string     `json:"custom_value3,omitempty"`
-	CustomValue4   string     `json:"custom_value4,omitempty"`
-	TaxName1       string     `json:"tax_name1,omitempty"`
-	TaxName2       string     `json:"tax_name2,omitempty"`
-	TaxName3       string     `json:"tax_name3,omitempty"`
-	TaxRate1       float64    `json:"tax_rate1,omitempty"`
-	TaxRate2       float64    `json:"tax_rate2,omitempty"`
-	TaxRate3       float64    `json:"tax_rate3,omitempty"`
-	TotalTaxes     float64    `json:"total_taxes,omitempty"`
-	Amount         float64    `json:"amount,omitempty"`
-	Balance        float64    `json:"balance,omitempty"`
-	PaidToDate     float64    `json:"paid_to_date,omitempty"`
-	Discount       float64    `json:"discount,omitempty"`
-	PartialDueDate string     `json:"partial_due_date,omitempty"`
-	DueDate        string     `json:"due_date,omitempty"`
-	Date           string     `json:"date,omitempty"`
-	LineItems      []LineItem `json:"line_items,omitempty"`
-	IsDeleted      bool       `json:"is_deleted,omitempty"`
-	UpdatedAt      int64      `json:"updated_at,omitempty"`
-	ArchivedAt     int64      `json:"archived_at,omitempty"`
-	CreatedAt      int64      `json:"created_at,omitempty"`
-}
-
-// LineItem represents a line item on an invoice.
+	ID                 string       `json:"id,omitempty"`
+	UserID             string       `json:"user_id,omitempty"`
+	AssignedUserID     string       `json:"assigned_user_id,omitempty"`
+	ClientID           string       `json:"client_id,omitempty"`
+	StatusID           string       `json:"status_id,omitempty"`
+	RecurringID        string       `json:"recurring_id,omitempty"`
+	Number             string       `json:"number,omitempty"`
+	DesignID           string       `json:"design_id,omitempty"`
+	PONumber           string       `json:"po_number,omitempty"`
+	Terms              string       `json:"terms,omitempty"`
+	PublicNotes        string       `json:"public_notes,omitempty"`
+	PrivateNotes       string       `json:"private_notes,omitempty"`
+	Footer             string       `json:"footer,omitempty"`
+	CustomValue1       string       `json:"custom_value1,omitempty"`
+	CustomValue2       string       `json:"custom_value2,omitempty"`
+	CustomValue3       string       `json:"custom_value3,omitempty"`
+	CustomValue4       string       `json:"custom_value4,omitempty"`
+	TaxName1           string       `json:"tax_name1,omitempty"`
+	TaxName2           string       `json:"tax_name2,omitempty"`
+	TaxName3           string       `json:"tax_name3,omitempty"`
+	TaxRate1           float64      `json:"tax_rate1,omitempty"`
+	TaxRate2           float64      `json:"tax_rate2,omitempty"`
+	TaxRate3           float64      `json:"tax_rate3,omitempty"`
+	TotalTaxes         float64      `json:"total_taxes,omitempty"`
+	Amount             float64      `json:"amount,omitempty"`
+	Balance            float64      `json:"balance,omitempty"`
+	PaidToDate         float64      `json:"paid_to_date,omitempty"`
+	Discount           float64      `json:"discount,omitempty"`
+	IsAmountDiscount   *bool        `json:"is_amount_discount,omitempty"`
+	UsesInclusiveTaxes *bool        `json:"uses_inclusive_taxes,omitempty"`
+	PartialDueDate     string       `json:"partial_due_date,omitempty"`
+	DueDate            string       `json:"due_date,omitempty"`
+	Date               string       `json:"date,omitempty"`
+	LastSentDate       string       `json:"last_sent_date,omitempty"`
+	NextSendDate       string       `json:"next_send_date,omitempty"`
+	Reminder1Sent      string       `json:"reminder1_sent,omitempty"`
+	Reminder2Sent      string       `json:"reminder2_sent,omitempty"`
+	Reminder3Sent      string       `json:"reminder3_sent,omitempty"`
+	ReminderLastSent   string       `json:"reminder_last_sent,omitempty"`
+	LineItems          []LineItem   `json:"line_items,omitempty"`
+	Invitations        []Invitation `json:"invitations,omitempty"`
+	Payments           []Payment    `json:"payments,omitempty"`
+	Documents          []Document   `json:"documents,omitempty"`
+	IsDeleted          bool         `json:"is_deleted,omitempty"`
+	UpdatedAt          int64        `json:"updated_at,omitempty"`
+	ArchivedAt         int64        `json:"archived_at,omitempty"`
+	CreatedAt          int64        `json:"created_at,omitempty"`
+}
+
+// LineItem represents a line item on an invoice. TypeID holds a
+// LineItemType; use Type and SetType to read and set it.
 type LineItem struct {
 	Quantity     float64 `json:"quantity,omitempty"`
 	Cost         float64 `json:"cost,omitempty"`
@@ -133,6 +151,23 @@ type LineItem struct {
 	TypeID       string  `json:"type_id,omitempty"`
 }
 
+// Invitation links an invoice, quote or credit to one of the client's
+// contacts. Its Key identifies the document in PDF downloads and Link opens
+// it in the client portal.
+type Invitation struct {
+	ID              string `json:"id,omitempty"`
+	ClientContactID string `json:"client_contact_id,omitempty"`
+	Key             string `json:"key,omitempty"`
+	Link            string `json:"link,omitempty"`
+	SentDate        string `json:"sent_date,omitempty"`
+	ViewedDate      string `json:"viewed_date,omitempty"`
+	OpenedDate      string `json:"opened_date,omitempty"`
+	EmailStatus     string `json:"email_status,omitempty"`
+	CreatedAt       int64  `json:"created_at,omitempty"`
+	UpdatedAt       int64  `json:"updated_at,omitempty"`
+	ArchivedAt      int64  `json:"archived_at,omitempty"`
+}
+
 // INClient represents a client in Invoice Ninja.
 type INClient struct {
 	ID               string          `json:"id,omitempty"`
@@ -168,11 +203,33 @@ type INClient struct {
 	ShippingCountry  string          `json:"shipping_country_id,omitempty"`
 	IsDeleted        bool            `json:"is_deleted,omitempty"`
 	Contacts         []ClientContact `json:"contacts,omitempty"`
+	Documents        []Document      `json:"documents,omitempty"`
 	UpdatedAt        int64           `json:"updated_at,omitempty"`
 	ArchivedAt       int64           `json:"archived_at,omitempty"`
 	CreatedAt        int64           `json:"created_at,omitempty"`
 }
 
+// Document is a file attached to an entity, such as one added with
+// UploadsService. URL downloads it; Type is its file extension, e.g. "pdf".
+type Document struct {
+	ID         string `json:"id,omitempty"`
+	UserID     string `json:"user_id,omitempty"`
+	Name       string `json:"name,omitempty"`
+	URL        string `json:"url,omitempty"`
+	Preview    string `json:"preview,omitempty"`
+	Type       string `json:"type,omitempty"`
+	Hash       string `json:"hash,omitempty"`
+	Size       int64  `json:"size,omitempty"`
+	Width      int    `json:"width,omitempty"`
+	Height     int    `json:"height,omitempty"`
+	IsDefault  bool   `json:"is_default,omitempty"`
+	IsPublic   bool   `json:"is_public,omitempty"`
+	IsDeleted  bool   `json:"is_deleted,omitempty"`
+	CreatedAt  int64  `json:"created_at,omitempty"`
+	UpdatedAt  int64  `json:"updated_at,omitempty"`
+	ArchivedAt int64  `json:"archived_at,omitempty"`
+}
+
 // ClientContact represents a contact for a client.
 type ClientContact struct {
 	ID           string `json:"id,omitempty"`
@@ -202,6 +259,28 @@ type Pagination struct {
 	Links       *Links `json:"links,omitempty"`
 }
 
+// HasNext reports whether there is a page after the current one. When the
+// server omits the page count, the next link is consulted instead.
+func (p Pagination) HasNext() bool {
+	if p.TotalPages == 0 {
+		return p.Links != nil && p.Links.Next != ""
+	}
+	return p.CurrentPage < p.TotalPages
+}
+
+// HasPrev reports whether there is a page before the current one.
+func (p Pagination) HasPrev() bool {
+	return p.CurrentPage > 1
+}
+
+// NextPage returns the number of the next page, or 0 on the last page.
+func (p Pagination) NextPage() int {
+	if !p.HasNext() {
+		return 0
+	}
+	return p.CurrentPage + 1
+}
+
 // Links contains pagination links.
 type Links struct {
 	Next     string `json:"next,omitempty"`
@@ -214,6 +293,12 @@ type ListResponse[T any] struct {
 	Meta Meta `json:"meta,omitempty"`
 }
 
+// HasNextPage reports whether more results follow this page; pass
+// Meta.Pagination.NextPage() as the Page option to fetch them.
+func (r ListResponse[T]) HasNextPage() bool {
+	return r.Meta.Pagination.HasNext()
+}
+
 // SingleResponse is a generic response structure for single entity endpoints.
 type SingleResponse[T any] struct {
 	Data T `json:"data"`
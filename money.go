@@ -0,0 +1,85 @@
+package invoiceninja
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// currencyFormat describes how amounts in a currency are written.
+type currencyFormat struct {
+	symbol      string
+	decimals    int
+	thousands   string
+	decimal     string
+	symbolAfter bool
+}
+
+// currencyFormats covers the major currencies Invoice Ninja supports, keyed
+// by ISO 4217 code. The conventions follow Invoice Ninja's currency table.
+var currencyFormats = map[string]currencyFormat{
+	"USD": {symbol: "$", decimals: 2, thousands: ",", decimal: "."},
+	"EUR": {symbol: "€", decimals: 2, thousands: ".", decimal: ","},
+	"GBP": {symbol: "£", decimals: 2, thousands: ",", decimal: "."},
+	"CAD": {symbol: "C$", decimals: 2, thousands: ",", decimal: "."},
+	"AUD": {symbol: "A$", decimals: 2, thousands: ",", decimal: "."},
+	"NZD": {symbol: "NZ$", decimals: 2, thousands: ",", decimal: "."},
+	"CHF": {symbol: "CHF ", decimals: 2, thousands: "'", decimal: "."},
+	"JPY": {symbol: "¥", decimals: 0, thousands: ",", decimal: "."},
+	"CNY": {symbol: "¥", decimals: 2, thousands: ",", decimal: "."},
+	"KRW": {symbol: "₩", decimals: 0, thousands: ",", decimal: "."},
+	"INR": {symbol: "₹", decimals: 2, thousands: ",", decimal: "."},
+	"SEK": {symbol: " kr", decimals: 2, thousands: " ", decimal: ",", symbolAfter: true},
+	"NOK": {symbol: " kr", decimals: 2, thousands: " ", decimal: ",", symbolAfter: true},
+	"DKK": {symbol: " kr", decimals: 2, thousands: ".", decimal: ",", symbolAfter: true},
+	"PLN": {symbol: " zł", decimals: 2, thousands: " ", decimal: ",", symbolAfter: true},
+	"BRL": {symbol: "R$", decimals: 2, thousands: ".", decimal: ","},
+	"MXN": {symbol: "$", decimals: 2, thousands: ",", decimal: "."},
+	"ZAR": {symbol: "R", decimals: 2, thousands: ",", decimal: "."},
+	"SGD": {symbol: "S$", decimals: 2, thousands: ",", decimal: "."},
+	"HKD": {symbol: "HK$", decimals: 2, thousands: ",", decimal: "."},
+}
+
+// FormatMoney formats amount in the currency with the given ISO 4217 code,
+// using its symbol, number of decimal places and separators:
+//
+//	FormatMoney(1234.5, "USD") // "$1,234.50"
+//	FormatMoney(1234.5, "EUR") // "€1.234,50"
+//	FormatMoney(1234.5, "JPY") // "¥1,235"
+//
+// Codes are case-insensitive. Unknown codes are written before the amount
+// with two decimal places, e.g. "XYZ 1,234.50".
+func FormatMoney(amount float64, currencyCode string) string {
+	code := strings.ToUpper(strings.TrimSpace(currencyCode))
+	f, ok := currencyFormats[code]
+	if !ok {
+		f = currencyFormat{symbol: code + " ", decimals: 2, thousands: ",", decimal: "."}
+	}
+
+	// Round half away from zero, as invoices do, rather than to even
+	scale := math.Pow(10, float64(f.decimals))
+	digits := strconv.FormatFloat(math.Round(math.Abs(amount)*scale)/scale, 'f', f.decimals, 64)
+	whole, frac, _ := strings.Cut(digits, ".")
+
+	var b strings.Builder
+	if amount < 0 && strings.Trim(digits, "0.") != "" {
+		b.WriteByte('-')
+	}
+	if !f.symbolAfter {
+		b.WriteString(f.symbol)
+	}
+	for i, d := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(f.thousands)
+		}
+		b.WriteRune(d)
+	}
+	if frac != "" {
+		b.WriteString(f.decimal)
+		b.WriteString(frac)
+	}
+	if f.symbolAfter {
+		b.WriteString(f.symbol)
+	}
+	return b.String()
+}
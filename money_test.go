@@ -0,0 +1,29 @@
+package invoiceninja
+
+import "testing"
+
+func TestFormatMoney(t *testing.T) {
+	tests := []struct {
+		amount float64
+		code   string
+		want   string
+	}{
+		{1234.5, "USD", "$1,234.50"},
+		{0, "USD", "$0.00"},
+		{999.999, "USD", "$1,000.00"},
+		{-1234567.891, "USD", "-$1,234,567.89"},
+		{-0.001, "USD", "$0.00"},
+		{1234.5, "eur", "€1.234,50"},
+		{1234.5, "JPY", "¥1,235"},
+		{1234567.25, "CHF", "CHF 1'234'567.25"},
+		{1234.5, "SEK", "1 234,50 kr"},
+		{-12.3, "PLN", "-12,30 zł"},
+		{1234.5, "XYZ", "XYZ 1,234.50"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatMoney(tt.amount, tt.code); got != tt.want {
+			t.Errorf("FormatMoney(%v, %q) = %q, want %q", tt.amount, tt.code, got, tt.want)
+		}
+	}
+}
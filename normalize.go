@@ -0,0 +1,138 @@
+package invoiceninja
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	// minPhoneDigits is the minimum number of digits accepted in a phone number.
+	minPhoneDigits = 7
+
+	// maxPhoneDigits is the maximum number of digits in an E.164 phone number.
+	maxPhoneDigits = 15
+)
+
+// Normalized returns a copy of the client with whitespace trimmed from all
+// string fields, emails lowercased, phone numbers stripped of formatting
+// characters and VAT numbers uppercased without separators.
+//
+// Emails and phone numbers are checked for a basic shape; if any are invalid,
+// a *ValidationError listing every offending field is returned.
+func (c *INClient) Normalized() (*INClient, error) {
+	n := *c
+	trimStringFields(&n)
+
+	verr := &ValidationError{}
+
+	if n.Phone != "" {
+		phone, ok := normalizePhone(n.Phone)
+		if !ok {
+			verr.add("phone", fmt.Sprintf("invalid phone number %q", n.Phone))
+		}
+		n.Phone = phone
+	}
+
+	if n.VatNumber != "" {
+		vat, ok := normalizeVATNumber(n.VatNumber)
+		if !ok {
+			verr.add("vat_number", fmt.Sprintf("invalid VAT number %q", n.VatNumber))
+		}
+		n.VatNumber = vat
+	}
+
+	if c.Contacts != nil {
+		n.Contacts = make([]ClientContact, len(c.Contacts))
+		copy(n.Contacts, c.Contacts)
+	}
+
+	for i := range n.Contacts {
+		contact := &n.Contacts[i]
+		trimStringFields(contact)
+
+		if contact.Email != "" {
+			contact.Email = strings.ToLower(contact.Email)
+			if !isValidEmail(contact.Email) {
+				verr.add(fmt.Sprintf("contacts[%d].email", i), fmt.Sprintf("invalid email address %q", contact.Email))
+			}
+		}
+
+		if contact.Phone != "" {
+			phone, ok := normalizePhone(contact.Phone)
+			if !ok {
+				verr.add(fmt.Sprintf("contacts[%d].phone", i), fmt.Sprintf("invalid phone number %q", contact.Phone))
+			}
+			contact.Phone = phone
+		}
+	}
+
+	if err := verr.errOrNil(); err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// trimStringFields trims surrounding whitespace from every string field of the
+// struct pointed to by v.
+func trimStringFields(v interface{}) {
+	rv := reflect.ValueOf(v).Elem()
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Field(i)
+		if field.Kind() == reflect.String && field.CanSet() {
+			field.SetString(strings.TrimSpace(field.String()))
+		}
+	}
+}
+
+// normalizePhone strips common formatting characters from a phone number,
+// keeping a leading "+", and reports whether the result looks like a phone number.
+func normalizePhone(phone string) (string, bool) {
+	var b strings.Builder
+	digits := 0
+
+	for i, r := range phone {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+			digits++
+		case r == '+' && i == 0:
+			b.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+			// Formatting characters are dropped
+		default:
+			return phone, false
+		}
+	}
+
+	return b.String(), digits >= minPhoneDigits && digits <= maxPhoneDigits
+}
+
+// normalizeVATNumber uppercases a VAT number and removes spaces, dots and
+// dashes, reporting whether the result is alphanumeric.
+func normalizeVATNumber(vat string) (string, bool) {
+	vat = strings.ToUpper(vat)
+	vat = strings.NewReplacer(" ", "", ".", "", "-", "").Replace(vat)
+
+	for _, r := range vat {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return vat, false
+		}
+	}
+	return vat, vat != ""
+}
+
+// isValidEmail performs a basic shape check on an email address.
+func isValidEmail(email string) bool {
+	if strings.ContainsAny(email, " \t\r\n") {
+		return false
+	}
+
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok || local == "" || strings.Contains(domain, "@") {
+		return false
+	}
+
+	dot := strings.LastIndex(domain, ".")
+	return dot > 0 && dot < len(domain)-1
+}
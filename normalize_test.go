@@ -0,0 +1,146 @@
+package invoiceninja
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestINClientNormalized(t *testing.T) {
+	client := &INClient{
+		Name:      "  Acme Corp  ",
+		Phone:     " +1 (555) 123-4567 ",
+		VatNumber: "de 123.456-789",
+		Contacts: []ClientContact{
+			{FirstName: " Jane ", Email: "  Jane.Smith@Example.COM ", Phone: "555.123.4567"},
+		},
+	}
+
+	n, err := client.Normalized()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n.Name != "Acme Corp" {
+		t.Errorf("expected trimmed name, got %q", n.Name)
+	}
+	if n.Phone != "+15551234567" {
+		t.Errorf("expected normalized phone +15551234567, got %q", n.Phone)
+	}
+	if n.VatNumber != "DE123456789" {
+		t.Errorf("expected normalized VAT DE123456789, got %q", n.VatNumber)
+	}
+	if n.Contacts[0].Email != "jane.smith@example.com" {
+		t.Errorf("expected lowercased email, got %q", n.Contacts[0].Email)
+	}
+	if n.Contacts[0].Phone != "5551234567" {
+		t.Errorf("expected normalized contact phone, got %q", n.Contacts[0].Phone)
+	}
+	if n.Contacts[0].FirstName != "Jane" {
+		t.Errorf("expected trimmed first name, got %q", n.Contacts[0].FirstName)
+	}
+
+	// The original must be left untouched
+	if client.Name != "  Acme Corp  " || client.Contacts[0].Email != "  Jane.Smith@Example.COM " {
+		t.Error("expected original client to be unchanged")
+	}
+}
+
+func TestINClientNormalizedInvalidFields(t *testing.T) {
+	client := &INClient{
+		Name:  "Acme Corp",
+		Phone: "call me",
+		Contacts: []ClientContact{
+			{Email: "valid@example.com"},
+			{Email: "not-an-email"},
+		},
+	}
+
+	_, err := client.Normalized()
+
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+
+	if len(verr.Fields) != 2 {
+		t.Fatalf("expected 2 invalid fields, got %d: %v", len(verr.Fields), verr)
+	}
+	if verr.Fields[0].Field != "phone" {
+		t.Errorf("expected phone to be reported, got %s", verr.Fields[0].Field)
+	}
+	if verr.Fields[1].Field != "contacts[1].email" {
+		t.Errorf("expected contacts[1].email to be reported, got %s", verr.Fields[1].Field)
+	}
+	if !strings.Contains(err.Error(), "contacts[1].email") {
+		t.Errorf("expected error message to mention field, got %q", err.Error())
+	}
+}
+
+func TestIsValidEmail(t *testing.T) {
+	tests := map[string]bool{
+		"jane@example.com":     true,
+		"a.b+c@sub.example.io": true,
+		"jane@example":         false,
+		"@example.com":         false,
+		"jane@@example.com":    false,
+		"jane doe@example.com": false,
+		"jane@example.":        false,
+	}
+
+	for email, expected := range tests {
+		if got := isValidEmail(email); got != expected {
+			t.Errorf("isValidEmail(%q) = %v, want %v", email, got, expected)
+		}
+	}
+}
+
+func TestClientsServiceCreateWithNormalization(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body INClient
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+
+		if body.Contacts[0].Email != "jane@example.com" {
+			t.Errorf("expected normalized email to be sent, got %q", body.Contacts[0].Email)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": body})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithClientNormalization())
+
+	_, err := client.Clients.Create(context.Background(), &INClient{
+		Name:     "Acme",
+		Contacts: []ClientContact{{Email: " Jane@Example.com"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestClientsServiceCreateWithNormalizationRejectsInvalid(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not be sent for an invalid client")
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithClientNormalization())
+
+	_, err := client.Clients.Create(context.Background(), &INClient{
+		Name:     "Acme",
+		Contacts: []ClientContact{{Email: "jane.example.com"}},
+	})
+
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+}
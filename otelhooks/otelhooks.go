@@ -0,0 +1,108 @@
+// Package otelhooks builds tracing spans for Invoice Ninja API calls using only
+// the invoiceninja request and response hooks, so the core SDK does not depend
+// on OpenTelemetry.
+//
+// The package defines a minimal Span interface that an OpenTelemetry span can
+// satisfy with a small adapter:
+//
+//	type otelSpan struct{ trace.Span }
+//
+//	func (s otelSpan) SetAttribute(key string, value interface{}) {
+//		s.SetAttributes(attribute.String(key, fmt.Sprint(value)))
+//	}
+//
+//	start := func(ctx context.Context, name string) (context.Context, otelhooks.Span) {
+//		ctx, span := tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+//		return ctx, otelSpan{span}
+//	}
+//
+//	client := invoiceninja.NewClient(token, otelhooks.Options(start)...)
+//
+// Each request produces one span named "<METHOD> <path>" with the attributes
+// listed below. The path is normalized with invoiceninja.NormalizePath, which
+// replaces hashed entity IDs and invitation keys with "{id}" to keep span names
+// and attributes low-cardinality.
+package otelhooks
+
+import (
+	"context"
+	"net/http"
+
+	invoiceninja "github.com/AshkanYarmoradi/go-invoice-ninja"
+)
+
+// Attribute keys set on every span.
+const (
+	// AttrHTTPMethod is the HTTP request method.
+	AttrHTTPMethod = "http.method"
+
+	// AttrURLPath is the request path with IDs normalized to "{id}".
+	AttrURLPath = "url.path"
+
+	// AttrHTTPStatusCode is the HTTP response status code, when a response was received.
+	AttrHTTPStatusCode = "http.status_code"
+
+	// AttrError is true when the request failed or the server returned a 4xx/5xx status.
+	AttrError = "error"
+)
+
+// Span is the subset of a tracing span used by the hooks.
+type Span interface {
+	// SetAttribute records a single attribute on the span.
+	SetAttribute(key string, value interface{})
+
+	// RecordError records a transport error on the span.
+	RecordError(err error)
+
+	// End completes the span.
+	End()
+}
+
+// StartFunc starts a span named name as a child of ctx.
+type StartFunc func(ctx context.Context, name string) (context.Context, Span)
+
+// spanKey is the context key under which the active span is stored.
+type spanKey struct{}
+
+// Options returns the client options that trace every API request with start.
+func Options(start StartFunc) []invoiceninja.ClientOption {
+	return []invoiceninja.ClientOption{
+		invoiceninja.WithRequestHook(RequestHook(start)),
+		invoiceninja.WithResponseHook(ResponseHook()),
+	}
+}
+
+// RequestHook returns a hook that starts a span for each request and stores it
+// in the request context.
+func RequestHook(start StartFunc) invoiceninja.RequestHook {
+	return func(req *http.Request) *http.Request {
+		path := invoiceninja.NormalizePath(req.URL.Path)
+
+		ctx, span := start(req.Context(), req.Method+" "+path)
+		span.SetAttribute(AttrHTTPMethod, req.Method)
+		span.SetAttribute(AttrURLPath, path)
+
+		return req.WithContext(context.WithValue(ctx, spanKey{}, span))
+	}
+}
+
+// ResponseHook returns a hook that records the outcome on the span started by
+// RequestHook and ends it.
+func ResponseHook() invoiceninja.ResponseHook {
+	return func(req *http.Request, resp *http.Response, err error) {
+		span, ok := req.Context().Value(spanKey{}).(Span)
+		if !ok {
+			return
+		}
+		defer span.End()
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetAttribute(AttrError, true)
+			return
+		}
+
+		span.SetAttribute(AttrHTTPStatusCode, resp.StatusCode)
+		span.SetAttribute(AttrError, resp.StatusCode >= http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,119 @@
+package otelhooks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	invoiceninja "github.com/AshkanYarmoradi/go-invoice-ninja"
+)
+
+type fakeSpan struct {
+	name  string
+	attrs map[string]interface{}
+	err   error
+	ended bool
+}
+
+func (s *fakeSpan) SetAttribute(key string, value interface{}) { s.attrs[key] = value }
+func (s *fakeSpan) RecordError(err error)                      { s.err = err }
+func (s *fakeSpan) End()                                       { s.ended = true }
+
+func newRecorder() (StartFunc, *[]*fakeSpan) {
+	var spans []*fakeSpan
+	start := func(ctx context.Context, name string) (context.Context, Span) {
+		span := &fakeSpan{name: name, attrs: make(map[string]interface{})}
+		spans = append(spans, span)
+		return ctx, span
+	}
+	return start, &spans
+}
+
+func TestSpanAttributesOnSuccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "Wpmbk5ezJn"}}`))
+	}))
+	defer server.Close()
+
+	start, spans := newRecorder()
+	client := invoiceninja.NewClient("test-token",
+		append([]invoiceninja.ClientOption{invoiceninja.WithBaseURL(server.URL)}, Options(start)...)...)
+
+	if _, err := client.Invoices.Get(context.Background(), "Wpmbk5ezJn"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(*spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(*spans))
+	}
+
+	span := (*spans)[0]
+	if span.name != "GET /api/v1/invoices/{id}" {
+		t.Errorf("unexpected span name %q", span.name)
+	}
+	if span.attrs[AttrHTTPMethod] != "GET" {
+		t.Errorf("expected method GET, got %v", span.attrs[AttrHTTPMethod])
+	}
+	if span.attrs[AttrURLPath] != "/api/v1/invoices/{id}" {
+		t.Errorf("expected normalized path, got %v", span.attrs[AttrURLPath])
+	}
+	if span.attrs[AttrHTTPStatusCode] != http.StatusOK {
+		t.Errorf("expected status 200, got %v", span.attrs[AttrHTTPStatusCode])
+	}
+	if span.attrs[AttrError] != false {
+		t.Errorf("expected error=false, got %v", span.attrs[AttrError])
+	}
+	if !span.ended {
+		t.Error("expected span to be ended")
+	}
+}
+
+func TestSpanAttributesOnErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	start, spans := newRecorder()
+	client := invoiceninja.NewClient("test-token",
+		append([]invoiceninja.ClientOption{invoiceninja.WithBaseURL(server.URL)}, Options(start)...)...)
+
+	if _, err := client.Payments.Get(context.Background(), "missing1"); err == nil {
+		t.Fatal("expected error")
+	}
+
+	span := (*spans)[0]
+	if span.attrs[AttrHTTPStatusCode] != http.StatusNotFound {
+		t.Errorf("expected status 404, got %v", span.attrs[AttrHTTPStatusCode])
+	}
+	if span.attrs[AttrError] != true {
+		t.Errorf("expected error=true, got %v", span.attrs[AttrError])
+	}
+}
+
+func TestSpanAttributesOnTransportError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	start, spans := newRecorder()
+	client := invoiceninja.NewClient("test-token",
+		append([]invoiceninja.ClientOption{invoiceninja.WithBaseURL(url)}, Options(start)...)...)
+
+	if _, err := client.Clients.List(context.Background(), nil); err == nil {
+		t.Fatal("expected error")
+	}
+
+	span := (*spans)[0]
+	if span.err == nil {
+		t.Error("expected transport error to be recorded")
+	}
+	if _, ok := span.attrs[AttrHTTPStatusCode]; ok {
+		t.Error("expected no status code attribute without a response")
+	}
+	if span.attrs[AttrError] != true || !span.ended {
+		t.Errorf("expected ended span with error=true, got %+v", span)
+	}
+}
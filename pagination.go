@@ -0,0 +1,204 @@
+package invoiceninja
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Iterator pages through a list endpoint, yielding one entity at a time.
+// Pages are fetched lazily as the iterator advances.
+//
+//	it := client.Invoices.Iter(ctx, &invoiceninja.InvoiceListOptions{PerPage: 100})
+//	for it.Next() {
+//		invoice := it.Value()
+//		// ...
+//	}
+//	if err := it.Err(); err != nil {
+//		// handle error
+//	}
+type Iterator[T any] struct {
+	ctx    context.Context
+	client *Client
+	path   string
+	query  url.Values
+	fields *fieldSelection[T]
+
+	page    int
+	items   []T
+	index   int
+	current T
+	done    bool
+	err     error
+}
+
+// PageError is returned by iterators, ListAll and Stream when a page request
+// fails. It matches the underlying error with errors.Is and errors.As.
+type PageError struct {
+	Page int
+	Err  error
+}
+
+func (e *PageError) Error() string {
+	return fmt.Sprintf("failed to fetch page %d: %v", e.Page, e.Err)
+}
+
+// Unwrap returns the error of the page request.
+func (e *PageError) Unwrap() error {
+	return e.Err
+}
+
+// newIterator creates an iterator over path starting at the page in query (or page 1).
+// query is sent with every page request; only its page parameter changes.
+func newIterator[T any](ctx context.Context, c *Client, path string, query url.Values) *Iterator[T] {
+	page := 1
+	if p, err := strconv.Atoi(query.Get("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	return &Iterator[T]{
+		ctx:    ctx,
+		client: c,
+		path:   path,
+		query:  query,
+		page:   page,
+	}
+}
+
+// Next advances the iterator to the next entity, fetching the next page when
+// needed. It returns false when there are no more entities or an error occurred.
+func (it *Iterator[T]) Next() bool {
+	for it.index >= len(it.items) {
+		if it.done || it.err != nil {
+			return false
+		}
+		it.fetch()
+	}
+
+	it.current = it.items[it.index]
+	it.index++
+	return true
+}
+
+// Value returns the current entity.
+func (it *Iterator[T]) Value() T {
+	return it.current
+}
+
+// Err returns the first error encountered while paging, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// fetch retrieves the next page.
+func (it *Iterator[T]) fetch() {
+	if it.items != nil && it.client.pageDelay > 0 {
+		select {
+		case <-it.client.timeSource().After(it.client.pageDelay):
+		case <-it.ctx.Done():
+			it.err = it.ctx.Err()
+			return
+		}
+	}
+
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return
+	}
+
+	q := url.Values{}
+	for k, v := range it.query {
+		q[k] = append([]string(nil), v...)
+	}
+	q.Set("page", strconv.Itoa(it.page))
+
+	var resp ListResponse[T]
+	if err := it.client.listPage(it.ctx, it.path, q, &resp); err != nil {
+		it.err = &PageError{Page: it.page, Err: err}
+		return
+	}
+
+	it.fields.apply(resp.Data)
+	it.items = resp.Data
+	it.index = 0
+
+	// Decide like ListResponse.HasNextPage, on the page actually requested
+	p := resp.Meta.Pagination
+	if p.CurrentPage == 0 {
+		p.CurrentPage = it.page
+	}
+	if len(resp.Data) == 0 || !p.HasNext() {
+		it.done = true
+	}
+	it.page++
+}
+
+// listAll drains an iterator into a slice. On error, the entities fetched so
+// far are returned together with the error.
+func listAll[T any](it *Iterator[T]) ([]T, error) {
+	var all []T
+	for it.Next() {
+		all = append(all, it.Value())
+	}
+	return all, it.Err()
+}
+
+// stream sends the entities of an iterator to the returned channel from a new
+// goroutine, fetching each page once the previous one has been received. Both
+// channels are closed when the iterator is exhausted, a page fails or ctx is
+// done; the error channel then yields the error, if any.
+func stream[T any](ctx context.Context, it *Iterator[T]) (<-chan T, <-chan error) {
+	items := make(chan T)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(items)
+		defer close(errs)
+
+		for it.Next() {
+			select {
+			case items <- it.Value():
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			errs <- err
+		}
+	}()
+
+	return items, errs
+}
+
+// count returns the number of entities a list endpoint has for query, read
+// from the pagination total of a one-item page. An endpoint that does not
+// paginate returns all entities, which are counted instead.
+func count(ctx context.Context, c *Client, path string, query url.Values) (int, error) {
+	q := url.Values{}
+	for k, v := range query {
+		q[k] = v
+	}
+	q.Set("per_page", "1")
+	q.Del("page")
+
+	var resp ListResponse[json.RawMessage]
+	if err := c.listPage(ctx, path, q, &resp); err != nil {
+		return 0, err
+	}
+	if p := resp.Meta.Pagination; p.Total > 0 || p.PerPage > 0 {
+		return p.Total, nil
+	}
+	return len(resp.Data), nil
+}
+
+// listPage fetches a single page of a list endpoint. If the client belongs to a
+// RateLimitedClient, the request goes through its rate limiting and retry logic.
+func (c *Client) listPage(ctx context.Context, path string, query url.Values, result interface{}) error {
+	if c.retrier != nil {
+		return c.retrier.DoRequestWithRetry(ctx, "GET", path, query, nil, result)
+	}
+	return c.doRequest(ctx, "GET", path, query, nil, result)
+}
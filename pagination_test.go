@@ -0,0 +1,513 @@
+package invoiceninja
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// pagedHandler serves totalPages pages of perPage items with IDs "<page>-<n>".
+func pagedHandler(t *testing.T, totalPages, perPage int, hits *int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil {
+			t.Errorf("expected numeric page parameter, got %q", r.URL.Query().Get("page"))
+		}
+
+		data := make([]map[string]interface{}, 0, perPage)
+		for i := 0; i < perPage; i++ {
+			data = append(data, map[string]interface{}{"id": fmt.Sprintf("%d-%d", page, i)})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": data,
+			"meta": map[string]interface{}{
+				"pagination": map[string]interface{}{
+					"total":        totalPages * perPage,
+					"count":        perPage,
+					"per_page":     perPage,
+					"current_page": page,
+					"total_pages":  totalPages,
+				},
+			},
+		})
+	}
+}
+
+func TestInvoicesServiceListAll(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(pagedHandler(t, 3, 2, &hits))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	invoices, err := client.Invoices.ListAll(context.Background(), &InvoiceListOptions{PerPage: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(invoices) != 6 {
+		t.Errorf("expected 6 invoices, got %d", len(invoices))
+	}
+
+	if hits != 3 {
+		t.Errorf("expected 3 page requests, got %d", hits)
+	}
+
+	if invoices[5].ID != "3-1" {
+		t.Errorf("expected last invoice ID 3-1, got %s", invoices[5].ID)
+	}
+}
+
+func TestIteratorCancelledAfterFirstPage(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var hits int32
+	server := httptest.NewServer(pagedHandler(t, 3, 2, &hits))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	it := client.Payments.Iter(ctx, nil)
+
+	var payments []Payment
+	for it.Next() {
+		payments = append(payments, it.Value())
+		cancel()
+	}
+
+	if !errors.Is(it.Err(), context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", it.Err())
+	}
+
+	if len(payments) != 2 {
+		t.Errorf("expected the 2 payments from the first page, got %d", len(payments))
+	}
+
+	if hits != 1 {
+		t.Errorf("expected only the first page to be requested, got %d", hits)
+	}
+}
+
+func TestListAllPageError(t *testing.T) {
+	var hits int32
+	paged := pagedHandler(t, 3, 2, &hits)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		paged(w, r)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	clients, err := client.Clients.ListAll(context.Background(), nil)
+
+	apiErr, ok := IsAPIError(err)
+	if !ok || !apiErr.IsServerError() {
+		t.Fatalf("expected server APIError, got %v", err)
+	}
+
+	if len(clients) != 2 {
+		t.Errorf("expected partial results from first page, got %d", len(clients))
+	}
+}
+
+func TestPaymentsServiceStream(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(pagedHandler(t, 3, 2, &hits))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	payments, errs := client.Payments.Stream(context.Background(), &PaymentListOptions{PerPage: 2})
+
+	var ids []string
+	for payment := range payments {
+		ids = append(ids, payment.ID)
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"1-0", "1-1", "2-0", "2-1", "3-0", "3-1"}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("expected %v, got %v", want, ids)
+	}
+	if hits != 3 {
+		t.Errorf("expected 3 page requests, got %d", hits)
+	}
+}
+
+func TestPaymentsServiceStreamPageError(t *testing.T) {
+	var hits int32
+	paged := pagedHandler(t, 3, 2, &hits)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		paged(w, r)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	payments, errs := client.Payments.Stream(context.Background(), nil)
+
+	received := 0
+	for range payments {
+		received++
+	}
+	err := <-errs
+
+	var pageErr *PageError
+	if !errors.As(err, &pageErr) || pageErr.Page != 2 {
+		t.Fatalf("expected a PageError for page 2, got %v", err)
+	}
+	if apiErr, ok := IsAPIError(err); !ok || !apiErr.IsServerError() {
+		t.Errorf("expected the server APIError to be wrapped, got %v", err)
+	}
+	if received != 2 {
+		t.Errorf("expected the 2 payments from the first page, got %d", received)
+	}
+	if _, open := <-errs; open {
+		t.Error("expected the error channel to be closed")
+	}
+}
+
+func TestPaymentsServiceStreamCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var hits int32
+	server := httptest.NewServer(pagedHandler(t, 3, 2, &hits))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	payments, errs := client.Payments.Stream(ctx, nil)
+
+	// Stop consuming after the first payment; the goroutine must still exit
+	<-payments
+	cancel()
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the stream to stop after cancellation")
+	}
+	for range payments {
+	}
+	if hits != 1 {
+		t.Errorf("expected only the first page to be requested, got %d", hits)
+	}
+}
+
+func TestListAllRetriesThroughRateLimitedClient(t *testing.T) {
+	var hits, failures int32
+	paged := pagedHandler(t, 2, 1, &hits)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" && atomic.AddInt32(&failures, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		paged(w, r)
+	}))
+	defer server.Close()
+
+	client := NewRateLimitedClient("test-token", WithBaseURL(server.URL))
+	client.SetRetryConfig(&RetryConfig{
+		MaxRetries:         2,
+		InitialBackoff:     time.Millisecond,
+		MaxBackoff:         time.Millisecond,
+		BackoffMultiplier:  1,
+		RetryOnStatusCodes: []int{503},
+	})
+
+	credits, err := client.Credits.ListAll(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(credits) != 2 {
+		t.Errorf("expected 2 credits, got %d", len(credits))
+	}
+
+	if failures != 2 {
+		t.Errorf("expected page 2 to be retried once, got %d attempts", failures)
+	}
+}
+
+func TestListAllPageDelay(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(pagedHandler(t, 3, 1, &hits))
+	defer server.Close()
+
+	clock := newFakeClock()
+	client := NewClient("test-token", WithBaseURL(server.URL), WithPageDelay(time.Hour), WithClock(clock))
+
+	done := make(chan []PaymentTerm)
+	go func() {
+		terms, err := client.PaymentTerms.ListAll(context.Background(), nil)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		done <- terms
+	}()
+
+	// Each page after the first waits for the delay on the client's clock
+	for page := 2; page <= 3; page++ {
+		clock.waitForWaiters(t, 1)
+		if n := atomic.LoadInt32(&hits); n != int32(page-1) {
+			t.Errorf("expected page %d to wait for the delay, got %d requests", page, n)
+		}
+		clock.Advance(time.Hour)
+	}
+
+	if terms := <-done; len(terms) != 3 {
+		t.Errorf("expected 3 payment terms, got %d", len(terms))
+	}
+}
+
+func TestIteratorStartsAtRequestedPage(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(pagedHandler(t, 3, 1, &hits))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	it := client.Invoices.Iter(context.Background(), &InvoiceListOptions{Page: 2})
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(ids) != 2 || ids[0] != "2-0" || ids[1] != "3-0" {
+		t.Errorf("expected pages 2 and 3, got %v", ids)
+	}
+}
+
+func TestListAllKeepsOptionsOnEveryPage(t *testing.T) {
+	var mu sync.Mutex
+	var queries []url.Values
+	paged := pagedHandler(t, 3, 1, new(int32))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.Query())
+		mu.Unlock()
+		paged(w, r)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	ctx := context.Background()
+	deleted := true
+
+	scans := map[string]func() (int, error){
+		"invoices": func() (int, error) {
+			all, err := client.Invoices.ListAll(ctx, &InvoiceListOptions{
+				PerPage: 1, Filter: "acme", Status: "active,deleted", IsDeleted: &deleted,
+				Filters: NewFilter().Gte("amount", 100).Exprs(),
+			})
+			return len(all), err
+		},
+		"clients": func() (int, error) {
+			all, err := client.Clients.ListAll(ctx, &ClientListOptions{
+				PerPage: 1, Filter: "acme", Status: "active,deleted", IsDeleted: &deleted,
+			})
+			return len(all), err
+		},
+		"payments": func() (int, error) {
+			all, err := client.Payments.ListAll(ctx, &PaymentListOptions{
+				PerPage: 1, Filter: "acme", Status: "active,deleted", IsDeleted: &deleted,
+			})
+			return len(all), err
+		},
+	}
+
+	for name, scan := range scans {
+		queries = nil
+		n, err := scan()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if n != 3 || len(queries) != 3 {
+			t.Fatalf("%s: expected 3 items from 3 pages, got %d from %d", name, n, len(queries))
+		}
+
+		first := queries[0]
+		if first.Get("is_deleted") != "true" || first.Get("status") != "active,deleted" || first.Get("filter") != "acme" {
+			t.Errorf("%s: options missing from page 1: %v", name, first)
+		}
+		for i, q := range queries[1:] {
+			page := strconv.Itoa(i + 2)
+			if q.Get("page") != page {
+				t.Errorf("%s: expected page=%s, got %q", name, page, q.Get("page"))
+			}
+			q.Set("page", first.Get("page"))
+			if !reflect.DeepEqual(q, first) {
+				t.Errorf("%s: page %s query %v differs from page 1 query %v", name, page, q, first)
+			}
+		}
+	}
+}
+
+func TestIteratorSinglePageWithoutMeta(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": [{"id": "a"}, {"id": "b"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	invoices, err := client.Invoices.ListAll(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(invoices) != 2 || hits != 1 {
+		t.Errorf("expected 2 invoices from 1 request, got %d from %d", len(invoices), hits)
+	}
+}
+
+func TestIteratorFollowsNextLinkWithoutTotalPages(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+
+		pagination := map[string]interface{}{"current_page": page, "per_page": 1}
+		if page < 3 {
+			pagination["links"] = map[string]interface{}{"next": fmt.Sprintf("/api/v1/invoices?page=%d", page+1)}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{{"id": fmt.Sprintf("%d-0", page)}},
+			"meta": map[string]interface{}{"pagination": pagination},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	invoices, err := client.Invoices.ListAll(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(invoices) != 3 || hits != 3 {
+		t.Errorf("expected 3 invoices from 3 requests, got %d from %d", len(invoices), hits)
+	}
+}
+
+func TestPaginationHelpers(t *testing.T) {
+	tests := []struct {
+		name     string
+		p        Pagination
+		hasNext  bool
+		hasPrev  bool
+		nextPage int
+	}{
+		{"first page", Pagination{CurrentPage: 1, TotalPages: 3}, true, false, 2},
+		{"middle page", Pagination{CurrentPage: 2, TotalPages: 3}, true, true, 3},
+		{"last page", Pagination{CurrentPage: 3, TotalPages: 3}, false, true, 0},
+		{"single page", Pagination{CurrentPage: 1, TotalPages: 1}, false, false, 0},
+		{"empty result", Pagination{CurrentPage: 1, TotalPages: 0}, false, false, 0},
+		{"no meta", Pagination{}, false, false, 0},
+		{"next link only", Pagination{CurrentPage: 1, Links: &Links{Next: "https://example.com?page=2"}}, true, false, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.HasNext(); got != tt.hasNext {
+				t.Errorf("HasNext() = %v, want %v", got, tt.hasNext)
+			}
+			if got := tt.p.HasPrev(); got != tt.hasPrev {
+				t.Errorf("HasPrev() = %v, want %v", got, tt.hasPrev)
+			}
+			if got := tt.p.NextPage(); got != tt.nextPage {
+				t.Errorf("NextPage() = %d, want %d", got, tt.nextPage)
+			}
+
+			resp := ListResponse[Invoice]{Meta: Meta{Pagination: tt.p}}
+			if resp.HasNextPage() != tt.hasNext {
+				t.Errorf("HasNextPage() = %v, want %v", resp.HasNextPage(), tt.hasNext)
+			}
+		})
+	}
+}
+
+func TestInvoicesServiceCount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/api/v1/invoices" {
+			t.Errorf("expected path /api/v1/invoices, got %s", r.URL.Path)
+		}
+		if q.Get("per_page") != "1" || q.Has("page") {
+			t.Errorf("expected per_page=1 and no page, got %q", r.URL.RawQuery)
+		}
+		if q.Get("client_id") != "c1" {
+			t.Errorf("expected the list options to be kept, got %q", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": [{"id": "inv1"}], "meta": {"pagination": {"total": 137, "count": 1, "per_page": 1, "current_page": 1, "total_pages": 137}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	n, err := client.Invoices.Count(context.Background(), &InvoiceListOptions{ClientID: "c1", PerPage: 50, Page: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 137 {
+		t.Errorf("expected 137, got %d", n)
+	}
+}
+
+func TestCountWithoutPagination(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": [{"id": "t1"}, {"id": "t2"}, {"id": "t3"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	n, err := client.PaymentTerms.Count(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("expected the unpaginated entities to be counted, got %d", n)
+	}
+}
@@ -26,9 +26,12 @@ type PaymentTerm struct {
 
 // PaymentTermListOptions specifies the optional parameters for listing payment terms.
 type PaymentTermListOptions struct {
-	PerPage int
-	Page    int
-	Include string
+	PerPage   int
+	Page      int
+	Status    string
+	IsDeleted *bool
+	Include   string
+	Filters   []FilterExpr
 }
 
 // toQuery converts options to URL query parameters.
@@ -45,88 +48,129 @@ func (o *PaymentTermListOptions) toQuery() url.Values {
 	if o.Page > 0 {
 		q.Set("page", strconv.Itoa(o.Page))
 	}
+	if o.Status != "" {
+		q.Set("status", o.Status)
+	}
+	if o.IsDeleted != nil {
+		q.Set("is_deleted", strconv.FormatBool(*o.IsDeleted))
+	}
 	if o.Include != "" {
 		q.Set("include", o.Include)
 	}
 
+	applyFilters(q, o.Filters)
+
 	return q
 }
 
 // List retrieves a list of payment terms.
 func (s *PaymentTermsService) List(ctx context.Context, opts *PaymentTermListOptions) (*ListResponse[PaymentTerm], error) {
 	var resp ListResponse[PaymentTerm]
-	if err := s.client.doRequest(ctx, "GET", "/api/v1/payment_terms", opts.toQuery(), nil, &resp); err != nil {
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/payment_terms"), opts.toQuery(), nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp, nil
 }
 
+// Iter returns an iterator over all payment terms matching opts, fetching pages lazily.
+// Paging starts at opts.Page (or page 1) and keeps every other option.
+func (s *PaymentTermsService) Iter(ctx context.Context, opts *PaymentTermListOptions) *Iterator[PaymentTerm] {
+	return newIterator[PaymentTerm](ctx, s.client, s.client.apiPath("/payment_terms"), opts.toQuery())
+}
+
+// ListAll retrieves all payment terms matching opts across every page.
+// If a page fails or ctx is done mid-scan, the payment terms fetched so far are
+// returned together with the error.
+func (s *PaymentTermsService) ListAll(ctx context.Context, opts *PaymentTermListOptions) ([]PaymentTerm, error) {
+	return listAll(s.Iter(ctx, opts))
+}
+
+// Count returns the number of payment terms matching opts without fetching them,
+// from the pagination total of a one-item page. Paging options are ignored.
+func (s *PaymentTermsService) Count(ctx context.Context, opts *PaymentTermListOptions) (int, error) {
+	return count(ctx, s.client, s.client.apiPath("/payment_terms"), opts.toQuery())
+}
+
 // Get retrieves a single payment term by ID.
 func (s *PaymentTermsService) Get(ctx context.Context, id string) (*PaymentTerm, error) {
 	var resp SingleResponse[PaymentTerm]
-	if err := s.client.doRequest(ctx, "GET", fmt.Sprintf("/api/v1/payment_terms/%s", id), nil, nil, &resp); err != nil {
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/payment_terms/%s", id), nil, nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp.Data, nil
 }
 
 // Create creates a new payment term.
-func (s *PaymentTermsService) Create(ctx context.Context, term *PaymentTerm) (*PaymentTerm, error) {
+func (s *PaymentTermsService) Create(ctx context.Context, term *PaymentTerm, opts ...RequestOption) (*PaymentTerm, error) {
 	var resp SingleResponse[PaymentTerm]
-	if err := s.client.doRequest(ctx, "POST", "/api/v1/payment_terms", nil, term, &resp); err != nil {
+	if err := s.client.doRequest(ctx, "POST", s.client.apiPath("/payment_terms"), nil, term, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp.Data, nil
 }
 
 // Update updates an existing payment term.
-func (s *PaymentTermsService) Update(ctx context.Context, id string, term *PaymentTerm) (*PaymentTerm, error) {
+func (s *PaymentTermsService) Update(ctx context.Context, id string, term *PaymentTerm, opts ...RequestOption) (*PaymentTerm, error) {
 	var resp SingleResponse[PaymentTerm]
-	if err := s.client.doRequest(ctx, "PUT", fmt.Sprintf("/api/v1/payment_terms/%s", id), nil, term, &resp); err != nil {
+	if err := s.client.doRequest(ctx, "PUT", s.client.apiPath("/payment_terms/%s", id), nil, term, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp.Data, nil
 }
 
 // Delete deletes a payment term by ID.
-func (s *PaymentTermsService) Delete(ctx context.Context, id string) error {
-	return s.client.doRequest(ctx, "DELETE", fmt.Sprintf("/api/v1/payment_terms/%s", id), nil, nil, nil)
+func (s *PaymentTermsService) Delete(ctx context.Context, id string, opts ...RequestOption) error {
+	return s.client.doRequest(ctx, "DELETE", s.client.apiPath("/payment_terms/%s", id), nil, nil, nil, opts...)
 }
 
 // Bulk performs a bulk action on multiple payment terms.
-func (s *PaymentTermsService) Bulk(ctx context.Context, action string, ids []string) ([]PaymentTerm, error) {
+func (s *PaymentTermsService) Bulk(ctx context.Context, action string, ids []string, opts ...RequestOption) ([]PaymentTerm, error) {
 	req := BulkAction{
 		Action: action,
 		IDs:    ids,
 	}
 
-	var resp ListResponse[PaymentTerm]
-	if err := s.client.doRequest(ctx, "POST", "/api/v1/payment_terms/bulk", nil, req, &resp); err != nil {
+	return bulkRequest[PaymentTerm](ctx, s.client, s.client.apiPath("/payment_terms/bulk"), req, opts...)
+}
+
+// BulkTyped performs a bulk action on multiple payment terms like Bulk, but
+// first checks that action is one of BulkArchive, BulkRestore and BulkDelete,
+// returning a *ValidationError otherwise.
+func (s *PaymentTermsService) BulkTyped(ctx context.Context, action BulkActionType, ids []string, opts ...RequestOption) ([]PaymentTerm, error) {
+	if err := checkBulkAction("payment terms", action); err != nil {
 		return nil, err
 	}
-	return resp.Data, nil
+	return s.Bulk(ctx, string(action), ids, opts...)
+}
+
+// BulkChunked performs a bulk action on a large number of payment terms by splitting ids
+// into chunks of chunkSize (DefaultBulkChunkSize when chunkSize <= 0) and sending
+// one Bulk request per chunk. It returns the payment terms echoed by successful chunks along
+// with a joined error of *BulkChunkError values for any chunks that failed.
+func (s *PaymentTermsService) BulkChunked(ctx context.Context, action string, ids []string, chunkSize int, opts ...RequestOption) ([]PaymentTerm, error) {
+	return bulkChunked(ctx, ids, chunkSize, func(ctx context.Context, chunk []string) ([]PaymentTerm, error) {
+		return s.Bulk(ctx, action, chunk, opts...)
+	})
 }
 
 // Archive archives a payment term.
 func (s *PaymentTermsService) Archive(ctx context.Context, id string) (*PaymentTerm, error) {
-	terms, err := s.Bulk(ctx, "archive", []string{id})
-	if err != nil {
-		return nil, err
-	}
-	if len(terms) == 0 {
-		return nil, fmt.Errorf("no payment term returned from bulk action")
-	}
-	return &terms[0], nil
+	return s.bulkAction(ctx, "archive", id)
 }
 
-// Restore restores an archived payment term.
+// Restore restores an archived or deleted payment term.
 func (s *PaymentTermsService) Restore(ctx context.Context, id string) (*PaymentTerm, error) {
-	terms, err := s.Bulk(ctx, "restore", []string{id})
+	return s.bulkAction(ctx, "restore", id)
+}
+
+// bulkAction performs a single-item bulk action.
+func (s *PaymentTermsService) bulkAction(ctx context.Context, action, id string) (*PaymentTerm, error) {
+	terms, err := s.Bulk(ctx, action, []string{id})
 	if err != nil {
 		return nil, err
 	}
 	if len(terms) == 0 {
-		return nil, fmt.Errorf("no payment term returned from bulk action")
+		return nil, fmt.Errorf("%s payment term: %w", action, ErrEmptyBulkResponse)
 	}
 	return &terms[0], nil
 }
@@ -134,7 +178,7 @@ func (s *PaymentTermsService) Restore(ctx context.Context, id string) (*PaymentT
 // GetBlank retrieves a blank payment term object with default values.
 func (s *PaymentTermsService) GetBlank(ctx context.Context) (*PaymentTerm, error) {
 	var resp SingleResponse[PaymentTerm]
-	if err := s.client.doRequest(ctx, "GET", "/api/v1/payment_terms/create", nil, nil, &resp); err != nil {
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/payment_terms/create"), nil, nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp.Data, nil
@@ -0,0 +1,125 @@
+package invoiceninja
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// PaymentType identifies how a payment was made. The values match the
+// payment type IDs used in Payment.TypeID and PaymentRequest.TypeID.
+type PaymentType int
+
+// Payment types defined by Invoice Ninja.
+const (
+	PaymentTypeBankTransfer       PaymentType = 1
+	PaymentTypeCash               PaymentType = 2
+	PaymentTypeACH                PaymentType = 4
+	PaymentTypeVisa               PaymentType = 5
+	PaymentTypeMasterCard         PaymentType = 6
+	PaymentTypeAmericanExpress    PaymentType = 7
+	PaymentTypeDiscover           PaymentType = 8
+	PaymentTypeDiners             PaymentType = 9
+	PaymentTypeEuroCard           PaymentType = 10
+	PaymentTypeNova               PaymentType = 11
+	PaymentTypeCreditCardOther    PaymentType = 12
+	PaymentTypePayPal             PaymentType = 13
+	PaymentTypeCheck              PaymentType = 15
+	PaymentTypeCarteBlanche       PaymentType = 16
+	PaymentTypeUnionPay           PaymentType = 17
+	PaymentTypeJCB                PaymentType = 18
+	PaymentTypeLaser              PaymentType = 19
+	PaymentTypeMaestro            PaymentType = 20
+	PaymentTypeSolo               PaymentType = 21
+	PaymentTypeSwitch             PaymentType = 22
+	PaymentTypeVenmo              PaymentType = 24
+	PaymentTypeAlipay             PaymentType = 27
+	PaymentTypeSofort             PaymentType = 28
+	PaymentTypeSEPA               PaymentType = 29
+	PaymentTypeGoCardless         PaymentType = 30
+	PaymentTypeCrypto             PaymentType = 31
+	PaymentTypeCredit             PaymentType = 32
+	PaymentTypeZelle              PaymentType = 33
+	PaymentTypeMollieBankTransfer PaymentType = 34
+	PaymentTypeKBC                PaymentType = 35
+	PaymentTypeBancontact         PaymentType = 36
+	PaymentTypeIDEAL              PaymentType = 37
+	PaymentTypeHostedPage         PaymentType = 38
+	PaymentTypeGiropay            PaymentType = 39
+	PaymentTypePrzelewy24         PaymentType = 40
+	PaymentTypeEPS                PaymentType = 41
+	PaymentTypeDirectDebit        PaymentType = 42
+	PaymentTypeBECS               PaymentType = 43
+	PaymentTypeACSS               PaymentType = 44
+	PaymentTypeInstantBankPay     PaymentType = 45
+	PaymentTypeFPX                PaymentType = 46
+	PaymentTypeKlarna             PaymentType = 47
+	PaymentTypeBACS               PaymentType = 48
+)
+
+var paymentTypeNames = map[PaymentType]string{
+	PaymentTypeBankTransfer:       "Bank Transfer",
+	PaymentTypeCash:               "Cash",
+	PaymentTypeACH:                "ACH",
+	PaymentTypeVisa:               "Visa Card",
+	PaymentTypeMasterCard:         "MasterCard",
+	PaymentTypeAmericanExpress:    "American Express",
+	PaymentTypeDiscover:           "Discover Card",
+	PaymentTypeDiners:             "Diners Card",
+	PaymentTypeEuroCard:           "EuroCard",
+	PaymentTypeNova:               "Nova",
+	PaymentTypeCreditCardOther:    "Credit Card Other",
+	PaymentTypePayPal:             "PayPal",
+	PaymentTypeCheck:              "Check",
+	PaymentTypeCarteBlanche:       "Carte Blanche",
+	PaymentTypeUnionPay:           "UnionPay",
+	PaymentTypeJCB:                "JCB",
+	PaymentTypeLaser:              "Laser",
+	PaymentTypeMaestro:            "Maestro",
+	PaymentTypeSolo:               "Solo",
+	PaymentTypeSwitch:             "Switch",
+	PaymentTypeVenmo:              "Venmo",
+	PaymentTypeAlipay:             "Alipay",
+	PaymentTypeSofort:             "Sofort",
+	PaymentTypeSEPA:               "SEPA",
+	PaymentTypeGoCardless:         "GoCardless",
+	PaymentTypeCrypto:             "Crypto",
+	PaymentTypeCredit:             "Credit",
+	PaymentTypeZelle:              "Zelle",
+	PaymentTypeMollieBankTransfer: "Mollie Bank Transfer",
+	PaymentTypeKBC:                "KBC/CBC",
+	PaymentTypeBancontact:         "Bancontact",
+	PaymentTypeIDEAL:              "iDEAL",
+	PaymentTypeHostedPage:         "Hosted Page",
+	PaymentTypeGiropay:            "GiroPay",
+	PaymentTypePrzelewy24:         "Przelewy24",
+	PaymentTypeEPS:                "EPS",
+	PaymentTypeDirectDebit:        "Direct Debit",
+	PaymentTypeBECS:               "BECS",
+	PaymentTypeACSS:               "ACSS",
+	PaymentTypeInstantBankPay:     "Instant Bank Pay",
+	PaymentTypeFPX:                "FPX",
+	PaymentTypeKlarna:             "Klarna",
+	PaymentTypeBACS:               "BACS",
+}
+
+// String returns the payment type name as shown in Invoice Ninja, e.g. "Bank Transfer".
+func (t PaymentType) String() string {
+	if name, ok := paymentTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("PaymentType(%d)", int(t))
+}
+
+// SetType sets the request's TypeID to the given payment type.
+//
+//	req := &invoiceninja.PaymentRequest{ClientID: clientID, Amount: 100}
+//	req.SetType(invoiceninja.PaymentTypeBankTransfer)
+func (r *PaymentRequest) SetType(t PaymentType) {
+	r.TypeID = strconv.Itoa(int(t))
+}
+
+// Type returns the request's TypeID as a PaymentType, or 0 if it is empty or
+// not numeric.
+func (r *PaymentRequest) Type() PaymentType {
+	return PaymentType(parseStatusID(r.TypeID))
+}
@@ -0,0 +1,45 @@
+package invoiceninja
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestPaymentRequestSetType(t *testing.T) {
+	req := &PaymentRequest{ClientID: "client1", Amount: 100}
+	req.SetType(PaymentTypeBankTransfer)
+
+	if req.TypeID != "1" {
+		t.Errorf("expected TypeID 1, got %q", req.TypeID)
+	}
+
+	if req.Type() != PaymentTypeBankTransfer {
+		t.Errorf("expected Type() to round-trip, got %v", req.Type())
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(string(body), `"type_id":"1"`) {
+		t.Errorf("expected type_id in JSON, got %s", body)
+	}
+}
+
+func TestPaymentTypeString(t *testing.T) {
+	tests := map[PaymentType]string{
+		PaymentTypeCash:            "Cash",
+		PaymentTypeCreditCardOther: "Credit Card Other",
+		PaymentTypePayPal:          "PayPal",
+		PaymentTypeCheck:           "Check",
+		PaymentType(3):             "PaymentType(3)",
+	}
+
+	for paymentType, expected := range tests {
+		if paymentType.String() != expected {
+			t.Errorf("PaymentType(%d).String() = %q, want %q", int(paymentType), paymentType.String(), expected)
+		}
+	}
+}
@@ -2,11 +2,18 @@ package invoiceninja
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
 )
 
+// balanceTolerance absorbs floating point rounding when comparing amounts to balances.
+const balanceTolerance = 0.005
+
+// ErrInvoiceNotApplied is returned when a payment is not applied to an invoice.
+var ErrInvoiceNotApplied = errors.New("payment is not applied to invoice")
+
 // PaymentsService handles payment-related API operations.
 type PaymentsService struct {
 	client *Client
@@ -29,6 +36,9 @@ type PaymentListOptions struct {
 	// ClientID filters by client.
 	ClientID string
 
+	// AssignedUserID filters by the user the payment is assigned to.
+	AssignedUserID string
+
 	// Status filters by status (comma-separated: active, archived, deleted).
 	Status string
 
@@ -49,6 +59,9 @@ type PaymentListOptions struct {
 
 	// Include specifies related entities to include.
 	Include string
+
+	// Filters are additional query filters built with NewFilter.
+	Filters []FilterExpr
 }
 
 // toQuery converts options to URL query parameters.
@@ -77,6 +90,9 @@ func (o *PaymentListOptions) toQuery() url.Values {
 	if o.Status != "" {
 		q.Set("status", o.Status)
 	}
+	if o.AssignedUserID != "" {
+		q.Set("assigned_user", o.AssignedUserID)
+	}
 	if o.CreatedAt != "" {
 		q.Set("created_at", o.CreatedAt)
 	}
@@ -96,66 +112,289 @@ func (o *PaymentListOptions) toQuery() url.Values {
 		q.Set("include", o.Include)
 	}
 
+	applyFilters(q, o.Filters)
+
 	return q
 }
 
 // List retrieves a list of payments.
 func (s *PaymentsService) List(ctx context.Context, opts *PaymentListOptions) (*ListResponse[Payment], error) {
 	var resp ListResponse[Payment]
-	if err := s.client.doRequest(ctx, "GET", "/api/v1/payments", opts.toQuery(), nil, &resp); err != nil {
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/payments"), opts.toQuery(), nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp, nil
 }
 
+// Iter returns an iterator over all payments matching opts, fetching pages lazily.
+// Paging starts at opts.Page (or page 1) and keeps every other option.
+func (s *PaymentsService) Iter(ctx context.Context, opts *PaymentListOptions) *Iterator[Payment] {
+	return newIterator[Payment](ctx, s.client, s.client.apiPath("/payments"), opts.toQuery())
+}
+
+// ListAll retrieves all payments matching opts across every page.
+// If a page fails or ctx is done mid-scan, the payments fetched so far are
+// returned together with the error.
+func (s *PaymentsService) ListAll(ctx context.Context, opts *PaymentListOptions) ([]Payment, error) {
+	return listAll(s.Iter(ctx, opts))
+}
+
+// Stream sends all payments matching opts to the returned channel as their
+// pages arrive, for consumers such as worker pools that process payments
+// without holding every page in memory:
+//
+//	payments, errs := client.Payments.Stream(ctx, &invoiceninja.PaymentListOptions{PerPage: 100})
+//	for payment := range payments {
+//		// ...
+//	}
+//	if err := <-errs; err != nil {
+//		// handle error
+//	}
+//
+// Both channels are closed once every payment has been sent, a page fails or
+// ctx is done. The error channel then yields a *PageError naming the failed
+// page, or ctx.Err(). Receive until the payment channel is closed or cancel
+// ctx, otherwise the goroutine feeding it leaks.
+func (s *PaymentsService) Stream(ctx context.Context, opts *PaymentListOptions) (<-chan Payment, <-chan error) {
+	return stream(ctx, s.Iter(ctx, opts))
+}
+
+// Count returns the number of payments matching opts without fetching them,
+// from the pagination total of a one-item page. Paging options are ignored.
+func (s *PaymentsService) Count(ctx context.Context, opts *PaymentListOptions) (int, error) {
+	return count(ctx, s.client, s.client.apiPath("/payments"), opts.toQuery())
+}
+
 // Get retrieves a single payment by ID.
 func (s *PaymentsService) Get(ctx context.Context, id string) (*Payment, error) {
+	return s.GetWith(ctx, id)
+}
+
+// GetWith retrieves a single payment by ID together with the related entities
+// named in include, e.g. "paymentables", in one request.
+func (s *PaymentsService) GetWith(ctx context.Context, id string, include ...string) (*Payment, error) {
 	var resp SingleResponse[Payment]
-	if err := s.client.doRequest(ctx, "GET", fmt.Sprintf("/api/v1/payments/%s", id), nil, nil, &resp); err != nil {
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/payments/%s", id), includeQuery(include), nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp.Data, nil
 }
 
+// Documents returns the documents attached to the payment with the given ID,
+// fetching the payment with its documents included.
+func (s *PaymentsService) Documents(ctx context.Context, paymentID string) ([]Document, error) {
+	payment, err := s.GetWith(ctx, paymentID, "documents")
+	if err != nil {
+		return nil, err
+	}
+	return payment.Documents, nil
+}
+
 // Create creates a new payment.
-func (s *PaymentsService) Create(ctx context.Context, payment *PaymentRequest) (*Payment, error) {
+// No email_receipt parameter is sent, so whether the client is emailed a
+// receipt is left to the company's settings; use CreateWithEmailReceipt to
+// decide per payment.
+// When the client was built with WithPaymentValidation, the invoice balances
+// are checked first and an *OverApplicationError is returned on over-application.
+// With WithClientValidation, PaymentRequest.Validate is checked before that.
+func (s *PaymentsService) Create(ctx context.Context, payment *PaymentRequest, opts ...RequestOption) (*Payment, error) {
+	if err := s.validate(ctx, payment); err != nil {
+		return nil, err
+	}
+
 	var resp SingleResponse[Payment]
-	if err := s.client.doRequest(ctx, "POST", "/api/v1/payments", nil, payment, &resp); err != nil {
+	if err := s.client.doRequest(ctx, "POST", s.client.apiPath("/payments"), nil, payment, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp.Data, nil
 }
 
-// CreateWithEmailReceipt creates a new payment and optionally sends an email receipt.
-func (s *PaymentsService) CreateWithEmailReceipt(ctx context.Context, payment *PaymentRequest, sendEmail bool) (*Payment, error) {
+// CreateWithEmailReceipt creates a new payment and sets Invoice Ninja's
+// email_receipt query parameter, so a receipt is emailed to the client if and
+// only if sendEmail is true, whatever the company's settings.
+func (s *PaymentsService) CreateWithEmailReceipt(ctx context.Context, payment *PaymentRequest, sendEmail bool, opts ...RequestOption) (*Payment, error) {
+	if err := s.validate(ctx, payment); err != nil {
+		return nil, err
+	}
+
 	q := url.Values{}
 	q.Set("email_receipt", strconv.FormatBool(sendEmail))
 
 	var resp SingleResponse[Payment]
-	if err := s.client.doRequest(ctx, "POST", "/api/v1/payments", q, payment, &resp); err != nil {
+	if err := s.client.doRequest(ctx, "POST", s.client.apiPath("/payments"), q, payment, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp.Data, nil
 }
 
-// Update updates an existing payment.
-func (s *PaymentsService) Update(ctx context.Context, id string, payment *PaymentRequest) (*Payment, error) {
+// validate runs the checks enabled by WithClientValidation and WithPaymentValidation.
+func (s *PaymentsService) validate(ctx context.Context, payment *PaymentRequest) error {
+	if s.client.validateRequests && payment != nil {
+		if err := payment.Validate(); err != nil {
+			return err
+		}
+	}
+	return s.checkBalances(ctx, payment)
+}
+
+// checkBalances verifies, when payment validation is enabled, that the amount
+// the payment applies to each invoice does not exceed the invoice's balance.
+func (s *PaymentsService) checkBalances(ctx context.Context, payment *PaymentRequest) error {
+	if !s.client.validatePayments || payment == nil {
+		return nil
+	}
+
+	// Sum per invoice so repeated entries for the same invoice are checked together
+	applied := make(map[string]float64)
+	var order []string
+	for _, inv := range payment.Invoices {
+		if _, ok := applied[inv.InvoiceID]; !ok {
+			order = append(order, inv.InvoiceID)
+		}
+		applied[inv.InvoiceID] += inv.Amount
+	}
+
+	for _, id := range order {
+		invoice, err := s.client.Invoices.Get(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to fetch invoice %s for validation: %w", id, err)
+		}
+
+		if applied[id]-invoice.Balance > balanceTolerance {
+			return &OverApplicationError{
+				InvoiceID: id,
+				Amount:    applied[id],
+				Balance:   invoice.Balance,
+			}
+		}
+	}
+
+	return nil
+}
+
+// Update updates an existing payment. Like Create, it leaves receipts to the
+// company's settings.
+func (s *PaymentsService) Update(ctx context.Context, id string, payment *PaymentRequest, opts ...RequestOption) (*Payment, error) {
 	var resp SingleResponse[Payment]
-	if err := s.client.doRequest(ctx, "PUT", fmt.Sprintf("/api/v1/payments/%s", id), nil, payment, &resp); err != nil {
+	if err := s.client.doRequest(ctx, "PUT", s.client.apiPath("/payments/%s", id), nil, payment, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp.Data, nil
 }
 
+// UpdateWithEmailReceipt updates an existing payment and sets the
+// email_receipt query parameter, like CreateWithEmailReceipt.
+func (s *PaymentsService) UpdateWithEmailReceipt(ctx context.Context, id string, payment *PaymentRequest, sendEmail bool, opts ...RequestOption) (*Payment, error) {
+	q := url.Values{}
+	q.Set("email_receipt", strconv.FormatBool(sendEmail))
+
+	var resp SingleResponse[Payment]
+	if err := s.client.doRequest(ctx, "PUT", s.client.apiPath("/payments/%s", id), q, payment, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Assign assigns the payment to the user with the given ID by updating only its
+// assigned user.
+func (s *PaymentsService) Assign(ctx context.Context, id, userID string, opts ...RequestOption) (*Payment, error) {
+	if err := validateAssignee(userID); err != nil {
+		return nil, err
+	}
+	return s.Update(ctx, id, &PaymentRequest{AssignedUserID: userID}, opts...)
+}
+
 // Delete deletes a payment by ID.
-func (s *PaymentsService) Delete(ctx context.Context, id string) error {
-	return s.client.doRequest(ctx, "DELETE", fmt.Sprintf("/api/v1/payments/%s", id), nil, nil, nil)
+func (s *PaymentsService) Delete(ctx context.Context, id string, opts ...RequestOption) error {
+	return s.client.doRequest(ctx, "DELETE", s.client.apiPath("/payments/%s", id), nil, nil, nil, opts...)
 }
 
 // Refund creates a refund for a payment.
-func (s *PaymentsService) Refund(ctx context.Context, refund *RefundRequest) (*Payment, error) {
+func (s *PaymentsService) Refund(ctx context.Context, refund *RefundRequest, opts ...RequestOption) (*Payment, error) {
 	var resp SingleResponse[Payment]
-	if err := s.client.doRequest(ctx, "POST", "/api/v1/payments/refund", nil, refund, &resp); err != nil {
+	if err := s.client.doRequest(ctx, "POST", s.client.apiPath("/payments/refund"), nil, refund, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// unapplyRequest is the payment update sent by Unapply. Its fields are not
+// omitted when zero, so an amount of 0 and an empty list reach the server.
+type unapplyRequest struct {
+	Invoices []appliedInvoice `json:"invoices"`
+}
+
+// appliedInvoice is the amount of a payment applied to an invoice.
+type appliedInvoice struct {
+	InvoiceID string  `json:"invoice_id"`
+	Amount    float64 `json:"amount"`
+}
+
+// Unapply detaches a payment from invoices it was applied to, e.g. after a
+// misapplied payment, and returns the updated payment. The amounts become
+// unapplied on the payment, so Unapplied grows and they can be applied to
+// other invoices; nothing is refunded.
+//
+// The payment is fetched with its paymentables first; if any invoice ID is not
+// among them with a remaining applied amount, an error wrapping
+// ErrInvoiceNotApplied is returned and nothing is changed. The payment is then
+// updated with every invoice it stays applied to at its current amount, and
+// the unapplied invoices at 0, which restores their balances.
+func (s *PaymentsService) Unapply(ctx context.Context, paymentID string, invoiceIDs []string, opts ...RequestOption) (*Payment, error) {
+	current, err := s.GetWith(ctx, paymentID, "paymentables")
+	if err != nil {
+		return nil, err
+	}
+
+	// Net amount still applied per invoice, over all of its paymentables,
+	// in the order the invoices were applied
+	applied := make(map[string]float64)
+	var order []string
+	for _, p := range current.Paymentables {
+		if p.InvoiceID == "" {
+			continue
+		}
+		if _, ok := applied[p.InvoiceID]; !ok {
+			order = append(order, p.InvoiceID)
+		}
+		applied[p.InvoiceID] += p.Amount - p.Refunded
+	}
+
+	unapply := make(map[string]bool, len(invoiceIDs))
+	var errs []error
+	for _, id := range invoiceIDs {
+		if unapply[id] {
+			continue
+		}
+		if applied[id] <= balanceTolerance {
+			errs = append(errs, fmt.Errorf("invoice %s: %w", id, ErrInvoiceNotApplied))
+			continue
+		}
+		unapply[id] = true
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	if len(unapply) == 0 {
+		return nil, fmt.Errorf("no invoice IDs to unapply")
+	}
+
+	req := unapplyRequest{Invoices: []appliedInvoice{}}
+	for _, id := range order {
+		amount := roundCents(applied[id])
+		if unapply[id] {
+			amount = 0
+		} else if amount <= balanceTolerance {
+			continue
+		}
+		req.Invoices = append(req.Invoices, appliedInvoice{InvoiceID: id, Amount: amount})
+	}
+
+	q := url.Values{}
+	q.Set("include", "paymentables")
+
+	var resp SingleResponse[Payment]
+	if err := s.client.doRequest(ctx, "PUT", s.client.apiPath("/payments/%s", paymentID), q, req, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp.Data, nil
@@ -166,23 +405,39 @@ func (s *PaymentsService) Archive(ctx context.Context, id string) (*Payment, err
 	return s.bulkAction(ctx, "archive", id)
 }
 
-// Restore restores an archived payment.
+// Restore restores an archived or deleted payment.
 func (s *PaymentsService) Restore(ctx context.Context, id string) (*Payment, error) {
 	return s.bulkAction(ctx, "restore", id)
 }
 
 // Bulk performs a bulk action on multiple payments.
-func (s *PaymentsService) Bulk(ctx context.Context, action string, ids []string) ([]Payment, error) {
+func (s *PaymentsService) Bulk(ctx context.Context, action string, ids []string, opts ...RequestOption) ([]Payment, error) {
 	req := BulkAction{
 		Action: action,
 		IDs:    ids,
 	}
 
-	var resp ListResponse[Payment]
-	if err := s.client.doRequest(ctx, "POST", "/api/v1/payments/bulk", nil, req, &resp); err != nil {
+	return bulkRequest[Payment](ctx, s.client, s.client.apiPath("/payments/bulk"), req, opts...)
+}
+
+// BulkTyped performs a bulk action on multiple payments like Bulk, but first
+// checks that action is one of BulkArchive, BulkRestore and BulkDelete,
+// returning a *ValidationError otherwise.
+func (s *PaymentsService) BulkTyped(ctx context.Context, action BulkActionType, ids []string, opts ...RequestOption) ([]Payment, error) {
+	if err := checkBulkAction("payments", action); err != nil {
 		return nil, err
 	}
-	return resp.Data, nil
+	return s.Bulk(ctx, string(action), ids, opts...)
+}
+
+// BulkChunked performs a bulk action on a large number of payments by splitting ids
+// into chunks of chunkSize (DefaultBulkChunkSize when chunkSize <= 0) and sending
+// one Bulk request per chunk. It returns the payments echoed by successful chunks along
+// with a joined error of *BulkChunkError values for any chunks that failed.
+func (s *PaymentsService) BulkChunked(ctx context.Context, action string, ids []string, chunkSize int, opts ...RequestOption) ([]Payment, error) {
+	return bulkChunked(ctx, ids, chunkSize, func(ctx context.Context, chunk []string) ([]Payment, error) {
+		return s.Bulk(ctx, action, chunk, opts...)
+	})
 }
 
 // bulkAction performs a single-item bulk action.
@@ -192,7 +447,7 @@ func (s *PaymentsService) bulkAction(ctx context.Context, action, id string) (*P
 		return nil, err
 	}
 	if len(payments) == 0 {
-		return nil, fmt.Errorf("no payment returned from bulk action")
+		return nil, fmt.Errorf("%s payment: %w", action, ErrEmptyBulkResponse)
 	}
 	return &payments[0], nil
 }
@@ -200,8 +455,32 @@ func (s *PaymentsService) bulkAction(ctx context.Context, action, id string) (*P
 // GetBlank retrieves a blank payment object with default values.
 func (s *PaymentsService) GetBlank(ctx context.Context) (*Payment, error) {
 	var resp SingleResponse[Payment]
-	if err := s.client.doRequest(ctx, "GET", "/api/v1/payments/create", nil, nil, &resp); err != nil {
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/payments/create"), nil, nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp.Data, nil
 }
+
+// Applied returns the part of the payment's own money applied to invoices:
+// the amounts applied to invoices less the credits used towards them, net of
+// refunds. It is computed from Paymentables, so the payment must be fetched
+// with them, e.g. with GetWith(ctx, id, "paymentables").
+func (p *Payment) Applied() float64 {
+	var applied float64
+	for _, pa := range p.Paymentables {
+		switch {
+		case pa.InvoiceID != "":
+			applied += pa.Amount - pa.Refunded
+		case pa.CreditID != "":
+			applied -= pa.Amount - pa.Refunded
+		}
+	}
+	return roundCents(applied)
+}
+
+// Unapplied returns the part of the payment still available to apply to
+// invoices: Amount less Applied and Refunded. Like Applied, it needs the
+// payment's Paymentables.
+func (p *Payment) Unapplied() float64 {
+	return roundCents(p.Amount - p.Applied() - p.Refunded)
+}
@@ -3,8 +3,11 @@ package invoiceninja
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -159,6 +162,85 @@ func TestPaymentsServiceCreate(t *testing.T) {
 	}
 }
 
+// balanceServer serves invoices with the given balances and records whether a payment was created.
+func balanceServer(t *testing.T, balances map[string]float64, created *bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == "POST" && r.URL.Path == "/api/v1/payments" {
+			*created = true
+			w.Write([]byte(`{"data": {"id": "pay1"}}`))
+			return
+		}
+
+		id := strings.TrimPrefix(r.URL.Path, "/api/v1/invoices/")
+		balance, ok := balances[id]
+		if r.Method != "GET" || !ok {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"id": id, "balance": balance},
+		})
+	}))
+}
+
+func TestPaymentsServiceCreateWithValidation(t *testing.T) {
+	var created bool
+	server := balanceServer(t, map[string]float64{"inv1": 100, "inv2": 50.25}, &created)
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithPaymentValidation())
+
+	payment, err := client.Payments.Create(context.Background(), &PaymentRequest{
+		ClientID: "client1",
+		Amount:   150.25,
+		Invoices: []PaymentInvoice{
+			{InvoiceID: "inv1", Amount: 100},
+			{InvoiceID: "inv2", Amount: 50.25},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !created || payment.ID != "pay1" {
+		t.Errorf("expected payment to be created, got %+v", payment)
+	}
+}
+
+func TestPaymentsServiceCreateWithValidationOverApplied(t *testing.T) {
+	var created bool
+	server := balanceServer(t, map[string]float64{"inv1": 100, "inv2": 50}, &created)
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithPaymentValidation())
+
+	_, err := client.Payments.Create(context.Background(), &PaymentRequest{
+		ClientID: "client1",
+		Amount:   130,
+		Invoices: []PaymentInvoice{
+			{InvoiceID: "inv1", Amount: 50},
+			{InvoiceID: "inv2", Amount: 40},
+			{InvoiceID: "inv2", Amount: 40},
+		},
+	})
+
+	var overErr *OverApplicationError
+	if !errors.As(err, &overErr) {
+		t.Fatalf("expected *OverApplicationError, got %v", err)
+	}
+
+	if overErr.InvoiceID != "inv2" || overErr.Amount != 80 || overErr.Balance != 50 {
+		t.Errorf("unexpected over-application error: %+v", overErr)
+	}
+
+	if created {
+		t.Error("expected payment not to be created")
+	}
+}
+
 func TestPaymentsServiceUpdate(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != "PUT" {
@@ -194,6 +276,50 @@ func TestPaymentsServiceUpdate(t *testing.T) {
 	}
 }
 
+func TestPaymentsServiceEmailReceipt(t *testing.T) {
+	var gotQuery []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if v, ok := q["email_receipt"]; ok {
+			gotQuery = append(gotQuery, r.Method+" "+v[0])
+		} else {
+			gotQuery = append(gotQuery, r.Method+" unset")
+		}
+		if _, ok := q["send_email"]; ok {
+			t.Errorf("unexpected send_email parameter in %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "abc123"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	ctx := context.Background()
+	req := &PaymentRequest{ClientID: "client1", Amount: 10}
+
+	if _, err := client.Payments.Create(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Payments.CreateWithEmailReceipt(ctx, req, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Payments.CreateWithEmailReceipt(ctx, req, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Payments.Update(ctx, "abc123", req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Payments.UpdateWithEmailReceipt(ctx, "abc123", req, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"POST unset", "POST true", "POST false", "PUT unset", "PUT true"}
+	if strings.Join(gotQuery, ", ") != strings.Join(want, ", ") {
+		t.Errorf("expected email_receipt %v, got %v", want, gotQuery)
+	}
+}
+
 func TestPaymentsServiceDelete(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != "DELETE" {
@@ -334,3 +460,165 @@ func TestPaymentListOptionsNilToQuery(t *testing.T) {
 		t.Error("expected nil query for nil options")
 	}
 }
+
+func TestPaymentsServiceUnapply(t *testing.T) {
+	var update struct {
+		Invoices []struct {
+			InvoiceID string   `json:"invoice_id"`
+			Amount    *float64 `json:"amount"`
+		} `json:"invoices"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v1/payments/pay1":
+			if r.URL.Query().Get("include") != "paymentables" {
+				t.Errorf("expected include=paymentables, got %s", r.URL.Query().Get("include"))
+			}
+			w.Write([]byte(`{"data": {"id": "pay1", "amount": 350, "refunded": 40, "paymentables": [
+				{"id": "pb1", "invoice_id": "inv1", "amount": 100},
+				{"id": "pb2", "invoice_id": "inv2", "amount": 200, "refunded": 50},
+				{"id": "pb3", "invoice_id": "inv3", "amount": 40, "refunded": 40},
+				{"id": "pb4", "invoice_id": "inv4", "amount": 60}
+			]}}`))
+		case r.Method == "PUT" && r.URL.Path == "/api/v1/payments/pay1":
+			if r.URL.Query().Get("include") != "paymentables" {
+				t.Errorf("expected include=paymentables, got %s", r.URL.Query().Get("include"))
+			}
+			json.NewDecoder(r.Body).Decode(&update)
+			w.Write([]byte(`{"data": {"id": "pay1", "amount": 350, "refunded": 40, "paymentables": [
+				{"id": "pb3", "invoice_id": "inv3", "amount": 40, "refunded": 40},
+				{"id": "pb4", "invoice_id": "inv4", "amount": 60}
+			]}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	before, err := client.Payments.GetWith(ctx, "pay1", "paymentables")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	payment, err := client.Payments.Unapply(ctx, "pay1", []string{"inv1", "inv2", "inv1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := make(map[string]float64)
+	for _, inv := range update.Invoices {
+		if inv.Amount == nil {
+			t.Errorf("expected an explicit amount for %s", inv.InvoiceID)
+			continue
+		}
+		got[inv.InvoiceID] = *inv.Amount
+	}
+	expected := map[string]float64{"inv1": 0, "inv2": 0, "inv4": 60}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected applied invoices %v, got %v", expected, got)
+	}
+
+	if payment.Refunded != before.Refunded {
+		t.Errorf("expected Refunded to stay %v, got %v", before.Refunded, payment.Refunded)
+	}
+	if before.Unapplied() != 0 || payment.Unapplied() != 250 {
+		t.Errorf("expected Unapplied to grow from 0 to 250, got %v and %v", before.Unapplied(), payment.Unapplied())
+	}
+}
+
+func TestPaymentsServiceUnapplyNotApplied(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("expected no request besides the payment lookup, got %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "pay1", "paymentables": [
+			{"invoice_id": "inv1", "amount": 100},
+			{"invoice_id": "inv3", "amount": 40, "refunded": 40}
+		]}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	_, err := client.Payments.Unapply(context.Background(), "pay1", []string{"inv1", "inv2", "inv3"})
+	if !errors.Is(err, ErrInvoiceNotApplied) {
+		t.Fatalf("expected ErrInvoiceNotApplied, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "inv2") || !strings.Contains(err.Error(), "inv3") || strings.Contains(err.Error(), "inv1") {
+		t.Errorf("expected inv2 and inv3 to be reported, got %v", err)
+	}
+}
+
+func TestPaymentAppliedUnapplied(t *testing.T) {
+	tests := []struct {
+		name      string
+		payment   Payment
+		applied   float64
+		unapplied float64
+	}{
+		{
+			name:      "unapplied payment",
+			payment:   Payment{Amount: 100},
+			applied:   0,
+			unapplied: 100,
+		},
+		{
+			name: "partly applied to invoices",
+			payment: Payment{Amount: 100, Paymentables: []Paymentable{
+				{InvoiceID: "inv1", Amount: 40},
+				{InvoiceID: "inv2", Amount: 25.5},
+			}},
+			applied:   65.5,
+			unapplied: 34.5,
+		},
+		{
+			name: "invoice paid with cash and a credit",
+			payment: Payment{Amount: 60, Paymentables: []Paymentable{
+				{InvoiceID: "inv1", Amount: 100},
+				{CreditID: "cred1", Amount: 40},
+			}},
+			applied:   60,
+			unapplied: 0,
+		},
+		{
+			name: "credit covers part, cash left over",
+			payment: Payment{Amount: 80, Paymentables: []Paymentable{
+				{InvoiceID: "inv1", Amount: 70},
+				{CreditID: "cred1", Amount: 30},
+			}},
+			applied:   40,
+			unapplied: 40,
+		},
+		{
+			name: "refund against an invoice",
+			payment: Payment{Amount: 100, Refunded: 30, Paymentables: []Paymentable{
+				{InvoiceID: "inv1", Amount: 100, Refunded: 30},
+			}},
+			applied:   70,
+			unapplied: 0,
+		},
+		{
+			name:      "refund of unapplied money",
+			payment:   Payment{Amount: 100, Refunded: 30},
+			applied:   0,
+			unapplied: 70,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.payment.Applied(); got != tt.applied {
+				t.Errorf("Applied() = %v, want %v", got, tt.applied)
+			}
+			if got := tt.payment.Unapplied(); got != tt.unapplied {
+				t.Errorf("Unapplied() = %v, want %v", got, tt.unapplied)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package invoiceninja
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// ErrProductNotFound is returned when no product matches a product key.
+var ErrProductNotFound = errors.New("product not found")
+
+// ProductsService handles product-related API operations.
+type ProductsService struct {
+	client *Client
+}
+
+// Product represents a catalog product in Invoice Ninja.
+type Product struct {
+	ID             string  `json:"id,omitempty"`
+	UserID         string  `json:"user_id,omitempty"`
+	AssignedUserID string  `json:"assigned_user_id,omitempty"`
+	ProductKey     string  `json:"product_key,omitempty"`
+	Notes          string  `json:"notes,omitempty"`
+	Cost           float64 `json:"cost,omitempty"`
+	Price          float64 `json:"price,omitempty"`
+	Quantity       float64 `json:"quantity,omitempty"`
+	TaxName1       string  `json:"tax_name1,omitempty"`
+	TaxRate1       float64 `json:"tax_rate1,omitempty"`
+	TaxName2       string  `json:"tax_name2,omitempty"`
+	TaxRate2       float64 `json:"tax_rate2,omitempty"`
+	TaxName3       string  `json:"tax_name3,omitempty"`
+	TaxRate3       float64 `json:"tax_rate3,omitempty"`
+	CustomValue1   string  `json:"custom_value1,omitempty"`
+	CustomValue2   string  `json:"custom_value2,omitempty"`
+	CustomValue3   string  `json:"custom_value3,omitempty"`
+	CustomValue4   string  `json:"custom_value4,omitempty"`
+	IsDeleted      bool    `json:"is_deleted,omitempty"`
+	CreatedAt      int64   `json:"created_at,omitempty"`
+	UpdatedAt      int64   `json:"updated_at,omitempty"`
+	ArchivedAt     int64   `json:"archived_at,omitempty"`
+}
+
+// ProductListOptions specifies the optional parameters for listing products.
+type ProductListOptions struct {
+	PerPage    int
+	Page       int
+	Filter     string
+	ProductKey string
+	Status     string
+	IsDeleted  *bool
+	Sort       string
+	Filters    []FilterExpr
+}
+
+// toQuery converts options to URL query parameters.
+func (o *ProductListOptions) toQuery() url.Values {
+	if o == nil {
+		return nil
+	}
+
+	q := url.Values{}
+
+	if o.PerPage > 0 {
+		q.Set("per_page", strconv.Itoa(o.PerPage))
+	}
+	if o.Page > 0 {
+		q.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.Filter != "" {
+		q.Set("filter", o.Filter)
+	}
+	if o.ProductKey != "" {
+		q.Set("product_key", o.ProductKey)
+	}
+	if o.Status != "" {
+		q.Set("status", o.Status)
+	}
+	if o.IsDeleted != nil {
+		q.Set("is_deleted", strconv.FormatBool(*o.IsDeleted))
+	}
+	if o.Sort != "" {
+		q.Set("sort", o.Sort)
+	}
+
+	applyFilters(q, o.Filters)
+
+	return q
+}
+
+// List retrieves a list of products.
+func (s *ProductsService) List(ctx context.Context, opts *ProductListOptions) (*ListResponse[Product], error) {
+	var resp ListResponse[Product]
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/products"), opts.toQuery(), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Iter returns an iterator over all products matching opts, fetching pages lazily.
+// Paging starts at opts.Page (or page 1) and keeps every other option.
+func (s *ProductsService) Iter(ctx context.Context, opts *ProductListOptions) *Iterator[Product] {
+	return newIterator[Product](ctx, s.client, s.client.apiPath("/products"), opts.toQuery())
+}
+
+// ListAll retrieves all products matching opts across every page.
+// If a page fails or ctx is done mid-scan, the products fetched so far are
+// returned together with the error.
+func (s *ProductsService) ListAll(ctx context.Context, opts *ProductListOptions) ([]Product, error) {
+	return listAll(s.Iter(ctx, opts))
+}
+
+// Count returns the number of products matching opts without fetching them,
+// from the pagination total of a one-item page. Paging options are ignored.
+func (s *ProductsService) Count(ctx context.Context, opts *ProductListOptions) (int, error) {
+	return count(ctx, s.client, s.client.apiPath("/products"), opts.toQuery())
+}
+
+// Get retrieves a single product by ID.
+func (s *ProductsService) Get(ctx context.Context, id string) (*Product, error) {
+	var resp SingleResponse[Product]
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/products/%s", id), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// GetByKey retrieves the product with exactly the given product key.
+// It returns an error wrapping ErrProductNotFound if no product has that key.
+func (s *ProductsService) GetByKey(ctx context.Context, productKey string) (*Product, error) {
+	products, err := s.ListAll(ctx, &ProductListOptions{ProductKey: productKey})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range products {
+		if products[i].ProductKey == productKey {
+			return &products[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q", ErrProductNotFound, productKey)
+}
+
+// Create creates a new product.
+func (s *ProductsService) Create(ctx context.Context, product *Product, opts ...RequestOption) (*Product, error) {
+	var resp SingleResponse[Product]
+	if err := s.client.doRequest(ctx, "POST", s.client.apiPath("/products"), nil, product, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Update updates an existing product.
+func (s *ProductsService) Update(ctx context.Context, id string, product *Product, opts ...RequestOption) (*Product, error) {
+	var resp SingleResponse[Product]
+	if err := s.client.doRequest(ctx, "PUT", s.client.apiPath("/products/%s", id), nil, product, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Delete deletes a product by ID.
+func (s *ProductsService) Delete(ctx context.Context, id string, opts ...RequestOption) error {
+	return s.client.doRequest(ctx, "DELETE", s.client.apiPath("/products/%s", id), nil, nil, nil, opts...)
+}
+
+// Archive archives a product.
+func (s *ProductsService) Archive(ctx context.Context, id string) (*Product, error) {
+	return s.bulkAction(ctx, "archive", id)
+}
+
+// Restore restores an archived or deleted product.
+func (s *ProductsService) Restore(ctx context.Context, id string) (*Product, error) {
+	return s.bulkAction(ctx, "restore", id)
+}
+
+// Bulk performs a bulk action on multiple products.
+func (s *ProductsService) Bulk(ctx context.Context, action string, ids []string, opts ...RequestOption) ([]Product, error) {
+	req := BulkAction{
+		Action: action,
+		IDs:    ids,
+	}
+
+	return bulkRequest[Product](ctx, s.client, s.client.apiPath("/products/bulk"), req, opts...)
+}
+
+// BulkTyped performs a bulk action on multiple products like Bulk, but first
+// checks that action is one of BulkArchive, BulkRestore and BulkDelete,
+// returning a *ValidationError otherwise.
+func (s *ProductsService) BulkTyped(ctx context.Context, action BulkActionType, ids []string, opts ...RequestOption) ([]Product, error) {
+	if err := checkBulkAction("products", action); err != nil {
+		return nil, err
+	}
+	return s.Bulk(ctx, string(action), ids, opts...)
+}
+
+// BulkChunked performs a bulk action on a large number of products by splitting ids
+// into chunks of chunkSize (DefaultBulkChunkSize when chunkSize <= 0) and sending
+// one Bulk request per chunk. It returns the products echoed by successful chunks along
+// with a joined error of *BulkChunkError values for any chunks that failed.
+func (s *ProductsService) BulkChunked(ctx context.Context, action string, ids []string, chunkSize int, opts ...RequestOption) ([]Product, error) {
+	return bulkChunked(ctx, ids, chunkSize, func(ctx context.Context, chunk []string) ([]Product, error) {
+		return s.Bulk(ctx, action, chunk, opts...)
+	})
+}
+
+// bulkAction performs a single-item bulk action.
+func (s *ProductsService) bulkAction(ctx context.Context, action, id string) (*Product, error) {
+	products, err := s.Bulk(ctx, action, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	if len(products) == 0 {
+		return nil, fmt.Errorf("%s product: %w", action, ErrEmptyBulkResponse)
+	}
+	return &products[0], nil
+}
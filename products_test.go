@@ -0,0 +1,105 @@
+package invoiceninja
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProductsServiceList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("expected GET method, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/products" {
+			t.Errorf("expected path /api/v1/products, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{
+				{"id": "p1", "product_key": "WIDGET", "price": 10.0},
+				{"id": "p2", "product_key": "GADGET", "price": 25.0},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	resp, err := client.Products.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Data) != 2 {
+		t.Errorf("expected 2 products, got %d", len(resp.Data))
+	}
+
+	if resp.Data[1].ProductKey != "GADGET" || resp.Data[1].Price != 25 {
+		t.Errorf("unexpected second product: %+v", resp.Data[1])
+	}
+}
+
+func TestProductsServiceCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("expected POST method, got %s", r.Method)
+		}
+
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["product_key"] != "WIDGET" {
+			t.Errorf("expected product_key WIDGET, got %v", body["product_key"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"id": "p1", "product_key": "WIDGET"},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	product, err := client.Products.Create(context.Background(), &Product{ProductKey: "WIDGET", Price: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if product.ID != "p1" {
+		t.Errorf("expected ID p1, got %s", product.ID)
+	}
+}
+
+func TestProductsServiceGetByKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("product_key") == "" {
+			t.Error("expected product_key query parameter")
+		}
+
+		// The server may match loosely; only the exact key should be returned
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": [{"id": "p2", "product_key": "WIDGET-XL"}, {"id": "p1", "product_key": "WIDGET"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	product, err := client.Products.GetByKey(context.Background(), "WIDGET")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if product.ID != "p1" {
+		t.Errorf("expected exact match p1, got %s", product.ID)
+	}
+
+	_, err = client.Products.GetByKey(context.Background(), "MISSING")
+	if !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("expected ErrProductNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,311 @@
+package invoiceninja
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// QuotesService handles quote-related API operations.
+type QuotesService struct {
+	client *Client
+}
+
+// Quote represents a quote in Invoice Ninja.
+type Quote struct {
+	ID                 string       `json:"id,omitempty"`
+	UserID             string       `json:"user_id,omitempty"`
+	AssignedUserID     string       `json:"assigned_user_id,omitempty"`
+	ClientID           string       `json:"client_id,omitempty"`
+	StatusID           string       `json:"status_id,omitempty"`
+	InvoiceID          string       `json:"invoice_id,omitempty"`
+	Number             string       `json:"number,omitempty"`
+	DesignID           string       `json:"design_id,omitempty"`
+	PONumber           string       `json:"po_number,omitempty"`
+	Terms              string       `json:"terms,omitempty"`
+	PublicNotes        string       `json:"public_notes,omitempty"`
+	PrivateNotes       string       `json:"private_notes,omitempty"`
+	Footer             string       `json:"footer,omitempty"`
+	CustomValue1       string       `json:"custom_value1,omitempty"`
+	CustomValue2       string       `json:"custom_value2,omitempty"`
+	CustomValue3       string       `json:"custom_value3,omitempty"`
+	CustomValue4       string       `json:"custom_value4,omitempty"`
+	TaxName1           string       `json:"tax_name1,omitempty"`
+	TaxName2           string       `json:"tax_name2,omitempty"`
+	TaxName3           string       `json:"tax_name3,omitempty"`
+	TaxRate1           float64      `json:"tax_rate1,omitempty"`
+	TaxRate2           float64      `json:"tax_rate2,omitempty"`
+	TaxRate3           float64      `json:"tax_rate3,omitempty"`
+	TotalTaxes         float64      `json:"total_taxes,omitempty"`
+	Amount             float64      `json:"amount,omitempty"`
+	Balance            float64      `json:"balance,omitempty"`
+	Discount           float64      `json:"discount,omitempty"`
+	Partial            float64      `json:"partial,omitempty"`
+	IsAmountDiscount   bool         `json:"is_amount_discount,omitempty"`
+	IsDeleted          bool         `json:"is_deleted,omitempty"`
+	UsesInclusiveTaxes bool         `json:"uses_inclusive_taxes,omitempty"`
+	Date               string       `json:"date,omitempty"`
+	DueDate            string       `json:"due_date,omitempty"`
+	PartialDueDate     string       `json:"partial_due_date,omitempty"`
+	LastSentDate       string       `json:"last_sent_date,omitempty"`
+	LineItems          []LineItem   `json:"line_items,omitempty"`
+	Invitations        []Invitation `json:"invitations,omitempty"`
+	UpdatedAt          int64        `json:"updated_at,omitempty"`
+	ArchivedAt         int64        `json:"archived_at,omitempty"`
+	CreatedAt          int64        `json:"created_at,omitempty"`
+}
+
+// QuoteListOptions specifies the optional parameters for listing quotes.
+type QuoteListOptions struct {
+	PerPage   int
+	Page      int
+	Filter    string
+	ClientID  string
+	Status    string
+	IsDeleted *bool
+	Sort      string
+	Include   string
+	Fields    []string
+	Filters   []FilterExpr
+}
+
+// toQuery converts options to URL query parameters.
+func (o *QuoteListOptions) toQuery() url.Values {
+	if o == nil {
+		return nil
+	}
+
+	q := url.Values{}
+
+	if o.PerPage > 0 {
+		q.Set("per_page", strconv.Itoa(o.PerPage))
+	}
+	if o.Page > 0 {
+		q.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.Filter != "" {
+		q.Set("filter", o.Filter)
+	}
+	if o.ClientID != "" {
+		q.Set("client_id", o.ClientID)
+	}
+	if o.Status != "" {
+		q.Set("status", o.Status)
+	}
+	if o.IsDeleted != nil {
+		q.Set("is_deleted", strconv.FormatBool(*o.IsDeleted))
+	}
+	if o.Sort != "" {
+		q.Set("sort", o.Sort)
+	}
+	if o.Include != "" {
+		q.Set("include", o.Include)
+	}
+
+	applyFilters(q, o.Filters)
+
+	return q
+}
+
+// fieldList returns the Fields option of possibly nil options.
+func (o *QuoteListOptions) fieldList() []string {
+	if o == nil {
+		return nil
+	}
+	return o.Fields
+}
+
+// List retrieves a list of quotes.
+func (s *QuotesService) List(ctx context.Context, opts *QuoteListOptions) (*ListResponse[Quote], error) {
+	fields, err := newFieldSelection[Quote](opts.fieldList())
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ListResponse[Quote]
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/quotes"), opts.toQuery(), nil, &resp); err != nil {
+		return nil, err
+	}
+	fields.apply(resp.Data)
+	return &resp, nil
+}
+
+// Iter returns an iterator over all quotes matching opts, fetching pages lazily.
+// Paging starts at opts.Page (or page 1) and keeps every other option.
+func (s *QuotesService) Iter(ctx context.Context, opts *QuoteListOptions) *Iterator[Quote] {
+	it := newIterator[Quote](ctx, s.client, s.client.apiPath("/quotes"), opts.toQuery())
+	it.fields, it.err = newFieldSelection[Quote](opts.fieldList())
+	return it
+}
+
+// ListAll retrieves all quotes matching opts across every page.
+// If a page fails or ctx is done mid-scan, the quotes fetched so far are
+// returned together with the error.
+func (s *QuotesService) ListAll(ctx context.Context, opts *QuoteListOptions) ([]Quote, error) {
+	return listAll(s.Iter(ctx, opts))
+}
+
+// Count returns the number of quotes matching opts without fetching them,
+// from the pagination total of a one-item page. Paging options are ignored.
+func (s *QuotesService) Count(ctx context.Context, opts *QuoteListOptions) (int, error) {
+	return count(ctx, s.client, s.client.apiPath("/quotes"), opts.toQuery())
+}
+
+// Get retrieves a single quote by ID.
+func (s *QuotesService) Get(ctx context.Context, id string) (*Quote, error) {
+	var resp SingleResponse[Quote]
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/quotes/%s", id), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Create creates a new quote.
+func (s *QuotesService) Create(ctx context.Context, quote *Quote, opts ...RequestOption) (*Quote, error) {
+	var resp SingleResponse[Quote]
+	if err := s.client.doRequest(ctx, "POST", s.client.apiPath("/quotes"), nil, quote, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Update updates an existing quote.
+func (s *QuotesService) Update(ctx context.Context, id string, quote *Quote, opts ...RequestOption) (*Quote, error) {
+	var resp SingleResponse[Quote]
+	if err := s.client.doRequest(ctx, "PUT", s.client.apiPath("/quotes/%s", id), nil, quote, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Delete deletes a quote by ID.
+func (s *QuotesService) Delete(ctx context.Context, id string, opts ...RequestOption) error {
+	return s.client.doRequest(ctx, "DELETE", s.client.apiPath("/quotes/%s", id), nil, nil, nil, opts...)
+}
+
+// Archive archives a quote.
+func (s *QuotesService) Archive(ctx context.Context, id string) (*Quote, error) {
+	return s.bulkAction(ctx, "archive", id)
+}
+
+// Restore restores an archived or deleted quote.
+func (s *QuotesService) Restore(ctx context.Context, id string) (*Quote, error) {
+	return s.bulkAction(ctx, "restore", id)
+}
+
+// MarkSent marks a draft quote as sent without emailing it
+// (QuoteStatusDraft to QuoteStatusSent).
+func (s *QuotesService) MarkSent(ctx context.Context, id string) (*Quote, error) {
+	return s.bulkAction(ctx, "mark_sent", id)
+}
+
+// Email emails a quote to the client's contacts, marking a draft quote as
+// sent (QuoteStatusDraft to QuoteStatusSent).
+func (s *QuotesService) Email(ctx context.Context, id string) (*Quote, error) {
+	return s.bulkAction(ctx, "email", id)
+}
+
+// MarkApproved approves a quote on the client's behalf, e.g. after it was
+// signed outside the client portal (QuoteStatusSent to QuoteStatusApproved).
+// The server only approves sent quotes; an approved quote can then be
+// converted with BulkConvert.
+func (s *QuotesService) MarkApproved(ctx context.Context, id string) (*Quote, error) {
+	return s.bulkAction(ctx, "approve", id)
+}
+
+// Reject marks a quote as declined by the client (QuoteStatusSent to
+// QuoteStatusRejected). Servers that predate the rejected status answer
+// with an *APIError.
+func (s *QuotesService) Reject(ctx context.Context, id string) (*Quote, error) {
+	return s.bulkAction(ctx, "reject", id)
+}
+
+// Bulk performs a bulk action on multiple quotes.
+func (s *QuotesService) Bulk(ctx context.Context, action string, ids []string, opts ...RequestOption) ([]Quote, error) {
+	req := BulkAction{
+		Action: action,
+		IDs:    ids,
+	}
+
+	return bulkRequest[Quote](ctx, s.client, s.client.apiPath("/quotes/bulk"), req, opts...)
+}
+
+// BulkTyped performs a bulk action on multiple quotes like Bulk, but first
+// checks that action is one of BulkArchive, BulkRestore, BulkDelete,
+// BulkMarkSent, BulkEmail, BulkApprove, BulkReject and BulkConvertToInvoice,
+// returning a *ValidationError otherwise.
+func (s *QuotesService) BulkTyped(ctx context.Context, action BulkActionType, ids []string, opts ...RequestOption) ([]Quote, error) {
+	if err := checkBulkAction("quotes", action, BulkMarkSent, BulkEmail, BulkApprove, BulkReject, BulkConvertToInvoice); err != nil {
+		return nil, err
+	}
+	return s.Bulk(ctx, string(action), ids, opts...)
+}
+
+// BulkChunked performs a bulk action on a large number of quotes by splitting ids
+// into chunks of chunkSize (DefaultBulkChunkSize when chunkSize <= 0) and sending
+// one Bulk request per chunk. It returns the quotes echoed by successful chunks along
+// with a joined error of *BulkChunkError values for any chunks that failed.
+func (s *QuotesService) BulkChunked(ctx context.Context, action string, ids []string, chunkSize int, opts ...RequestOption) ([]Quote, error) {
+	return bulkChunked(ctx, ids, chunkSize, func(ctx context.Context, chunk []string) ([]Quote, error) {
+		return s.Bulk(ctx, action, chunk, opts...)
+	})
+}
+
+// BulkConvert converts approved quotes to invoices and returns the created
+// invoices keyed by the ID of the quote they were converted from.
+//
+// The conversion is a single bulk request; each created invoice is then
+// fetched by the invoice ID recorded on its quote. Quotes the server did not
+// convert, and invoices that could not be fetched, are reported in the joined
+// error while the remaining conversions are still returned.
+func (s *QuotesService) BulkConvert(ctx context.Context, quoteIDs []string) (map[string]*Invoice, error) {
+	quotes, err := s.Bulk(ctx, "convert_to_invoice", quoteIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	invoiceIDs := make(map[string]string, len(quotes))
+	for _, quote := range quotes {
+		invoiceIDs[quote.ID] = quote.InvoiceID
+	}
+
+	invoices := make(map[string]*Invoice, len(quoteIDs))
+	var errs []error
+
+	for _, quoteID := range quoteIDs {
+		invoiceID := invoiceIDs[quoteID]
+		if invoiceID == "" {
+			errs = append(errs, fmt.Errorf("quote %s was not converted to an invoice", quoteID))
+			continue
+		}
+
+		invoice, err := s.client.Invoices.Get(ctx, invoiceID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to fetch invoice %s for quote %s: %w", invoiceID, quoteID, err))
+			continue
+		}
+		invoices[quoteID] = invoice
+	}
+
+	return invoices, errors.Join(errs...)
+}
+
+// bulkAction performs a single-item bulk action.
+func (s *QuotesService) bulkAction(ctx context.Context, action, id string) (*Quote, error) {
+	quotes, err := s.Bulk(ctx, action, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	if len(quotes) == 0 {
+		return nil, fmt.Errorf("%s quote: %w", action, ErrEmptyBulkResponse)
+	}
+	return &quotes[0], nil
+}
+
+// Download downloads a quote PDF by invitation key.
+// It is equivalent to Downloads.DownloadQuotePDF.
+func (s *QuotesService) Download(ctx context.Context, invitationKey string) ([]byte, error) {
+	return s.client.Downloads.DownloadQuotePDF(ctx, invitationKey)
+}
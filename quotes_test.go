@@ -0,0 +1,223 @@
+package invoiceninja
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestQuotesServiceList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("expected GET method, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/quotes" {
+			t.Errorf("expected path /api/v1/quotes, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("client_id") != "client1" {
+			t.Errorf("expected client_id client1, got %s", r.URL.Query().Get("client_id"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{
+				{"id": "q1", "number": "Q-0001", "amount": 100.0},
+				{"id": "q2", "number": "Q-0002", "amount": 250.0},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	resp, err := client.Quotes.List(context.Background(), &QuoteListOptions{ClientID: "client1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Data) != 2 {
+		t.Errorf("expected 2 quotes, got %d", len(resp.Data))
+	}
+
+	if resp.Data[1].Number != "Q-0002" {
+		t.Errorf("expected second quote number Q-0002, got %s", resp.Data[1].Number)
+	}
+}
+
+func TestQuotesServiceGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/quotes/q1" {
+			t.Errorf("expected path /api/v1/quotes/q1, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"id": "q1", "status_id": "3"},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	quote, err := client.Quotes.Get(context.Background(), "q1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if quote.StatusID != "3" {
+		t.Errorf("expected status 3, got %s", quote.StatusID)
+	}
+}
+
+func TestQuotesServiceBulkConvert(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/v1/quotes/bulk":
+			var req BulkAction
+			json.NewDecoder(r.Body).Decode(&req)
+
+			if req.Action != "convert_to_invoice" {
+				t.Errorf("expected action convert_to_invoice, got %s", req.Action)
+			}
+			if strings.Join(req.IDs, ",") != "q1,q2,q3" {
+				t.Errorf("unexpected IDs: %v", req.IDs)
+			}
+
+			// Returned out of order to exercise correlation by quote ID
+			w.Write([]byte(`{"data": [
+				{"id": "q3", "invoice_id": "inv3"},
+				{"id": "q1", "invoice_id": "inv1"},
+				{"id": "q2", "invoice_id": "inv2"}
+			]}`))
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/api/v1/invoices/"):
+			id := strings.TrimPrefix(r.URL.Path, "/api/v1/invoices/")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{"id": id, "number": "N-" + id},
+			})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	invoices, err := client.Quotes.BulkConvert(context.Background(), []string{"q1", "q2", "q3"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(invoices) != 3 {
+		t.Fatalf("expected 3 invoices, got %d", len(invoices))
+	}
+
+	for quoteID, want := range map[string]string{"q1": "inv1", "q2": "inv2", "q3": "inv3"} {
+		if invoices[quoteID] == nil || invoices[quoteID].ID != want {
+			t.Errorf("expected quote %s to map to invoice %s, got %+v", quoteID, want, invoices[quoteID])
+		}
+	}
+
+	if invoices["q2"].Number != "N-inv2" {
+		t.Errorf("expected fetched invoice number N-inv2, got %s", invoices["q2"].Number)
+	}
+}
+
+func TestQuotesServiceBulkConvertPartial(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path == "/api/v1/quotes/bulk" {
+			w.Write([]byte(`{"data": [{"id": "q1", "invoice_id": "inv1"}, {"id": "q2"}]}`))
+			return
+		}
+		w.Write([]byte(`{"data": {"id": "inv1"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	invoices, err := client.Quotes.BulkConvert(context.Background(), []string{"q1", "q2"})
+	if err == nil || !strings.Contains(err.Error(), "quote q2 was not converted") {
+		t.Fatalf("expected error for unconverted quote q2, got %v", err)
+	}
+
+	if len(invoices) != 1 || invoices["q1"].ID != "inv1" {
+		t.Errorf("expected q1 conversion to be returned, got %+v", invoices)
+	}
+}
+
+func TestQuotesServiceStatusActions(t *testing.T) {
+	tests := []struct {
+		name     string
+		call     func(ctx context.Context, s *QuotesService) (*Quote, error)
+		action   string
+		statusID string
+		expected QuoteStatus
+	}{
+		{"MarkSent", func(ctx context.Context, s *QuotesService) (*Quote, error) { return s.MarkSent(ctx, "q1") }, "mark_sent", "2", QuoteStatusSent},
+		{"Email", func(ctx context.Context, s *QuotesService) (*Quote, error) { return s.Email(ctx, "q1") }, "email", "2", QuoteStatusSent},
+		{"MarkApproved", func(ctx context.Context, s *QuotesService) (*Quote, error) { return s.MarkApproved(ctx, "q1") }, "approve", "3", QuoteStatusApproved},
+		{"Reject", func(ctx context.Context, s *QuotesService) (*Quote, error) { return s.Reject(ctx, "q1") }, "reject", "5", QuoteStatusRejected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "POST" || r.URL.Path != "/api/v1/quotes/bulk" {
+					t.Errorf("expected POST /api/v1/quotes/bulk, got %s %s", r.Method, r.URL.Path)
+				}
+
+				var req BulkAction
+				json.NewDecoder(r.Body).Decode(&req)
+				if req.Action != tt.action {
+					t.Errorf("expected action %s, got %s", tt.action, req.Action)
+				}
+				if len(req.IDs) != 1 || req.IDs[0] != "q1" {
+					t.Errorf("expected ids [q1], got %v", req.IDs)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"data": []map[string]interface{}{{"id": "q1", "status_id": tt.statusID}},
+				})
+			}))
+			defer server.Close()
+
+			client := NewClient("test-token", WithBaseURL(server.URL))
+
+			quote, err := tt.call(context.Background(), client.Quotes)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if quote.Status() != tt.expected {
+				t.Errorf("expected status %s, got %s", tt.expected, quote.Status())
+			}
+		})
+	}
+}
+
+func TestQuotesServiceDownload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/quote/inv-key/download" {
+			t.Errorf("expected path /api/v1/quote/inv-key/download, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write([]byte("%PDF-1.4 quote"))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	pdf, err := client.Quotes.Download(context.Background(), "inv-key")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(pdf) != "%PDF-1.4 quote" {
+		t.Errorf("unexpected PDF content: %q", pdf)
+	}
+}
@@ -0,0 +1,289 @@
+package invoiceninja
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// RecurringInvoicesService handles recurring invoice API operations.
+type RecurringInvoicesService struct {
+	client *Client
+}
+
+// RecurringInvoice is a template from which Invoice Ninja generates invoices
+// on a schedule.
+type RecurringInvoice struct {
+	ID                 string       `json:"id,omitempty"`
+	UserID             string       `json:"user_id,omitempty"`
+	AssignedUserID     string       `json:"assigned_user_id,omitempty"`
+	ClientID           string       `json:"client_id,omitempty"`
+	StatusID           string       `json:"status_id,omitempty"`
+	FrequencyID        string       `json:"frequency_id,omitempty"`
+	RemainingCycles    int          `json:"remaining_cycles,omitempty"`
+	Number             string       `json:"number,omitempty"`
+	PONumber           string       `json:"po_number,omitempty"`
+	Terms              string       `json:"terms,omitempty"`
+	PublicNotes        string       `json:"public_notes,omitempty"`
+	PrivateNotes       string       `json:"private_notes,omitempty"`
+	Footer             string       `json:"footer,omitempty"`
+	TaxName1           string       `json:"tax_name1,omitempty"`
+	TaxName2           string       `json:"tax_name2,omitempty"`
+	TaxName3           string       `json:"tax_name3,omitempty"`
+	TaxRate1           float64      `json:"tax_rate1,omitempty"`
+	TaxRate2           float64      `json:"tax_rate2,omitempty"`
+	TaxRate3           float64      `json:"tax_rate3,omitempty"`
+	Amount             float64      `json:"amount,omitempty"`
+	Balance            float64      `json:"balance,omitempty"`
+	Discount           float64      `json:"discount,omitempty"`
+	IsAmountDiscount   bool         `json:"is_amount_discount,omitempty"`
+	UsesInclusiveTaxes bool         `json:"uses_inclusive_taxes,omitempty"`
+	AutoBill           string       `json:"auto_bill,omitempty"`
+	DueDateDays        string       `json:"due_date_days,omitempty"`
+	Date               string       `json:"date,omitempty"`
+	NextSendDate       string       `json:"next_send_date,omitempty"`
+	LastSentDate       string       `json:"last_sent_date,omitempty"`
+	LineItems          []LineItem   `json:"line_items,omitempty"`
+	Invitations        []Invitation `json:"invitations,omitempty"`
+	IsDeleted          bool         `json:"is_deleted,omitempty"`
+	UpdatedAt          int64        `json:"updated_at,omitempty"`
+	ArchivedAt         int64        `json:"archived_at,omitempty"`
+	CreatedAt          int64        `json:"created_at,omitempty"`
+}
+
+// ErrNoGeneratedInvoice is returned by RecurringInvoices.GenerateInvoice when
+// the server accepted the request but no new invoice generated from the
+// recurring invoice could be found, e.g. because it is not active.
+var ErrNoGeneratedInvoice = errors.New("no invoice was generated")
+
+// generatedInvoiceLookup is the number of the client's newest invoices that
+// GenerateInvoice searches for the generated one.
+const generatedInvoiceLookup = 20
+
+// RecurringInvoiceListOptions specifies the optional parameters for listing
+// recurring invoices.
+type RecurringInvoiceListOptions struct {
+	PerPage   int
+	Page      int
+	Filter    string
+	ClientID  string
+	Status    string
+	IsDeleted *bool
+	Sort      string
+	Filters   []FilterExpr
+}
+
+// toQuery converts options to URL query parameters.
+func (o *RecurringInvoiceListOptions) toQuery() url.Values {
+	if o == nil {
+		return nil
+	}
+
+	q := url.Values{}
+	if o.PerPage > 0 {
+		q.Set("per_page", strconv.Itoa(o.PerPage))
+	}
+	if o.Page > 0 {
+		q.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.Filter != "" {
+		q.Set("filter", o.Filter)
+	}
+	if o.ClientID != "" {
+		q.Set("client_id", o.ClientID)
+	}
+	if o.Status != "" {
+		q.Set("status", o.Status)
+	}
+	if o.IsDeleted != nil {
+		q.Set("is_deleted", strconv.FormatBool(*o.IsDeleted))
+	}
+	if o.Sort != "" {
+		q.Set("sort", o.Sort)
+	}
+
+	applyFilters(q, o.Filters)
+
+	return q
+}
+
+// List retrieves a list of recurring invoices.
+func (s *RecurringInvoicesService) List(ctx context.Context, opts *RecurringInvoiceListOptions) (*ListResponse[RecurringInvoice], error) {
+	var resp ListResponse[RecurringInvoice]
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/recurring_invoices"), opts.toQuery(), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Iter returns an iterator over all recurring invoices matching opts, fetching pages lazily.
+// Paging starts at opts.Page (or page 1) and keeps every other option.
+func (s *RecurringInvoicesService) Iter(ctx context.Context, opts *RecurringInvoiceListOptions) *Iterator[RecurringInvoice] {
+	return newIterator[RecurringInvoice](ctx, s.client, s.client.apiPath("/recurring_invoices"), opts.toQuery())
+}
+
+// ListAll retrieves all recurring invoices matching opts across every page.
+// If a page fails or ctx is done mid-scan, the recurring invoices fetched so
+// far are returned together with the error.
+func (s *RecurringInvoicesService) ListAll(ctx context.Context, opts *RecurringInvoiceListOptions) ([]RecurringInvoice, error) {
+	return listAll(s.Iter(ctx, opts))
+}
+
+// Count returns the number of recurring invoices matching opts without fetching them,
+// from the pagination total of a one-item page. Paging options are ignored.
+func (s *RecurringInvoicesService) Count(ctx context.Context, opts *RecurringInvoiceListOptions) (int, error) {
+	return count(ctx, s.client, s.client.apiPath("/recurring_invoices"), opts.toQuery())
+}
+
+// Get retrieves a single recurring invoice by ID.
+func (s *RecurringInvoicesService) Get(ctx context.Context, id string) (*RecurringInvoice, error) {
+	var resp SingleResponse[RecurringInvoice]
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/recurring_invoices/%s", id), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Create creates a new recurring invoice.
+func (s *RecurringInvoicesService) Create(ctx context.Context, invoice *RecurringInvoice, opts ...RequestOption) (*RecurringInvoice, error) {
+	var resp SingleResponse[RecurringInvoice]
+	if err := s.client.doRequest(ctx, "POST", s.client.apiPath("/recurring_invoices"), nil, invoice, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Update updates an existing recurring invoice.
+func (s *RecurringInvoicesService) Update(ctx context.Context, id string, invoice *RecurringInvoice, opts ...RequestOption) (*RecurringInvoice, error) {
+	var resp SingleResponse[RecurringInvoice]
+	if err := s.client.doRequest(ctx, "PUT", s.client.apiPath("/recurring_invoices/%s", id), nil, invoice, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Delete deletes a recurring invoice by ID.
+func (s *RecurringInvoicesService) Delete(ctx context.Context, id string, opts ...RequestOption) error {
+	return s.client.doRequest(ctx, "DELETE", s.client.apiPath("/recurring_invoices/%s", id), nil, nil, nil, opts...)
+}
+
+// Bulk performs a bulk action on multiple recurring invoices. Actions include
+// "archive", "restore", "delete", "start", "stop" and "send_now".
+func (s *RecurringInvoicesService) Bulk(ctx context.Context, action string, ids []string, opts ...RequestOption) ([]RecurringInvoice, error) {
+	req := BulkAction{
+		Action: action,
+		IDs:    ids,
+	}
+
+	return bulkRequest[RecurringInvoice](ctx, s.client, s.client.apiPath("/recurring_invoices/bulk"), req, opts...)
+}
+
+// BulkTyped performs a bulk action on multiple recurring invoices like Bulk,
+// but first checks that action is one of BulkArchive, BulkRestore, BulkDelete,
+// BulkStart, BulkStop and BulkSendNow, returning a *ValidationError otherwise.
+func (s *RecurringInvoicesService) BulkTyped(ctx context.Context, action BulkActionType, ids []string, opts ...RequestOption) ([]RecurringInvoice, error) {
+	if err := checkBulkAction("recurring invoices", action, BulkStart, BulkStop, BulkSendNow); err != nil {
+		return nil, err
+	}
+	return s.Bulk(ctx, string(action), ids, opts...)
+}
+
+// BulkChunked performs a bulk action on a large number of recurring invoices by splitting ids
+// into chunks of chunkSize (DefaultBulkChunkSize when chunkSize <= 0) and sending
+// one Bulk request per chunk. It returns the recurring invoices echoed by successful chunks along
+// with a joined error of *BulkChunkError values for any chunks that failed.
+func (s *RecurringInvoicesService) BulkChunked(ctx context.Context, action string, ids []string, chunkSize int, opts ...RequestOption) ([]RecurringInvoice, error) {
+	return bulkChunked(ctx, ids, chunkSize, func(ctx context.Context, chunk []string) ([]RecurringInvoice, error) {
+		return s.Bulk(ctx, action, chunk, opts...)
+	})
+}
+
+// Archive archives a recurring invoice.
+func (s *RecurringInvoicesService) Archive(ctx context.Context, id string) (*RecurringInvoice, error) {
+	return s.bulkAction(ctx, "archive", id)
+}
+
+// Restore restores an archived or deleted recurring invoice.
+func (s *RecurringInvoicesService) Restore(ctx context.Context, id string) (*RecurringInvoice, error) {
+	return s.bulkAction(ctx, "restore", id)
+}
+
+// Start activates a recurring invoice so that it generates invoices on its schedule.
+func (s *RecurringInvoicesService) Start(ctx context.Context, id string) (*RecurringInvoice, error) {
+	return s.bulkAction(ctx, "start", id)
+}
+
+// Stop pauses a recurring invoice.
+func (s *RecurringInvoicesService) Stop(ctx context.Context, id string) (*RecurringInvoice, error) {
+	return s.bulkAction(ctx, "stop", id)
+}
+
+// GenerateInvoice generates the next invoice from the active recurring invoice
+// with the given ID immediately, off its schedule, using the bulk "send_now"
+// action, and returns the created invoice. The invoice is handled as a
+// scheduled one would be, so it is emailed if the recurring invoice is set up
+// to send. The schedule moves on to the following date.
+//
+// The server answers with the recurring invoice only, so the generated invoice
+// is looked up among the client's newest invoices by its RecurringID. Only an
+// invoice newer than the last one generated before the call is accepted; an
+// error matching ErrNoGeneratedInvoice is returned if there is none.
+func (s *RecurringInvoicesService) GenerateInvoice(ctx context.Context, id string) (*Invoice, error) {
+	recurring, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := s.generatedInvoices(ctx, recurring.ClientID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.bulkAction(ctx, "send_now", id); err != nil {
+		return nil, err
+	}
+
+	after, err := s.generatedInvoices(ctx, recurring.ClientID, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(after) == 0 || (len(before) > 0 && after[0].ID == before[0].ID) {
+		return nil, fmt.Errorf("recurring invoice %s: %w", id, ErrNoGeneratedInvoice)
+	}
+	return &after[0], nil
+}
+
+// generatedInvoices returns the invoices generated from the recurring invoice
+// id among the client's newest ones, newest first.
+func (s *RecurringInvoicesService) generatedInvoices(ctx context.Context, clientID, id string) ([]Invoice, error) {
+	invoices, err := s.client.Invoices.List(ctx, &InvoiceListOptions{
+		ClientID: clientID,
+		Sort:     SortBy(SortFieldID, true),
+		PerPage:  generatedInvoiceLookup,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find invoices generated from recurring invoice %s: %w", id, err)
+	}
+
+	var generated []Invoice
+	for _, invoice := range invoices.Data {
+		if invoice.RecurringID == id {
+			generated = append(generated, invoice)
+		}
+	}
+	return generated, nil
+}
+
+// bulkAction performs a single-item bulk action.
+func (s *RecurringInvoicesService) bulkAction(ctx context.Context, action, id string) (*RecurringInvoice, error) {
+	invoices, err := s.Bulk(ctx, action, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	if len(invoices) == 0 {
+		return nil, fmt.Errorf("%s recurring invoice: %w", action, ErrEmptyBulkResponse)
+	}
+	return &invoices[0], nil
+}
@@ -0,0 +1,104 @@
+package invoiceninja
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRecurringInvoicesServiceList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/recurring_invoices" {
+			t.Errorf("expected path /api/v1/recurring_invoices, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("client_id") != "c1" {
+			t.Errorf("expected client_id=c1, got %q", r.URL.Query().Get("client_id"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": [{"id": "r1", "client_id": "c1", "frequency_id": "5", "remaining_cycles": -1, "next_send_date": "2026-11-01"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	resp, err := client.RecurringInvoices.List(context.Background(), &RecurringInvoiceListOptions{ClientID: "c1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].FrequencyID != "5" || resp.Data[0].RemainingCycles != -1 || resp.Data[0].NextSendDate != "2026-11-01" {
+		t.Errorf("unexpected recurring invoices: %+v", resp.Data)
+	}
+}
+
+func TestRecurringInvoicesServiceGenerateInvoice(t *testing.T) {
+	tests := []struct {
+		name      string
+		generates bool
+		wantID    string
+	}{
+		{"new invoice", true, "i4"},
+		{"only older invoices", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				switch r.Method + " " + r.URL.Path {
+				case "GET /api/v1/recurring_invoices/r1":
+					w.Write([]byte(`{"data": {"id": "r1", "client_id": "c1"}}`))
+				case "POST /api/v1/recurring_invoices/bulk":
+					var body BulkAction
+					json.NewDecoder(r.Body).Decode(&body)
+					if body.Action != "send_now" || len(body.IDs) != 1 {
+						t.Errorf("expected send_now for one ID, got %q for %v", body.Action, body.IDs)
+					}
+					sent = true
+					w.Write([]byte(`{"data": [{"id": "` + body.IDs[0] + `", "client_id": "c1", "next_send_date": "2026-12-01"}]}`))
+				case "GET /api/v1/invoices":
+					q := r.URL.Query()
+					if q.Get("client_id") != "c1" || q.Get("sort") != "id|desc" {
+						t.Errorf("unexpected invoice lookup query: %v", q)
+					}
+					newest := ""
+					if sent && tt.generates {
+						newest = `{"id": "i4", "number": "0004", "recurring_id": "r1"},`
+					}
+					w.Write([]byte(`{"data": [` + newest + `
+						{"id": "i3", "number": "0003"},
+						{"id": "i2", "number": "0002", "recurring_id": "r1"},
+						{"id": "i1", "number": "0001", "recurring_id": "r1"}
+					]}`))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			}))
+			defer server.Close()
+
+			client := NewClient("test-token", WithBaseURL(server.URL))
+
+			invoice, err := client.RecurringInvoices.GenerateInvoice(context.Background(), "r1")
+			if !sent {
+				t.Error("expected send_now to be sent")
+			}
+			if tt.wantID == "" {
+				if !errors.Is(err, ErrNoGeneratedInvoice) {
+					t.Errorf("expected ErrNoGeneratedInvoice for an older invoice, got %v, %+v", err, invoice)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if invoice.ID != tt.wantID || invoice.RecurringID != "r1" {
+				t.Errorf("expected the new invoice of r1, got %+v", invoice)
+			}
+		})
+	}
+}
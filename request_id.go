@@ -0,0 +1,34 @@
+package invoiceninja
+
+import (
+	"context"
+	"net/http"
+)
+
+// RequestIDHeader is the header carrying the correlation ID of a request and
+// of its response.
+const RequestIDHeader = "X-Request-Id"
+
+// requestIDKey is the context key for the request ID.
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying a correlation ID. Every
+// API request made with the returned context sends it in the X-Request-Id
+// header, so SDK calls can be matched with your own logs.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the correlation ID set by ContextWithRequestID,
+// or an empty string.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// setRequestID sets the X-Request-Id header from the request's context.
+func setRequestID(req *http.Request) {
+	if id := RequestIDFromContext(req.Context()); id != "" {
+		req.Header.Set(RequestIDHeader, id)
+	}
+}
@@ -0,0 +1,64 @@
+package invoiceninja
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestContextWithRequestID(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("X-Request-Id"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	ctx := ContextWithRequestID(context.Background(), "req-123")
+	if _, err := client.Invoices.List(ctx, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Invoices.List(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got[0] != "req-123" {
+		t.Errorf("expected X-Request-Id req-123, got %q", got[0])
+	}
+	if got[1] != "" {
+		t.Errorf("expected no X-Request-Id without a context ID, got %q", got[1])
+	}
+
+	if RequestIDFromContext(ctx) != "req-123" || RequestIDFromContext(context.Background()) != "" {
+		t.Error("unexpected RequestIDFromContext result")
+	}
+}
+
+func TestAPIErrorRequestID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "srv-456")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"message": "boom"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	_, err := client.Invoices.Get(context.Background(), "inv1")
+	apiErr, ok := IsAPIError(err)
+	if !ok {
+		t.Fatalf("expected APIError, got %v", err)
+	}
+	if apiErr.RequestID != "srv-456" {
+		t.Errorf("expected RequestID srv-456, got %q", apiErr.RequestID)
+	}
+	if !strings.Contains(apiErr.Error(), "request ID srv-456") {
+		t.Errorf("expected request ID in error message, got %q", apiErr.Error())
+	}
+}
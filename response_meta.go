@@ -0,0 +1,62 @@
+package invoiceninja
+
+import (
+	"context"
+	"net/http"
+)
+
+// ResponseMeta holds the status and headers of an API response, which the
+// decoded entities do not carry.
+type ResponseMeta struct {
+	StatusCode int
+	Header     http.Header
+
+	// RateLimit is parsed from the X-RateLimit-* headers, or nil if the
+	// response has none.
+	RateLimit *RateLimitInfo
+}
+
+// responseMetaKey is the context key for the *ResponseMeta to fill.
+type responseMetaKey struct{}
+
+// ContextWithResponseMeta returns a copy of ctx that makes API requests made
+// with it record their response in meta, so the typed methods can surface
+// headers such as the ETag:
+//
+//	var meta invoiceninja.ResponseMeta
+//	invoice, err := client.Invoices.Get(invoiceninja.ContextWithResponseMeta(ctx, &meta), id)
+//	etag := meta.Header.Get("ETag")
+//
+// meta is filled even when the server returns an error status. If the call
+// makes several requests, such as a retried request or a ListAll, meta holds
+// the last response. Do not share meta between concurrent calls.
+func ContextWithResponseMeta(ctx context.Context, meta *ResponseMeta) context.Context {
+	return context.WithValue(ctx, responseMetaKey{}, meta)
+}
+
+// recordResponseMeta fills the *ResponseMeta carried by ctx, if any, from resp.
+func recordResponseMeta(ctx context.Context, resp *rawResponse) {
+	meta, _ := ctx.Value(responseMetaKey{}).(*ResponseMeta)
+	if meta == nil {
+		return
+	}
+
+	meta.StatusCode = resp.statusCode
+	meta.Header = resp.header.Clone()
+	meta.RateLimit = nil
+	if meta.Header.Get("X-RateLimit-Limit") != "" || meta.Header.Get("X-RateLimit-Remaining") != "" {
+		meta.RateLimit = ParseRateLimitHeaders(meta.Header)
+	}
+}
+
+// DoWithMeta performs a generic API request like Request and also returns the
+// status and headers of the response. The meta is returned when the server
+// answers with an error status too.
+func (c *Client) DoWithMeta(ctx context.Context, method, path string, body, result interface{}, opts ...RequestOption) (*ResponseMeta, error) {
+	meta := &ResponseMeta{}
+	err := c.doRequest(ContextWithResponseMeta(ctx, meta), method, path, nil, body, result, opts...)
+	if meta.StatusCode == 0 {
+		return nil, err
+	}
+	return meta, err
+}
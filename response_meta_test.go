@@ -0,0 +1,80 @@
+package invoiceninja
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestContextWithResponseMeta(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v42"`)
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "97")
+		w.Write([]byte(`{"data": {"id": "inv1"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	var meta ResponseMeta
+	invoice, err := client.Invoices.Get(ContextWithResponseMeta(context.Background(), &meta), "inv1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if invoice.ID != "inv1" {
+		t.Errorf("expected invoice inv1, got %q", invoice.ID)
+	}
+
+	if meta.StatusCode != http.StatusOK || meta.Header.Get("ETag") != `"v42"` {
+		t.Errorf("unexpected meta: %+v", meta)
+	}
+	if meta.RateLimit == nil || meta.RateLimit.Limit != 100 || meta.RateLimit.Remaining != 97 {
+		t.Errorf("unexpected rate limit: %+v", meta.RateLimit)
+	}
+}
+
+func TestClientDoWithMeta(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/expenses/limited" {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data": {"id": "exp1"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	var resp SingleResponse[map[string]interface{}]
+	meta, err := client.DoWithMeta(ctx, "POST", "/api/v1/expenses", map[string]float64{"amount": 10}, &resp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if meta.StatusCode != http.StatusCreated || meta.RateLimit != nil {
+		t.Errorf("unexpected meta: %+v", meta)
+	}
+	if resp.Data["id"] != "exp1" {
+		t.Errorf("expected decoded body, got %v", resp.Data)
+	}
+
+	meta, err = client.DoWithMeta(ctx, "GET", "/api/v1/expenses/limited", nil, nil)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited, got %v", err)
+	}
+	if meta == nil || meta.StatusCode != http.StatusTooManyRequests || meta.Header.Get("Retry-After") != "30" {
+		t.Errorf("expected meta for the error response, got %+v", meta)
+	}
+
+	client.SetBaseURL("http://127.0.0.1:1")
+	if meta, err := client.DoWithMeta(ctx, "GET", "/api/v1/expenses", nil, nil); err == nil || meta != nil {
+		t.Errorf("expected an error and no meta without a response, got %+v, %v", meta, err)
+	}
+}
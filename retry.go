@@ -2,12 +2,19 @@ package invoiceninja
 
 import (
 	"context"
-	"crypto/rand"
+	crand "crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+	"log/slog"
 	"math"
-	"math/big"
+	"math/rand"
+	"net"
 	"net/http"
+	"net/url"
 	"strconv"
 	"sync"
+	"syscall"
 	"time"
 )
 
@@ -30,8 +37,35 @@ type RetryConfig struct {
 
 	// Jitter adds randomness to backoff to prevent thundering herd.
 	Jitter bool
+
+	// RetryUnsafeMethods also retries POST and PATCH requests that carry no
+	// idempotency key. Such a request may have been processed even though
+	// its response was lost, so retrying it can, for example, create an
+	// invoice twice, so by default they are not retried, not even after a
+	// 429 response.
+	RetryUnsafeMethods bool
+
+	// RandSource supplies the randomness for jitter. When nil, a source backed
+	// by crypto/rand is used. Set it to a seeded source, e.g. rand.NewSource(1),
+	// to make backoff durations reproducible in tests.
+	RandSource rand.Source
+}
+
+// cryptoSource is a rand.Source backed by crypto/rand.
+type cryptoSource struct{}
+
+// Int63 returns a non-negative random 63-bit integer.
+func (cryptoSource) Int63() int64 {
+	var b [8]byte
+	if _, err := crand.Read(b[:]); err != nil {
+		return 0
+	}
+	return int64(binary.LittleEndian.Uint64(b[:]) & math.MaxInt64)
 }
 
+// Seed is a no-op; crypto/rand cannot be seeded.
+func (cryptoSource) Seed(int64) {}
+
 // DefaultRetryConfig returns the default retry configuration.
 func DefaultRetryConfig() *RetryConfig {
 	return &RetryConfig{
@@ -50,15 +84,23 @@ type RateLimiter struct {
 	requestsLimit int
 	windowSize    time.Duration
 	requests      []time.Time
+	clock         Clock
 }
 
 // NewRateLimiter creates a new rate limiter.
 // requestsPerSecond specifies the maximum requests per second allowed.
 func NewRateLimiter(requestsPerSecond int) *RateLimiter {
+	return NewRateLimiterWithClock(requestsPerSecond, realClock{})
+}
+
+// NewRateLimiterWithClock creates a rate limiter that reads the time from
+// clock, e.g. a fake clock in tests.
+func NewRateLimiterWithClock(requestsPerSecond int, clock Clock) *RateLimiter {
 	return &RateLimiter{
 		requestsLimit: requestsPerSecond,
 		windowSize:    time.Second,
 		requests:      make([]time.Time, 0, requestsPerSecond),
+		clock:         clock,
 	}
 }
 
@@ -67,7 +109,7 @@ func (r *RateLimiter) Wait(ctx context.Context) error {
 	for {
 		r.mu.Lock()
 
-		now := time.Now()
+		now := r.clock.Now()
 
 		// Remove expired requests from the window
 		cutoff := now.Add(-r.windowSize)
@@ -88,7 +130,7 @@ func (r *RateLimiter) Wait(ctx context.Context) error {
 
 			if waitTime > 0 {
 				select {
-				case <-time.After(waitTime):
+				case <-r.clock.After(waitTime):
 					// Retry the loop
 					continue
 				case <-ctx.Done():
@@ -99,7 +141,7 @@ func (r *RateLimiter) Wait(ctx context.Context) error {
 		}
 
 		// Record this request and return
-		r.requests = append(r.requests, time.Now())
+		r.requests = append(r.requests, r.clock.Now())
 		r.mu.Unlock()
 		return nil
 	}
@@ -110,58 +152,88 @@ type RateLimitedClient struct {
 	*Client
 	rateLimiter *RateLimiter
 	retryConfig *RetryConfig
+
+	// jitterMu guards RandSource, which need not be safe for concurrent use.
+	jitterMu sync.Mutex
 }
 
 // NewRateLimitedClient creates a new client with rate limiting and retry logic.
 func NewRateLimitedClient(apiToken string, opts ...ClientOption) *RateLimitedClient {
 	client := NewClient(apiToken, opts...)
-	return &RateLimitedClient{
+	rlc := &RateLimitedClient{
 		Client:      client,
-		rateLimiter: NewRateLimiter(10), // Default: 10 requests per second
+		rateLimiter: NewRateLimiterWithClock(10, client.timeSource()), // Default: 10 requests per second
 		retryConfig: DefaultRetryConfig(),
 	}
+
+	// Route paginated list requests and bulk actions through rate limiting
+	// and retries
+	client.retrier = rlc
+
+	return rlc
 }
 
 // SetRateLimit sets the rate limit for API requests.
+// Unlike SetBaseURL, it must not be called while requests are in flight.
 func (c *RateLimitedClient) SetRateLimit(requestsPerSecond int) {
-	c.rateLimiter = NewRateLimiter(requestsPerSecond)
+	c.rateLimiter = NewRateLimiterWithClock(requestsPerSecond, c.timeSource())
 }
 
 // SetRetryConfig sets the retry configuration.
+// Unlike SetBaseURL, it must not be called while requests are in flight.
 func (c *RateLimitedClient) SetRetryConfig(config *RetryConfig) {
 	c.retryConfig = config
 }
 
 // DoRequestWithRetry performs a request with rate limiting and retry logic.
 // This method provides automatic retries with exponential backoff for transient errors.
-func (c *RateLimitedClient) DoRequestWithRetry(ctx context.Context, method, path string, query, body, result interface{}) error {
+// POST and PATCH requests are retried only with WithIdempotencyKey; see
+// RetryConfig.RetryUnsafeMethods. query may be nil or a url.Values.
+func (c *RateLimitedClient) DoRequestWithRetry(ctx context.Context, method, path string, query, body, result interface{}, opts ...RequestOption) error {
 	var lastErr error
+	values, _ := query.(url.Values)
 
 	for attempt := 0; attempt <= c.retryConfig.MaxRetries; attempt++ {
+		// Fail fast while the circuit is open
+		probe, err := c.breaker.allow()
+		if err != nil {
+			return err
+		}
+
 		// Wait for rate limit
 		if err := c.rateLimiter.Wait(ctx); err != nil {
+			c.breaker.release(probe)
 			return err
 		}
 
 		// Make the request
-		err := c.Client.doRequest(ctx, method, path, nil, body, result)
+		err = c.Client.doRequest(ctx, method, path, values, body, result, opts...)
 		if err == nil {
+			c.breaker.record(probe, false)
 			return nil
 		}
 
 		lastErr = err
 
+		// A request cut short by its context says nothing about the server
+		if ctx.Err() != nil {
+			c.breaker.release(probe)
+		} else {
+			c.breaker.record(probe, c.isRetryable(err))
+		}
+
 		// Check if we should retry
-		if !c.shouldRetry(err, attempt) {
+		if !c.shouldRetry(err, attempt) || !c.canRetry(method, opts) {
 			return err
 		}
 
 		// Calculate backoff
 		backoff := c.calculateBackoff(attempt, err)
+		c.logRetry(ctx, method, path, attempt+1, err, backoff)
 
 		// Wait before retrying
 		select {
-		case <-time.After(backoff):
+		case <-c.timeSource().After(backoff):
 		case <-ctx.Done():
 			return ctx.Err()
 		}
@@ -170,16 +242,70 @@ func (c *RateLimitedClient) DoRequestWithRetry(ctx context.Context, method, path
 	return lastErr
 }
 
+// logRetry logs a retry at debug level: the retry number, the status code or
+// error that caused it and the backoff before it is sent.
+func (c *RateLimitedClient) logRetry(ctx context.Context, method, path string, retry int, err error, backoff time.Duration) {
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("path", path),
+		slog.Int("attempt", retry),
+		slog.Int("max_retries", c.retryConfig.MaxRetries),
+		slog.Duration("backoff", backoff),
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		attrs = append(attrs, slog.Int("status", apiErr.StatusCode))
+	} else {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	c.log().LogAttrs(ctx, slog.LevelDebug, "invoiceninja: retrying request", attrs...)
+}
+
+// isTransientNetworkError reports whether a request error is worth retrying:
+// a network timeout, a refused or reset connection, or a connection closed
+// while the response was being read. Cancellation, TLS verification failures,
+// request construction and decoding errors are not retried.
+func isTransientNetworkError(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
 // shouldRetry determines if a request should be retried.
 func (c *RateLimitedClient) shouldRetry(err error, attempt int) bool {
 	if attempt >= c.retryConfig.MaxRetries {
 		return false
 	}
+	return c.isRetryable(err)
+}
 
+// canRetry reports whether a failed request with the given method may be
+// sent again. GET, HEAD, OPTIONS, PUT and DELETE are idempotent. POST and
+// PATCH are retried only with an idempotency key or with RetryUnsafeMethods.
+func (c *RateLimitedClient) canRetry(method string, opts []RequestOption) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return c.retryConfig.RetryUnsafeMethods || hasIdempotencyKey(opts)
+}
+
+// isRetryable reports whether err is a transient network error or an API
+// error with a status code in RetryOnStatusCodes.
+func (c *RateLimitedClient) isRetryable(err error) bool {
 	apiErr, ok := IsAPIError(err)
 	if !ok {
-		// Network errors should be retried
-		return true
+		return isTransientNetworkError(err)
 	}
 
 	// Check if status code is in retry list
@@ -206,12 +332,8 @@ func (c *RateLimitedClient) calculateBackoff(attempt int, err error) time.Durati
 
 	// Apply jitter
 	if c.retryConfig.Jitter {
-		// Use crypto/rand for secure random number generation
-		randInt, randErr := rand.Int(rand.Reader, big.NewInt(1000))
-		if randErr == nil {
-			jitter := (float64(randInt.Int64()) / 1000.0) * 0.3 * backoff // Up to 30% jitter
-			backoff += jitter
-		}
+		jitter := (float64(c.randInt63()%1000) / 1000.0) * 0.3 * backoff // Up to 30% jitter
+		backoff += jitter
 	}
 
 	// Cap at max backoff
@@ -256,3 +378,15 @@ func ParseRateLimitHeaders(headers http.Header) *RateLimitInfo {
 
 	return info
 }
+
+// randInt63 draws from the configured jitter source.
+func (c *RateLimitedClient) randInt63() int64 {
+	src := c.retryConfig.RandSource
+	if src == nil {
+		src = cryptoSource{}
+	}
+
+	c.jitterMu.Lock()
+	defer c.jitterMu.Unlock()
+	return src.Int63()
+}
@@ -1,10 +1,26 @@
 package invoiceninja
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"math/rand"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
 	"sync"
 	"sync/atomic"
+	"syscall"
 	"testing"
 	"time"
 )
@@ -290,3 +306,300 @@ func TestCalculateBackoffRateLimited(t *testing.T) {
 		t.Errorf("expected 60s backoff for rate limited, got %v", backoff)
 	}
 }
+
+// fixedSource is a rand.Source that always returns the same value.
+type fixedSource int64
+
+func (s fixedSource) Int63() int64 { return int64(s) }
+func (s fixedSource) Seed(int64)   {}
+
+func TestCalculateBackoffWithJitterSource(t *testing.T) {
+	client := NewRateLimitedClient("test-token")
+	client.retryConfig.RandSource = fixedSource(1500) // 1500 % 1000 = 500 -> 15% jitter
+
+	tests := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{attempt: 0, expected: 1150 * time.Millisecond},
+		{attempt: 1, expected: 2300 * time.Millisecond},
+		{attempt: 2, expected: 4600 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		result := client.calculateBackoff(tt.attempt, &APIError{StatusCode: 500})
+		if result != tt.expected {
+			t.Errorf("attempt %d: calculateBackoff() = %v, want %v", tt.attempt, result, tt.expected)
+		}
+	}
+}
+
+func TestCalculateBackoffSeededSourceIsReproducible(t *testing.T) {
+	backoffs := func() []time.Duration {
+		client := NewRateLimitedClient("test-token")
+		client.retryConfig.RandSource = rand.NewSource(42)
+
+		var out []time.Duration
+		for attempt := 0; attempt < 5; attempt++ {
+			out = append(out, client.calculateBackoff(attempt, &APIError{StatusCode: 503}))
+		}
+		return out
+	}
+
+	first, second := backoffs(), backoffs()
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("attempt %d: expected identical backoff with same seed, got %v and %v", i, first[i], second[i])
+		}
+
+		base := time.Duration(float64(time.Second) * math.Pow(2, float64(i)))
+		if first[i] < base || first[i] > base+base*3/10 {
+			t.Errorf("attempt %d: backoff %v outside 30%% jitter range of %v", i, first[i], base)
+		}
+	}
+}
+
+func TestCryptoSource(t *testing.T) {
+	var src cryptoSource
+	for i := 0; i < 100; i++ {
+		if v := src.Int63(); v < 0 {
+			t.Fatalf("expected non-negative value, got %d", v)
+		}
+	}
+}
+
+// timeoutError is a net.Error that reports a timeout.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestShouldRetryNetworkErrors(t *testing.T) {
+	client := NewRateLimitedClient("test-token")
+
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"timeout", fmt.Errorf("request failed: %w", &url.Error{Op: "Get", URL: "u", Err: timeoutError{}}), true},
+		{"connection refused", fmt.Errorf("request failed: %w", &url.Error{Op: "Get", URL: "u", Err: refused}), true},
+		{"EOF", fmt.Errorf("request failed: %w", &url.Error{Op: "Get", URL: "u", Err: io.EOF}), true},
+		{"unexpected EOF reading body", fmt.Errorf("failed to read response body: %w", io.ErrUnexpectedEOF), true},
+		{"context canceled", fmt.Errorf("request failed: %w", &url.Error{Op: "Get", URL: "u", Err: context.Canceled}), false},
+		{"bare context canceled", context.Canceled, false},
+		{"TLS verification", fmt.Errorf("request failed: %w", &url.Error{Op: "Get", URL: "u", Err: &tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}}}), false},
+		{"request construction", fmt.Errorf("failed to create request: %w", errors.New(`net/http: invalid method "BAD METHOD"`)), false},
+		{"invalid URL", fmt.Errorf("invalid URL: %w", &url.Error{Op: "parse", URL: "::", Err: errors.New("missing protocol scheme")}), false},
+		{"decode error", &DecodeError{Method: "GET", Path: "/api/v1/payments", Err: errors.New("bad json")}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := client.shouldRetry(tt.err, 0); result != tt.expected {
+				t.Errorf("shouldRetry(%v) = %v, want %v", tt.err, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDoRequestWithRetryDoesNotRetryCancellation(t *testing.T) {
+	var attempts int32
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&attempts, 1)
+		return nil, context.Canceled
+	})
+
+	client := NewRateLimitedClient("test-token", WithHTTPClient(&http.Client{Transport: transport}))
+	client.SetRetryConfig(&RetryConfig{
+		MaxRetries:        3,
+		InitialBackoff:    time.Millisecond,
+		MaxBackoff:        time.Millisecond,
+		BackoffMultiplier: 1,
+	})
+
+	err := client.DoRequestWithRetry(context.Background(), "GET", "/api/v1/payments", nil, nil, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if n := atomic.LoadInt32(&attempts); n != 1 {
+		t.Errorf("expected 1 attempt, got %d", n)
+	}
+}
+
+func TestDoRequestWithRetryRetriesConnectionRefused(t *testing.T) {
+	var attempts int32
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			return nil, &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"data":{"id":"pay1"}}`)),
+		}, nil
+	})
+
+	client := NewRateLimitedClient("test-token", WithHTTPClient(&http.Client{Transport: transport}))
+	client.SetRetryConfig(&RetryConfig{
+		MaxRetries:        3,
+		InitialBackoff:    time.Millisecond,
+		MaxBackoff:        time.Millisecond,
+		BackoffMultiplier: 1,
+	})
+
+	var resp SingleResponse[Payment]
+	if err := client.DoRequestWithRetry(context.Background(), "GET", "/api/v1/payments/pay1", nil, nil, &resp); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Data.ID != "pay1" || atomic.LoadInt32(&attempts) != 3 {
+		t.Errorf("expected success on attempt 3, got %q after %d attempts", resp.Data.ID, attempts)
+	}
+}
+
+func TestDoRequestWithRetryLogsAttempts(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"id":"inv1"}}`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client := NewRateLimitedClient("test-token", WithBaseURL(server.URL), WithLogger(logger))
+	client.SetRetryConfig(&RetryConfig{
+		MaxRetries:         3,
+		InitialBackoff:     time.Millisecond,
+		MaxBackoff:         time.Millisecond,
+		BackoffMultiplier:  1,
+		RetryOnStatusCodes: []int{http.StatusServiceUnavailable},
+	})
+
+	if err := client.DoRequestWithRetry(context.Background(), "GET", "/api/v1/invoices/inv1", nil, nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 retry log lines, got %d: %s", len(lines), logs.String())
+	}
+	for i, line := range lines {
+		var entry struct {
+			Level      string  `json:"level"`
+			Msg        string  `json:"msg"`
+			Method     string  `json:"method"`
+			Path       string  `json:"path"`
+			Attempt    int     `json:"attempt"`
+			MaxRetries int     `json:"max_retries"`
+			Status     int     `json:"status"`
+			Backoff    float64 `json:"backoff"`
+		}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		if entry.Level != "DEBUG" || entry.Msg != "invoiceninja: retrying request" {
+			t.Errorf("unexpected log entry: %s", line)
+		}
+		if entry.Method != "GET" || entry.Path != "/api/v1/invoices/inv1" || entry.Attempt != i+1 || entry.MaxRetries != 3 {
+			t.Errorf("unexpected request details: %s", line)
+		}
+		if entry.Status != http.StatusServiceUnavailable || entry.Backoff != float64(time.Millisecond) {
+			t.Errorf("expected status 503 and a 1ms backoff: %s", line)
+		}
+	}
+
+	logs.Reset()
+	client.SetBaseURL("http://127.0.0.1:1")
+	client.SetRetryConfig(&RetryConfig{MaxRetries: 1, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, BackoffMultiplier: 1})
+	client.DoRequestWithRetry(context.Background(), "GET", "/api/v1/invoices/inv1", nil, nil, nil)
+	if !strings.Contains(logs.String(), `"error":"request failed:`) {
+		t.Errorf("expected the network error to be logged, got %s", logs.String())
+	}
+}
+
+func TestDoRequestWithRetryMethodGating(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		opts     []RequestOption
+		unsafe   bool
+		attempts int32
+	}{
+		{"GET is retried", "GET", nil, false, 3},
+		{"PUT is retried", "PUT", nil, false, 3},
+		{"DELETE is retried", "DELETE", nil, false, 3},
+		{"POST without key is not retried", "POST", nil, false, 1},
+		{"PATCH without key is not retried", "PATCH", nil, false, 1},
+		{"POST with idempotency key is retried", "POST", []RequestOption{WithIdempotencyKey("k1")}, false, 3},
+		{"POST with key set by header is retried", "POST", []RequestOption{WithHeader("X-Idempotency-Key", "k1")}, false, 3},
+		{"POST with other headers is not retried", "POST", []RequestOption{WithHeader("X-Api-Company-Key", "c1")}, false, 1},
+		{"POST with RetryUnsafeMethods is retried", "POST", nil, true, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&attempts, 1)
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer server.Close()
+
+			client := NewRateLimitedClient("test-token", WithBaseURL(server.URL))
+			client.SetRetryConfig(&RetryConfig{
+				MaxRetries:         2,
+				InitialBackoff:     time.Millisecond,
+				MaxBackoff:         time.Millisecond,
+				BackoffMultiplier:  1,
+				RetryOnStatusCodes: []int{http.StatusServiceUnavailable},
+				RetryUnsafeMethods: tt.unsafe,
+			})
+
+			err := client.DoRequestWithRetry(context.Background(), tt.method, "/api/v1/invoices", nil, nil, nil, tt.opts...)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if n := atomic.LoadInt32(&attempts); n != tt.attempts {
+				t.Errorf("expected %d attempts, got %d", tt.attempts, n)
+			}
+		})
+	}
+}
+
+func TestDoRequestWithRetryDoesNotRetryPostAfterRateLimit(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewRateLimitedClient("test-token", WithBaseURL(server.URL))
+	client.SetRetryConfig(&RetryConfig{
+		MaxRetries:         2,
+		InitialBackoff:     time.Millisecond,
+		MaxBackoff:         time.Millisecond,
+		BackoffMultiplier:  1,
+		RetryOnStatusCodes: []int{http.StatusTooManyRequests},
+	})
+
+	err := client.DoRequestWithRetry(context.Background(), "POST", "/api/v1/invoices", nil, nil, nil)
+	if apiErr, ok := IsAPIError(err); !ok || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected a 429 API error, got %v", err)
+	}
+	if n := atomic.LoadInt32(&attempts); n != 1 {
+		t.Errorf("expected a POST rejected with 429 not to be retried without a key, got %d attempts", n)
+	}
+	if !client.canRetry("POST", []RequestOption{WithIdempotencyKey("k1")}) {
+		t.Error("expected a POST with an idempotency key to be retryable")
+	}
+}
@@ -0,0 +1,86 @@
+package invoiceninja
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Sort directions accepted in the sort parameter.
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+// Sortable fields for the Sort option of list requests.
+const (
+	// Fields sortable on every entity.
+	SortFieldID        = "id"
+	SortFieldCreatedAt = "created_at"
+	SortFieldUpdatedAt = "updated_at"
+
+	// Fields of invoices, quotes, credits and payments.
+	SortFieldNumber   = "number"
+	SortFieldDate     = "date"
+	SortFieldAmount   = "amount"
+	SortFieldClientID = "client_id"
+	SortFieldStatusID = "status_id"
+
+	// Fields of invoices, quotes and credits.
+	SortFieldDueDate  = "due_date"
+	SortFieldBalance  = "balance"
+	SortFieldPONumber = "po_number"
+
+	// Fields of clients.
+	SortFieldName       = "name"
+	SortFieldPaidToDate = "paid_to_date"
+
+	// Fields of products.
+	SortFieldProductKey = "product_key"
+	SortFieldPrice      = "price"
+)
+
+// SortBy returns the sort parameter value for ordering by field, e.g.
+// SortBy(SortFieldDate, true) returns "date|desc".
+func SortBy(field string, desc bool) string {
+	if desc {
+		return field + "|" + SortDesc
+	}
+	return field + "|" + SortAsc
+}
+
+// MultiSort joins several SortBy values into one sort parameter value,
+// ordering by the first field and breaking ties with the following ones:
+//
+//	opts := &invoiceninja.InvoiceListOptions{
+//		Sort: invoiceninja.MultiSort(
+//			invoiceninja.SortBy(invoiceninja.SortFieldDueDate, false),
+//			invoiceninja.SortBy(invoiceninja.SortFieldBalance, true),
+//		),
+//	}
+func MultiSort(sorts ...string) string {
+	return strings.Join(sorts, ",")
+}
+
+// ValidateSort checks a sort parameter value: each comma-separated part must
+// be a field name, optionally followed by "|asc" or "|desc". The server
+// silently ignores a malformed sort such as "amount|descending", so lists
+// come back in the default order instead of failing. It returns a
+// *ValidationError for the "sort" field, or nil.
+//
+// With WithClientValidation, the Sort option of every list request is
+// checked this way before the request is sent.
+func ValidateSort(sort string) error {
+	verr := &ValidationError{}
+
+	for _, part := range strings.Split(sort, ",") {
+		field, direction, hasDirection := strings.Cut(part, "|")
+		switch {
+		case strings.TrimSpace(field) == "":
+			verr.add("sort", fmt.Sprintf("missing field in %q", part))
+		case hasDirection && direction != SortAsc && direction != SortDesc:
+			verr.add("sort", fmt.Sprintf("invalid direction %q for %s, expected asc or desc", direction, field))
+		}
+	}
+
+	return verr.errOrNil()
+}
@@ -0,0 +1,83 @@
+package invoiceninja
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSortBy(t *testing.T) {
+	if got := SortBy(SortFieldDate, true); got != "date|desc" {
+		t.Errorf("expected date|desc, got %s", got)
+	}
+	if got := SortBy(SortFieldBalance, false); got != "balance|asc" {
+		t.Errorf("expected balance|asc, got %s", got)
+	}
+
+	got := MultiSort(SortBy(SortFieldDueDate, false), SortBy(SortFieldAmount, true))
+	if got != "due_date|asc,amount|desc" {
+		t.Errorf("expected due_date|asc,amount|desc, got %s", got)
+	}
+}
+
+func TestValidateSort(t *testing.T) {
+	tests := []struct {
+		sort  string
+		valid bool
+	}{
+		{"date|desc", true},
+		{"number", true},
+		{"due_date|asc,amount|desc", true},
+		{"amount|descending", false},
+		{"amount|DESC", false},
+		{"|asc", false},
+		{"date|asc,", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			err := ValidateSort(tt.sort)
+			if tt.valid && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !tt.valid {
+				var verr *ValidationError
+				if !errors.As(err, &verr) || verr.Fields[0].Field != "sort" {
+					t.Errorf("expected sort validation error, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestClientValidationChecksSort(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithClientValidation())
+	ctx := context.Background()
+
+	if _, err := client.Invoices.List(ctx, &InvoiceListOptions{Sort: "amount|descending"}); err == nil {
+		t.Error("expected sort validation error")
+	}
+	if _, err := client.Payments.ListAll(ctx, &PaymentListOptions{Sort: "date|up"}); err == nil {
+		t.Error("expected sort validation error from ListAll")
+	}
+	if requests != 0 {
+		t.Errorf("expected no requests for invalid sorts, got %d", requests)
+	}
+
+	if _, err := client.Invoices.List(ctx, &InvoiceListOptions{Sort: SortBy(SortFieldAmount, true)}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected valid sort to be sent, got %d requests", requests)
+	}
+}
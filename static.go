@@ -0,0 +1,257 @@
+package invoiceninja
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+)
+
+// StaticService retrieves the reference data Invoice Ninja uses for opaque
+// IDs such as INClient.CountryID and IndustryID.
+type StaticService struct {
+	client *Client
+
+	mu     sync.Mutex
+	cached *StaticData
+}
+
+// StaticData is the reference data served at /api/v1/statics.
+type StaticData struct {
+	Currencies   []Currency        `json:"currencies"`
+	Countries    []Country         `json:"countries"`
+	Industries   []Industry        `json:"industries"`
+	PaymentTypes []PaymentTypeInfo `json:"payment_types"`
+	Sizes        []Size            `json:"sizes"`
+	Gateways     []Gateway         `json:"gateways"`
+}
+
+// Currency is a currency Invoice Ninja supports.
+type Currency struct {
+	ID                 string  `json:"id"`
+	Name               string  `json:"name"`
+	Code               string  `json:"code"`
+	Symbol             string  `json:"symbol"`
+	Precision          int     `json:"precision"`
+	ThousandSeparator  string  `json:"thousand_separator"`
+	DecimalSeparator   string  `json:"decimal_separator"`
+	SwapCurrencySymbol bool    `json:"swap_currency_symbol"`
+	ExchangeRate       float64 `json:"exchange_rate"`
+}
+
+// Country is a country as referenced by INClient.CountryID.
+type Country struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	ISO2 string `json:"iso_3166_2"`
+	ISO3 string `json:"iso_3166_3"`
+}
+
+// Industry is an industry as referenced by INClient.IndustryID.
+type Industry struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// PaymentTypeInfo names a PaymentType.
+type PaymentTypeInfo struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Size is a company size bracket.
+type Size struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Gateway is a payment gateway provider that a CompanyGateway can use.
+type Gateway struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Key      string `json:"key"`
+	Provider string `json:"provider"`
+}
+
+// flexString decodes a JSON string or number as text. The static data comes
+// straight from the database, so IDs and some numbers are sent as either.
+type flexString string
+
+// UnmarshalJSON accepts a string, a number or null.
+func (s *flexString) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var v string
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		*s = flexString(v)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*s = flexString(n)
+	return nil
+}
+
+// UnmarshalJSON accepts numeric or string IDs and precision.
+func (c *Currency) UnmarshalJSON(data []byte) error {
+	type plain Currency
+	v := struct {
+		*plain
+		ID        flexString `json:"id"`
+		Precision flexString `json:"precision"`
+	}{plain: (*plain)(c)}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	c.ID = string(v.ID)
+	c.Precision, _ = strconv.Atoi(string(v.Precision))
+	return nil
+}
+
+// UnmarshalJSON accepts a numeric or string ID.
+func (c *Country) UnmarshalJSON(data []byte) error {
+	type plain Country
+	v := struct {
+		*plain
+		ID flexString `json:"id"`
+	}{plain: (*plain)(c)}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	c.ID = string(v.ID)
+	return nil
+}
+
+// UnmarshalJSON accepts a numeric or string ID.
+func (i *Industry) UnmarshalJSON(data []byte) error {
+	type plain Industry
+	v := struct {
+		*plain
+		ID flexString `json:"id"`
+	}{plain: (*plain)(i)}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	i.ID = string(v.ID)
+	return nil
+}
+
+// UnmarshalJSON accepts a numeric or string ID.
+func (p *PaymentTypeInfo) UnmarshalJSON(data []byte) error {
+	type plain PaymentTypeInfo
+	v := struct {
+		*plain
+		ID flexString `json:"id"`
+	}{plain: (*plain)(p)}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	p.ID = string(v.ID)
+	return nil
+}
+
+// UnmarshalJSON accepts a numeric or string ID.
+func (s *Size) UnmarshalJSON(data []byte) error {
+	type plain Size
+	v := struct {
+		*plain
+		ID flexString `json:"id"`
+	}{plain: (*plain)(s)}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	s.ID = string(v.ID)
+	return nil
+}
+
+// UnmarshalJSON accepts a numeric or string ID.
+func (g *Gateway) UnmarshalJSON(data []byte) error {
+	type plain Gateway
+	v := struct {
+		*plain
+		ID flexString `json:"id"`
+	}{plain: (*plain)(g)}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	g.ID = string(v.ID)
+	return nil
+}
+
+// Get retrieves the static data from the server.
+func (s *StaticService) Get(ctx context.Context) (*StaticData, error) {
+	var data StaticData
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/statics"), nil, nil, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// Cached returns the static data, fetching it on the first call only. The
+// data rarely changes, so it is kept for the lifetime of the client. Failed
+// fetches are not cached. The returned value is shared and must not be
+// modified.
+func (s *StaticService) Cached(ctx context.Context) (*StaticData, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cached != nil {
+		return s.cached, nil
+	}
+
+	data, err := s.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.cached = data
+	return data, nil
+}
+
+// CurrencyByID returns the currency with the given ID.
+func (d *StaticData) CurrencyByID(id string) (*Currency, bool) {
+	for i := range d.Currencies {
+		if d.Currencies[i].ID == id {
+			return &d.Currencies[i], true
+		}
+	}
+	return nil, false
+}
+
+// CurrencyByCode returns the currency with the given ISO 4217 code.
+func (d *StaticData) CurrencyByCode(code string) (*Currency, bool) {
+	for i := range d.Currencies {
+		if d.Currencies[i].Code == code {
+			return &d.Currencies[i], true
+		}
+	}
+	return nil, false
+}
+
+// CountryByID returns the country with the given ID, e.g. an
+// INClient.CountryID.
+func (d *StaticData) CountryByID(id string) (*Country, bool) {
+	for i := range d.Countries {
+		if d.Countries[i].ID == id {
+			return &d.Countries[i], true
+		}
+	}
+	return nil, false
+}
+
+// IndustryByID returns the industry with the given ID, e.g. an
+// INClient.IndustryID.
+func (d *StaticData) IndustryByID(id string) (*Industry, bool) {
+	for i := range d.Industries {
+		if d.Industries[i].ID == id {
+			return &d.Industries[i], true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,101 @@
+package invoiceninja
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+const staticsBody = `{
+	"currencies": [
+		{"id": 1, "name": "US Dollar", "code": "USD", "symbol": "$", "precision": "2", "thousand_separator": ",", "decimal_separator": ".", "swap_currency_symbol": false, "exchange_rate": 1},
+		{"id": "3", "name": "Euro", "code": "EUR", "symbol": "€", "precision": 2, "thousand_separator": ".", "decimal_separator": ","}
+	],
+	"countries": [{"id": 840, "name": "United States", "iso_3166_2": "US", "iso_3166_3": "USA"}],
+	"industries": [{"id": 14, "name": "Legal"}],
+	"payment_types": [{"id": 1, "name": "Bank Transfer"}],
+	"sizes": [{"id": 1, "name": "1 - 3"}],
+	"gateways": [{"id": 20, "name": "Stripe", "key": "d14dd26a37cecc30fdd65700bfb55b23", "provider": "Stripe"}],
+	"timezones": [{"id": 1, "name": "Pacific/Midway"}]
+}`
+
+func TestStaticServiceGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/statics" {
+			t.Errorf("expected path /api/v1/statics, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(staticsBody))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	data, err := client.Static.Get(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	usd, ok := data.CurrencyByID("1")
+	if !ok || usd.Code != "USD" || usd.Precision != 2 || usd.ExchangeRate != 1 {
+		t.Errorf("unexpected currency 1: %+v", usd)
+	}
+	eur, ok := data.CurrencyByCode("EUR")
+	if !ok || eur.ID != "3" || eur.Precision != 2 || eur.DecimalSeparator != "," {
+		t.Errorf("unexpected currency EUR: %+v", eur)
+	}
+	if country, ok := data.CountryByID("840"); !ok || country.ISO2 != "US" {
+		t.Errorf("unexpected country 840: %+v", country)
+	}
+	if industry, ok := data.IndustryByID("14"); !ok || industry.Name != "Legal" {
+		t.Errorf("unexpected industry 14: %+v", industry)
+	}
+	if _, ok := data.CountryByID("999"); ok {
+		t.Error("expected no country 999")
+	}
+	if len(data.PaymentTypes) != 1 || data.PaymentTypes[0].ID != "1" {
+		t.Errorf("unexpected payment types: %+v", data.PaymentTypes)
+	}
+	if len(data.Sizes) != 1 || len(data.Gateways) != 1 || data.Gateways[0].ID != "20" {
+		t.Errorf("unexpected sizes or gateways: %+v %+v", data.Sizes, data.Gateways)
+	}
+}
+
+func TestStaticServiceCached(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"message": "maintenance"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(staticsBody))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	if _, err := client.Static.Cached(ctx); err == nil {
+		t.Fatal("expected the first fetch to fail")
+	}
+
+	first, err := client.Static.Cached(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := client.Static.Cached(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if first != second {
+		t.Error("expected the cached data to be reused")
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("expected 2 requests, got %d", got)
+	}
+}
@@ -0,0 +1,164 @@
+package invoiceninja
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// InvoiceStatus is the lifecycle state of an invoice, as carried in
+// Invoice.StatusID.
+type InvoiceStatus int
+
+// Invoice statuses defined by Invoice Ninja.
+const (
+	InvoiceStatusDraft     InvoiceStatus = 1
+	InvoiceStatusSent      InvoiceStatus = 2
+	InvoiceStatusPartial   InvoiceStatus = 3
+	InvoiceStatusPaid      InvoiceStatus = 4
+	InvoiceStatusCancelled InvoiceStatus = 5
+	InvoiceStatusReversed  InvoiceStatus = 6
+)
+
+var invoiceStatusNames = map[InvoiceStatus]string{
+	InvoiceStatusDraft:     "draft",
+	InvoiceStatusSent:      "sent",
+	InvoiceStatusPartial:   "partial",
+	InvoiceStatusPaid:      "paid",
+	InvoiceStatusCancelled: "cancelled",
+	InvoiceStatusReversed:  "reversed",
+}
+
+// String returns the status name, e.g. "paid".
+func (s InvoiceStatus) String() string {
+	if name, ok := invoiceStatusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("InvoiceStatus(%d)", int(s))
+}
+
+// Status returns the invoice's StatusID as an InvoiceStatus, or 0 if it is
+// empty or not numeric.
+func (i *Invoice) Status() InvoiceStatus {
+	return InvoiceStatus(parseStatusID(i.StatusID))
+}
+
+// PaymentStatus is the processing state of a payment, as carried in
+// Payment.StatusID.
+type PaymentStatus int
+
+// Payment statuses defined by Invoice Ninja.
+const (
+	PaymentStatusPending           PaymentStatus = 1
+	PaymentStatusCancelled         PaymentStatus = 2
+	PaymentStatusFailed            PaymentStatus = 3
+	PaymentStatusCompleted         PaymentStatus = 4
+	PaymentStatusPartiallyRefunded PaymentStatus = 5
+	PaymentStatusRefunded          PaymentStatus = 6
+)
+
+var paymentStatusNames = map[PaymentStatus]string{
+	PaymentStatusPending:           "pending",
+	PaymentStatusCancelled:         "cancelled",
+	PaymentStatusFailed:            "failed",
+	PaymentStatusCompleted:         "completed",
+	PaymentStatusPartiallyRefunded: "partially refunded",
+	PaymentStatusRefunded:          "refunded",
+}
+
+// String returns the status name, e.g. "completed".
+func (s PaymentStatus) String() string {
+	if name, ok := paymentStatusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("PaymentStatus(%d)", int(s))
+}
+
+// Status returns the payment's StatusID as a PaymentStatus, or 0 if it is
+// empty or not numeric.
+func (p *Payment) Status() PaymentStatus {
+	return PaymentStatus(parseStatusID(p.StatusID))
+}
+
+// Type returns the payment's TypeID as a PaymentType, or 0 if it is empty or
+// not numeric.
+func (p *Payment) Type() PaymentType {
+	return PaymentType(parseStatusID(p.TypeID))
+}
+
+// QuoteStatus is the lifecycle state of a quote, as carried in Quote.StatusID.
+type QuoteStatus int
+
+// Quote statuses defined by Invoice Ninja. Expired is not stored by the
+// server but reported for sent quotes whose due date has passed.
+const (
+	QuoteStatusExpired   QuoteStatus = -1
+	QuoteStatusDraft     QuoteStatus = 1
+	QuoteStatusSent      QuoteStatus = 2
+	QuoteStatusApproved  QuoteStatus = 3
+	QuoteStatusConverted QuoteStatus = 4
+	QuoteStatusRejected  QuoteStatus = 5
+)
+
+var quoteStatusNames = map[QuoteStatus]string{
+	QuoteStatusExpired:   "expired",
+	QuoteStatusDraft:     "draft",
+	QuoteStatusSent:      "sent",
+	QuoteStatusApproved:  "approved",
+	QuoteStatusConverted: "converted",
+	QuoteStatusRejected:  "rejected",
+}
+
+// String returns the status name, e.g. "approved".
+func (s QuoteStatus) String() string {
+	if name, ok := quoteStatusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("QuoteStatus(%d)", int(s))
+}
+
+// Status returns the quote's StatusID as a QuoteStatus, or 0 if it is empty
+// or not numeric.
+func (q *Quote) Status() QuoteStatus {
+	return QuoteStatus(parseStatusID(q.StatusID))
+}
+
+// BankTransactionStatus is the reconciliation state of a bank transaction, as
+// carried in BankTransaction.StatusID.
+type BankTransactionStatus int
+
+// Bank transaction statuses defined by Invoice Ninja.
+const (
+	BankTransactionStatusUnmatched BankTransactionStatus = 1
+	BankTransactionStatusMatched   BankTransactionStatus = 2
+	BankTransactionStatusConverted BankTransactionStatus = 3
+)
+
+var bankTransactionStatusNames = map[BankTransactionStatus]string{
+	BankTransactionStatusUnmatched: "unmatched",
+	BankTransactionStatusMatched:   "matched",
+	BankTransactionStatusConverted: "converted",
+}
+
+// String returns the status name, e.g. "matched".
+func (s BankTransactionStatus) String() string {
+	if name, ok := bankTransactionStatusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("BankTransactionStatus(%d)", int(s))
+}
+
+// Status returns the transaction's StatusID as a BankTransactionStatus, or 0
+// if it is empty or not numeric.
+func (t *BankTransaction) Status() BankTransactionStatus {
+	return BankTransactionStatus(parseStatusID(t.StatusID))
+}
+
+// parseStatusID converts a numeric string ID to an int, returning 0 if it is
+// not a number.
+func parseStatusID(id string) int {
+	n, err := strconv.Atoi(id)
+	if err != nil {
+		return 0
+	}
+	return n
+}
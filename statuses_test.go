@@ -0,0 +1,82 @@
+package invoiceninja
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestInvoiceStatus(t *testing.T) {
+	tests := []struct {
+		statusID string
+		expected InvoiceStatus
+		name     string
+	}{
+		{"1", InvoiceStatusDraft, "draft"},
+		{"2", InvoiceStatusSent, "sent"},
+		{"3", InvoiceStatusPartial, "partial"},
+		{"4", InvoiceStatusPaid, "paid"},
+		{"5", InvoiceStatusCancelled, "cancelled"},
+		{"6", InvoiceStatusReversed, "reversed"},
+		{"", 0, "InvoiceStatus(0)"},
+		{"x", 0, "InvoiceStatus(0)"},
+		{"9", 9, "InvoiceStatus(9)"},
+	}
+
+	for _, tt := range tests {
+		inv := &Invoice{StatusID: tt.statusID}
+		status := inv.Status()
+		if status != tt.expected {
+			t.Errorf("StatusID %q: expected %d, got %d", tt.statusID, tt.expected, status)
+		}
+		if status.String() != tt.name {
+			t.Errorf("StatusID %q: expected name %q, got %q", tt.statusID, tt.name, status.String())
+		}
+	}
+}
+
+func TestPaymentStatusAndType(t *testing.T) {
+	var payment Payment
+	if err := json.Unmarshal([]byte(`{"status_id": "4", "type_id": "1"}`), &payment); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if payment.Status() != PaymentStatusCompleted || payment.Status().String() != "completed" {
+		t.Errorf("expected completed status, got %v", payment.Status())
+	}
+
+	if payment.Type() != PaymentTypeBankTransfer || payment.Type().String() != "Bank Transfer" {
+		t.Errorf("expected bank transfer type, got %v", payment.Type())
+	}
+
+	if PaymentStatus(42).String() != "PaymentStatus(42)" {
+		t.Errorf("unexpected unknown status name: %s", PaymentStatus(42))
+	}
+
+	if PaymentType(99).String() != "PaymentType(99)" {
+		t.Errorf("unexpected unknown type name: %s", PaymentType(99))
+	}
+}
+
+func TestQuoteStatus(t *testing.T) {
+	tests := []struct {
+		statusID string
+		expected QuoteStatus
+		name     string
+	}{
+		{"1", QuoteStatusDraft, "draft"},
+		{"3", QuoteStatusApproved, "approved"},
+		{"5", QuoteStatusRejected, "rejected"},
+		{"-1", QuoteStatusExpired, "expired"},
+		{"", 0, "QuoteStatus(0)"},
+	}
+
+	for _, tt := range tests {
+		quote := Quote{StatusID: tt.statusID}
+		if quote.Status() != tt.expected {
+			t.Errorf("status_id %q: expected %d, got %d", tt.statusID, tt.expected, quote.Status())
+		}
+		if quote.Status().String() != tt.name {
+			t.Errorf("status_id %q: expected name %q, got %q", tt.statusID, tt.name, quote.Status().String())
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package invoiceninja
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// SubscriptionsService handles subscription-related API operations.
+type SubscriptionsService struct {
+	client *Client
+}
+
+// Subscription represents a billing subscription in Invoice Ninja.
+type Subscription struct {
+	ID                   string                `json:"id,omitempty"`
+	UserID               string                `json:"user_id,omitempty"`
+	AssignedUserID       string                `json:"assigned_user_id,omitempty"`
+	GroupID              string                `json:"group_id,omitempty"`
+	Name                 string                `json:"name,omitempty"`
+	ProductIDs           string                `json:"product_ids,omitempty"`
+	RecurringProductIDs  string                `json:"recurring_product_ids,omitempty"`
+	OptionalProductIDs   string                `json:"optional_product_ids,omitempty"`
+	Price                float64               `json:"price,omitempty"`
+	PromoPrice           float64               `json:"promo_price,omitempty"`
+	FrequencyID          string                `json:"frequency_id,omitempty"`
+	AutoBill             string                `json:"auto_bill,omitempty"`
+	PromoCode            string                `json:"promo_code,omitempty"`
+	PromoDiscount        float64               `json:"promo_discount,omitempty"`
+	IsAmountDiscount     bool                  `json:"is_amount_discount,omitempty"`
+	AllowCancellation    bool                  `json:"allow_cancellation,omitempty"`
+	PerSeatEnabled       bool                  `json:"per_seat_enabled,omitempty"`
+	MaxSeatsLimit        int                   `json:"max_seats_limit,omitempty"`
+	TrialEnabled         bool                  `json:"trial_enabled,omitempty"`
+	TrialDuration        int                   `json:"trial_duration,omitempty"`
+	AllowQueryOverrides  bool                  `json:"allow_query_overrides,omitempty"`
+	AllowPlanChanges     bool                  `json:"allow_plan_changes,omitempty"`
+	RefundPeriod         int                   `json:"refund_period,omitempty"`
+	WebhookConfiguration *WebhookConfiguration `json:"webhook_configuration,omitempty"`
+	PurchasePage         string                `json:"purchase_page,omitempty"`
+	IsDeleted            bool                  `json:"is_deleted,omitempty"`
+	CreatedAt            int64                 `json:"created_at,omitempty"`
+	UpdatedAt            int64                 `json:"updated_at,omitempty"`
+	ArchivedAt           int64                 `json:"archived_at,omitempty"`
+}
+
+// WebhookConfiguration configures the callbacks made when a subscription is purchased.
+type WebhookConfiguration struct {
+	ReturnURL              string            `json:"return_url,omitempty"`
+	PostPurchaseURL        string            `json:"post_purchase_url,omitempty"`
+	PostPurchaseRestMethod string            `json:"post_purchase_rest_method,omitempty"`
+	PostPurchaseHeaders    map[string]string `json:"post_purchase_headers,omitempty"`
+	PostPurchaseBody       string            `json:"post_purchase_body,omitempty"`
+}
+
+// SubscriptionListOptions specifies the optional parameters for listing subscriptions.
+type SubscriptionListOptions struct {
+	PerPage   int
+	Page      int
+	Filter    string
+	Status    string
+	IsDeleted *bool
+	Sort      string
+	Include   string
+	Filters   []FilterExpr
+}
+
+// toQuery converts options to URL query parameters.
+func (o *SubscriptionListOptions) toQuery() url.Values {
+	if o == nil {
+		return nil
+	}
+
+	q := url.Values{}
+
+	if o.PerPage > 0 {
+		q.Set("per_page", strconv.Itoa(o.PerPage))
+	}
+	if o.Page > 0 {
+		q.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.Filter != "" {
+		q.Set("filter", o.Filter)
+	}
+	if o.Status != "" {
+		q.Set("status", o.Status)
+	}
+	if o.IsDeleted != nil {
+		q.Set("is_deleted", strconv.FormatBool(*o.IsDeleted))
+	}
+	if o.Sort != "" {
+		q.Set("sort", o.Sort)
+	}
+	if o.Include != "" {
+		q.Set("include", o.Include)
+	}
+
+	applyFilters(q, o.Filters)
+
+	return q
+}
+
+// List retrieves a list of subscriptions.
+func (s *SubscriptionsService) List(ctx context.Context, opts *SubscriptionListOptions) (*ListResponse[Subscription], error) {
+	var resp ListResponse[Subscription]
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/subscriptions"), opts.toQuery(), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Iter returns an iterator over all subscriptions matching opts, fetching pages lazily.
+// Paging starts at opts.Page (or page 1) and keeps every other option.
+func (s *SubscriptionsService) Iter(ctx context.Context, opts *SubscriptionListOptions) *Iterator[Subscription] {
+	return newIterator[Subscription](ctx, s.client, s.client.apiPath("/subscriptions"), opts.toQuery())
+}
+
+// ListAll retrieves all subscriptions matching opts across every page.
+// If a page fails or ctx is done mid-scan, the subscriptions fetched so far are
+// returned together with the error.
+func (s *SubscriptionsService) ListAll(ctx context.Context, opts *SubscriptionListOptions) ([]Subscription, error) {
+	return listAll(s.Iter(ctx, opts))
+}
+
+// Count returns the number of subscriptions matching opts without fetching them,
+// from the pagination total of a one-item page. Paging options are ignored.
+func (s *SubscriptionsService) Count(ctx context.Context, opts *SubscriptionListOptions) (int, error) {
+	return count(ctx, s.client, s.client.apiPath("/subscriptions"), opts.toQuery())
+}
+
+// Get retrieves a single subscription by ID.
+func (s *SubscriptionsService) Get(ctx context.Context, id string) (*Subscription, error) {
+	var resp SingleResponse[Subscription]
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/subscriptions/%s", id), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Create creates a new subscription.
+func (s *SubscriptionsService) Create(ctx context.Context, subscription *Subscription, opts ...RequestOption) (*Subscription, error) {
+	var resp SingleResponse[Subscription]
+	if err := s.client.doRequest(ctx, "POST", s.client.apiPath("/subscriptions"), nil, subscription, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Update updates an existing subscription.
+func (s *SubscriptionsService) Update(ctx context.Context, id string, subscription *Subscription, opts ...RequestOption) (*Subscription, error) {
+	var resp SingleResponse[Subscription]
+	if err := s.client.doRequest(ctx, "PUT", s.client.apiPath("/subscriptions/%s", id), nil, subscription, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Delete deletes a subscription by ID.
+func (s *SubscriptionsService) Delete(ctx context.Context, id string, opts ...RequestOption) error {
+	return s.client.doRequest(ctx, "DELETE", s.client.apiPath("/subscriptions/%s", id), nil, nil, nil, opts...)
+}
+
+// Archive archives a subscription.
+func (s *SubscriptionsService) Archive(ctx context.Context, id string) (*Subscription, error) {
+	return s.bulkAction(ctx, "archive", id)
+}
+
+// Restore restores an archived or deleted subscription.
+func (s *SubscriptionsService) Restore(ctx context.Context, id string) (*Subscription, error) {
+	return s.bulkAction(ctx, "restore", id)
+}
+
+// Bulk performs a bulk action on multiple subscriptions.
+func (s *SubscriptionsService) Bulk(ctx context.Context, action string, ids []string, opts ...RequestOption) ([]Subscription, error) {
+	req := BulkAction{
+		Action: action,
+		IDs:    ids,
+	}
+
+	return bulkRequest[Subscription](ctx, s.client, s.client.apiPath("/subscriptions/bulk"), req, opts...)
+}
+
+// BulkTyped performs a bulk action on multiple subscriptions like Bulk, but
+// first checks that action is one of BulkArchive, BulkRestore and BulkDelete,
+// returning a *ValidationError otherwise.
+func (s *SubscriptionsService) BulkTyped(ctx context.Context, action BulkActionType, ids []string, opts ...RequestOption) ([]Subscription, error) {
+	if err := checkBulkAction("subscriptions", action); err != nil {
+		return nil, err
+	}
+	return s.Bulk(ctx, string(action), ids, opts...)
+}
+
+// BulkChunked performs a bulk action on a large number of subscriptions by splitting ids
+// into chunks of chunkSize (DefaultBulkChunkSize when chunkSize <= 0) and sending
+// one Bulk request per chunk. It returns the subscriptions echoed by successful chunks along
+// with a joined error of *BulkChunkError values for any chunks that failed.
+func (s *SubscriptionsService) BulkChunked(ctx context.Context, action string, ids []string, chunkSize int, opts ...RequestOption) ([]Subscription, error) {
+	return bulkChunked(ctx, ids, chunkSize, func(ctx context.Context, chunk []string) ([]Subscription, error) {
+		return s.Bulk(ctx, action, chunk, opts...)
+	})
+}
+
+// bulkAction performs a single-item bulk action.
+func (s *SubscriptionsService) bulkAction(ctx context.Context, action, id string) (*Subscription, error) {
+	subscriptions, err := s.Bulk(ctx, action, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	if len(subscriptions) == 0 {
+		return nil, fmt.Errorf("%s subscription: %w", action, ErrEmptyBulkResponse)
+	}
+	return &subscriptions[0], nil
+}
@@ -0,0 +1,178 @@
+package invoiceninja
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSubscriptionsServiceList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("expected GET method, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/subscriptions" {
+			t.Errorf("expected path /api/v1/subscriptions, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("status") != "active" {
+			t.Errorf("expected status active, got %s", r.URL.Query().Get("status"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{
+				{"id": "sub1", "name": "Basic", "price": 9.99, "frequency_id": "5"},
+				{"id": "sub2", "name": "Pro", "price": 29.99, "frequency_id": "5"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	resp, err := client.Subscriptions.List(context.Background(), &SubscriptionListOptions{Status: "active"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Data) != 2 {
+		t.Errorf("expected 2 subscriptions, got %d", len(resp.Data))
+	}
+
+	if resp.Data[1].Name != "Pro" || resp.Data[1].Price != 29.99 {
+		t.Errorf("unexpected second subscription: %+v", resp.Data[1])
+	}
+}
+
+func TestSubscriptionsServiceGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/subscriptions/sub1" {
+			t.Errorf("expected path /api/v1/subscriptions/sub1, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {
+			"id": "sub1",
+			"name": "Basic",
+			"product_ids": "p1,p2",
+			"recurring_product_ids": "p3",
+			"webhook_configuration": {
+				"post_purchase_url": "https://example.com/hook",
+				"post_purchase_rest_method": "post",
+				"post_purchase_headers": {"X-Key": "secret"}
+			}
+		}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	sub, err := client.Subscriptions.Get(context.Background(), "sub1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if sub.ProductIDs != "p1,p2" || sub.RecurringProductIDs != "p3" {
+		t.Errorf("unexpected product IDs: %q / %q", sub.ProductIDs, sub.RecurringProductIDs)
+	}
+
+	if sub.WebhookConfiguration == nil {
+		t.Fatal("expected webhook configuration")
+	}
+
+	if sub.WebhookConfiguration.PostPurchaseHeaders["X-Key"] != "secret" {
+		t.Errorf("expected post purchase header, got %v", sub.WebhookConfiguration.PostPurchaseHeaders)
+	}
+}
+
+func TestSubscriptionsServiceCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("expected POST method, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/subscriptions" {
+			t.Errorf("expected path /api/v1/subscriptions, got %s", r.URL.Path)
+		}
+
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		if body["name"] != "Basic" {
+			t.Errorf("expected name Basic, got %v", body["name"])
+		}
+		if body["promo_code"] != "WELCOME" {
+			t.Errorf("expected promo_code WELCOME, got %v", body["promo_code"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"id": "new-sub", "name": "Basic"},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	sub, err := client.Subscriptions.Create(context.Background(), &Subscription{
+		Name:      "Basic",
+		Price:     9.99,
+		PromoCode: "WELCOME",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if sub.ID != "new-sub" {
+		t.Errorf("expected ID new-sub, got %s", sub.ID)
+	}
+}
+
+func TestSubscriptionsServiceUpdate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("expected PUT method, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/subscriptions/sub1" {
+			t.Errorf("expected path /api/v1/subscriptions/sub1, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"id": "sub1", "price": 12.5},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	sub, err := client.Subscriptions.Update(context.Background(), "sub1", &Subscription{Price: 12.5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if sub.Price != 12.5 {
+		t.Errorf("expected price 12.5, got %f", sub.Price)
+	}
+}
+
+func TestSubscriptionsServiceDelete(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("expected DELETE method, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/subscriptions/sub1" {
+			t.Errorf("expected path /api/v1/subscriptions/sub1, got %s", r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	if err := client.Subscriptions.Delete(context.Background(), "sub1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,30 @@
+package invoiceninja
+
+import (
+	"context"
+	"time"
+)
+
+// WithDefaultRequestTimeout bounds every request whose context has no
+// deadline at d, so a hung server cannot block a caller that forgot to set
+// one. The timeout covers the whole exchange, including reading the response
+// body of a download, and each retry gets a fresh one. Contexts that already
+// carry a deadline are left alone. d <= 0 disables the default.
+func WithDefaultRequestTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.requestTimeout = d
+	}
+}
+
+// requestContext applies the default request timeout to ctx if it has no
+// deadline. The returned cancel func must be called once the response body
+// has been read.
+func (c *Client) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.requestTimeout <= 0 {
+		return ctx, func() {}
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, c.requestTimeout)
+}
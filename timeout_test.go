@@ -0,0 +1,66 @@
+package invoiceninja
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithDefaultRequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithDefaultRequestTimeout(50*time.Millisecond))
+
+	start := time.Now()
+	_, err := client.Invoices.Get(context.Background(), "inv1")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a deadline error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected the request to time out quickly, took %v", elapsed)
+	}
+}
+
+func TestWithDefaultRequestTimeoutCoversDownloadBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write([]byte("%PDF-1.4"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithDefaultRequestTimeout(50*time.Millisecond))
+
+	if _, err := client.Downloads.DownloadInvoicePDF(context.Background(), "key1"); err == nil {
+		t.Fatal("expected a stalled download to time out")
+	}
+}
+
+func TestWithDefaultRequestTimeoutKeepsCallerDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "inv1"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithDefaultRequestTimeout(10*time.Millisecond))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	invoice, err := client.Invoices.Get(ctx, "inv1")
+	if err != nil {
+		t.Fatalf("expected the caller's deadline to be used, got %v", err)
+	}
+	if invoice.ID != "inv1" {
+		t.Errorf("expected inv1, got %q", invoice.ID)
+	}
+}
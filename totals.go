@@ -0,0 +1,97 @@
+package invoiceninja
+
+// ComputeTotals fills in Amount and TotalTaxes from the line items, so a total
+// can be shown before the invoice is submitted. The server recalculates both
+// on save and its figures are authoritative.
+//
+// Line item discounts apply first, then the invoice discount, which is shared
+// across the line items in proportion to their totals when it is a fixed
+// amount. Line item and invoice taxes are charged on the discounted amounts.
+// With UsesInclusiveTaxes the taxes are already part of the prices, so they
+// are extracted rather than added and Amount is the discounted subtotal.
+// Taxes are rounded to cents per line, as the server does.
+func (i *Invoice) ComputeTotals() {
+	lineTotals := make([]float64, len(i.LineItems))
+	var subtotal float64
+	for n, item := range i.LineItems {
+		lineTotals[n] = item.LineTotal()
+		subtotal += lineTotals[n]
+	}
+
+	discount := i.Discount
+	if !boolValue(i.IsAmountDiscount) {
+		discount = subtotal * i.Discount / 100
+	}
+	discount = roundCents(discount)
+
+	var taxes float64
+	for n, item := range i.LineItems {
+		taxable := lineTotals[n]
+		if discount != 0 && subtotal != 0 {
+			taxable -= taxable * discount / subtotal
+		}
+		for _, rate := range []float64{item.TaxRate1, item.TaxRate2, item.TaxRate3} {
+			taxes += i.taxOn(taxable, rate)
+		}
+	}
+
+	taxable := subtotal - discount
+	for _, rate := range []float64{i.TaxRate1, i.TaxRate2, i.TaxRate3} {
+		taxes += i.taxOn(taxable, rate)
+	}
+
+	i.TotalTaxes = roundCents(taxes)
+	i.Amount = roundCents(taxable)
+	if !boolValue(i.UsesInclusiveTaxes) {
+		i.Amount = roundCents(taxable + i.TotalTaxes)
+	}
+}
+
+// taxOn returns the tax at rate percent on amount, rounded to cents. For
+// inclusive taxes amount already contains the tax.
+func (i *Invoice) taxOn(amount, rate float64) float64 {
+	if rate == 0 {
+		return 0
+	}
+	if boolValue(i.UsesInclusiveTaxes) {
+		return roundCents(amount - amount/(1+rate/100))
+	}
+	return roundCents(amount * rate / 100)
+}
+
+// LineTotal returns Quantity times Cost less the line item's discount,
+// rounded to cents. Taxes and the invoice discount are not included.
+func (li *LineItem) LineTotal() float64 {
+	total := li.Quantity * li.Cost
+	if li.IsAmountDisc {
+		total -= li.Discount
+	} else {
+		total -= total * li.Discount / 100
+	}
+	return roundCents(total)
+}
+
+// AddLineItem appends li to the invoice's line items. Amount and TotalTaxes
+// are not updated; call ComputeTotals for a preview.
+func (i *Invoice) AddLineItem(li LineItem) {
+	i.LineItems = append(i.LineItems, li)
+}
+
+// SetTax sets invoice-level tax idx, which is 1, 2 or 3 for TaxName1 and
+// TaxRate1 through TaxName3 and TaxRate3, to rate percent. Other values of
+// idx are ignored.
+func (i *Invoice) SetTax(idx int, name string, rate float64) {
+	switch idx {
+	case 1:
+		i.TaxName1, i.TaxRate1 = name, rate
+	case 2:
+		i.TaxName2, i.TaxRate2 = name, rate
+	case 3:
+		i.TaxName3, i.TaxRate3 = name, rate
+	}
+}
+
+// boolValue returns *b, or false if b is nil.
+func boolValue(b *bool) bool {
+	return b != nil && *b
+}
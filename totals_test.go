@@ -0,0 +1,128 @@
+package invoiceninja
+
+import "testing"
+
+func TestInvoiceComputeTotals(t *testing.T) {
+	yes := true
+	tests := []struct {
+		name       string
+		invoice    Invoice
+		amount     float64
+		totalTaxes float64
+	}{
+		{
+			name: "exclusive invoice tax",
+			invoice: Invoice{
+				TaxRate1:  20,
+				LineItems: []LineItem{{Quantity: 2, Cost: 50}, {Quantity: 1, Cost: 25}},
+			},
+			amount:     150,
+			totalTaxes: 25,
+		},
+		{
+			name: "inclusive invoice tax",
+			invoice: Invoice{
+				TaxRate1:           20,
+				UsesInclusiveTaxes: &yes,
+				LineItems:          []LineItem{{Quantity: 1, Cost: 120}},
+			},
+			amount:     120,
+			totalTaxes: 20,
+		},
+		{
+			name: "percentage discount before tax",
+			invoice: Invoice{
+				TaxRate1:  10,
+				Discount:  10,
+				LineItems: []LineItem{{Quantity: 4, Cost: 50}},
+			},
+			amount:     198,
+			totalTaxes: 18,
+		},
+		{
+			name: "line taxes share a fixed discount",
+			invoice: Invoice{
+				Discount:         20,
+				IsAmountDiscount: &yes,
+				LineItems: []LineItem{
+					{Quantity: 1, Cost: 100, Discount: 10, IsAmountDisc: true, TaxRate1: 5},
+					{Quantity: 2, Cost: 50},
+				},
+			},
+			amount:     174.03,
+			totalTaxes: 4.03,
+		},
+		{
+			name: "inclusive line taxes",
+			invoice: Invoice{
+				UsesInclusiveTaxes: &yes,
+				LineItems: []LineItem{
+					{Quantity: 1, Cost: 110, TaxRate1: 10},
+					{Quantity: 1, Cost: 50},
+				},
+			},
+			amount:     160,
+			totalTaxes: 10,
+		},
+		{
+			name:    "no line items",
+			invoice: Invoice{TaxRate1: 20, Amount: 99, TotalTaxes: 9},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv := tt.invoice
+			inv.ComputeTotals()
+			if inv.Amount != tt.amount || inv.TotalTaxes != tt.totalTaxes {
+				t.Errorf("expected amount %v and taxes %v, got %v and %v", tt.amount, tt.totalTaxes, inv.Amount, inv.TotalTaxes)
+			}
+		})
+	}
+}
+
+func TestLineItemLineTotal(t *testing.T) {
+	tests := []struct {
+		name string
+		item LineItem
+		want float64
+	}{
+		{"no discount", LineItem{Quantity: 3, Cost: 19.99}, 59.97},
+		{"percentage discount", LineItem{Quantity: 4, Cost: 50, Discount: 10}, 180},
+		{"amount discount", LineItem{Quantity: 4, Cost: 50, Discount: 15, IsAmountDisc: true}, 185},
+		{"rounded to cents", LineItem{Quantity: 1, Cost: 10, Discount: 33.333}, 6.67},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.item.LineTotal(); got != tt.want {
+				t.Errorf("LineTotal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvoiceAddLineItemAndSetTax(t *testing.T) {
+	var inv Invoice
+	inv.AddLineItem(LineItem{ProductKey: "a", Quantity: 2, Cost: 50})
+	inv.AddLineItem(LineItem{ProductKey: "b", Quantity: 1, Cost: 25})
+	inv.SetTax(1, "VAT", 20)
+	inv.SetTax(3, "Levy", 1)
+	inv.SetTax(4, "ignored", 99)
+
+	if len(inv.LineItems) != 2 || inv.LineItems[1].ProductKey != "b" {
+		t.Fatalf("unexpected line items: %+v", inv.LineItems)
+	}
+	if inv.Amount != 0 {
+		t.Errorf("expected AddLineItem not to compute totals, got amount %v", inv.Amount)
+	}
+	if inv.TaxName1 != "VAT" || inv.TaxRate1 != 20 || inv.TaxName2 != "" || inv.TaxName3 != "Levy" || inv.TaxRate3 != 1 {
+		t.Errorf("unexpected taxes: %q %v, %q %v, %q %v",
+			inv.TaxName1, inv.TaxRate1, inv.TaxName2, inv.TaxRate2, inv.TaxName3, inv.TaxRate3)
+	}
+
+	inv.ComputeTotals()
+	if inv.Amount != 151.25 {
+		t.Errorf("expected amount 151.25, got %v", inv.Amount)
+	}
+}
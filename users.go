@@ -0,0 +1,139 @@
+package invoiceninja
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// usersInclude is requested with every user so IsAdmin can be answered.
+const usersInclude = "company_user"
+
+// UsersService resolves the user IDs found in fields such as UserID and
+// AssignedUserID. It is read-only.
+type UsersService struct {
+	client *Client
+}
+
+// User is a user of the company's Invoice Ninja account.
+type User struct {
+	ID        string `json:"id,omitempty"`
+	FirstName string `json:"first_name,omitempty"`
+	LastName  string `json:"last_name,omitempty"`
+	Email     string `json:"email,omitempty"`
+	Phone     string `json:"phone,omitempty"`
+
+	// CompanyUser holds the user's role in the company. The service always
+	// requests it.
+	CompanyUser *CompanyUser `json:"company_user,omitempty"`
+
+	IsDeleted  bool  `json:"is_deleted,omitempty"`
+	CreatedAt  int64 `json:"created_at,omitempty"`
+	UpdatedAt  int64 `json:"updated_at,omitempty"`
+	ArchivedAt int64 `json:"archived_at,omitempty"`
+}
+
+// CompanyUser is a user's membership of a company.
+type CompanyUser struct {
+	IsAdmin     bool   `json:"is_admin,omitempty"`
+	IsOwner     bool   `json:"is_owner,omitempty"`
+	Permissions string `json:"permissions,omitempty"`
+}
+
+// FullName returns the user's first and last name, or the email address
+// when both are empty.
+func (u *User) FullName() string {
+	name := strings.TrimSpace(u.FirstName + " " + u.LastName)
+	if name == "" {
+		return u.Email
+	}
+	return name
+}
+
+// IsAdmin reports whether the user is an administrator of the company.
+func (u *User) IsAdmin() bool {
+	return u.CompanyUser != nil && u.CompanyUser.IsAdmin
+}
+
+// UserListOptions specifies the optional parameters for listing users.
+type UserListOptions struct {
+	PerPage   int
+	Page      int
+	Filter    string
+	Status    string
+	IsDeleted *bool
+	Sort      string
+	Filters   []FilterExpr
+}
+
+// toQuery converts options to URL query parameters.
+func (o *UserListOptions) toQuery() url.Values {
+	q := url.Values{}
+	q.Set("include", usersInclude)
+
+	if o == nil {
+		return q
+	}
+
+	if o.PerPage > 0 {
+		q.Set("per_page", strconv.Itoa(o.PerPage))
+	}
+	if o.Page > 0 {
+		q.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.Filter != "" {
+		q.Set("filter", o.Filter)
+	}
+	if o.Status != "" {
+		q.Set("status", o.Status)
+	}
+	if o.IsDeleted != nil {
+		q.Set("is_deleted", strconv.FormatBool(*o.IsDeleted))
+	}
+	if o.Sort != "" {
+		q.Set("sort", o.Sort)
+	}
+
+	applyFilters(q, o.Filters)
+
+	return q
+}
+
+// List retrieves a list of users.
+func (s *UsersService) List(ctx context.Context, opts *UserListOptions) (*ListResponse[User], error) {
+	var resp ListResponse[User]
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/users"), opts.toQuery(), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Iter returns an iterator over all users matching opts, fetching pages lazily.
+// Paging starts at opts.Page (or page 1) and keeps every other option.
+func (s *UsersService) Iter(ctx context.Context, opts *UserListOptions) *Iterator[User] {
+	return newIterator[User](ctx, s.client, s.client.apiPath("/users"), opts.toQuery())
+}
+
+// ListAll retrieves all users matching opts across every page.
+// If a page fails or ctx is done mid-scan, the users fetched so far are
+// returned together with the error.
+func (s *UsersService) ListAll(ctx context.Context, opts *UserListOptions) ([]User, error) {
+	return listAll(s.Iter(ctx, opts))
+}
+
+// Count returns the number of users matching opts without fetching them,
+// from the pagination total of a one-item page. Paging options are ignored.
+func (s *UsersService) Count(ctx context.Context, opts *UserListOptions) (int, error) {
+	return count(ctx, s.client, s.client.apiPath("/users"), opts.toQuery())
+}
+
+// Get retrieves a single user by ID.
+func (s *UsersService) Get(ctx context.Context, id string) (*User, error) {
+	var resp SingleResponse[User]
+	query := url.Values{"include": {usersInclude}}
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/users/%s", id), query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
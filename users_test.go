@@ -0,0 +1,75 @@
+package invoiceninja
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUsersServiceList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/api/v1/users" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.URL.Query().Get("include"); got != "company_user" {
+			t.Errorf("expected include=company_user, got %q", got)
+		}
+		if got := r.URL.Query().Get("per_page"); got != "100" {
+			t.Errorf("expected per_page=100, got %q", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": [
+			{"id": "u1", "first_name": "Jane", "last_name": "Smith", "email": "jane@example.com", "company_user": {"is_admin": true, "is_owner": true}},
+			{"id": "u2", "email": "ops@example.com", "company_user": {"is_admin": false, "permissions": "view_invoice"}}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	resp, err := client.Users.List(context.Background(), &UserListOptions{PerPage: 100})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Data) != 2 {
+		t.Fatalf("expected 2 users, got %d", len(resp.Data))
+	}
+	jane, ops := resp.Data[0], resp.Data[1]
+	if jane.FullName() != "Jane Smith" || !jane.IsAdmin() {
+		t.Errorf("unexpected first user: %q admin=%v", jane.FullName(), jane.IsAdmin())
+	}
+	if ops.FullName() != "ops@example.com" || ops.IsAdmin() {
+		t.Errorf("unexpected second user: %q admin=%v", ops.FullName(), ops.IsAdmin())
+	}
+}
+
+func TestUsersServiceGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/users/u1" {
+			t.Errorf("expected path /api/v1/users/u1, got %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("include"); got != "company_user" {
+			t.Errorf("expected include=company_user, got %q", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "u1", "first_name": "Jane", "last_name": "Smith"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	user, err := client.Users.Get(context.Background(), "u1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.FullName() != "Jane Smith" {
+		t.Errorf("expected Jane Smith, got %q", user.FullName())
+	}
+	if user.IsAdmin() {
+		t.Error("expected a user without company_user not to be an admin")
+	}
+}
@@ -0,0 +1,107 @@
+package invoiceninja
+
+import "fmt"
+
+// Validate checks the invoice for problems the API would reject on create:
+// a missing client, no line items, negative amounts, or an unknown line item
+// type. It returns a *ValidationError listing every problem found, or nil.
+func (i *Invoice) Validate() error {
+	verr := &ValidationError{}
+
+	if i.ClientID == "" {
+		verr.add("client_id", "is required")
+	}
+	if len(i.LineItems) == 0 {
+		verr.add("line_items", "at least one line item is required")
+	}
+	if i.Discount < 0 {
+		verr.add("discount", "must not be negative")
+	}
+	for n, item := range i.LineItems {
+		if item.Quantity < 0 {
+			verr.add(fmt.Sprintf("line_items[%d].quantity", n), "must not be negative")
+		}
+		if item.Cost < 0 {
+			verr.add(fmt.Sprintf("line_items[%d].cost", n), "must not be negative")
+		}
+		if item.Discount < 0 {
+			verr.add(fmt.Sprintf("line_items[%d].discount", n), "must not be negative")
+		}
+		if item.TypeID != "" && !LineItemType(item.TypeID).Valid() {
+			verr.add(fmt.Sprintf("line_items[%d].type_id", n), fmt.Sprintf("%q is not a line item type", item.TypeID))
+		}
+	}
+
+	return verr.errOrNil()
+}
+
+// Validate checks the payment request for problems the API would reject on
+// create: a missing client, negative amounts, applied invoices and credits
+// without an ID, or more applied to invoices than the payment amount (see
+// AllocationWarning). It returns a *ValidationError listing every problem
+// found, or nil.
+func (p *PaymentRequest) Validate() error {
+	verr := &ValidationError{}
+
+	if p.ClientID == "" {
+		verr.add("client_id", "is required")
+	}
+	if p.Amount < 0 {
+		verr.add("amount", "must not be negative")
+	}
+	for n, inv := range p.Invoices {
+		if inv.InvoiceID == "" {
+			verr.add(fmt.Sprintf("invoices[%d].invoice_id", n), "is required")
+		}
+		if inv.Amount < 0 {
+			verr.add(fmt.Sprintf("invoices[%d].amount", n), "must not be negative")
+		}
+	}
+	for n, credit := range p.Credits {
+		if credit.CreditID == "" {
+			verr.add(fmt.Sprintf("credits[%d].credit_id", n), "is required")
+		}
+		if credit.Amount < 0 {
+			verr.add(fmt.Sprintf("credits[%d].amount", n), "must not be negative")
+		}
+	}
+	if applied := p.appliedTotal(); p.Amount > 0 && applied-p.Amount > balanceTolerance {
+		verr.add("amount", fmt.Sprintf("is less than the %.2f applied to invoices", applied))
+	}
+
+	return verr.errOrNil()
+}
+
+// Validate checks the client for problems the API would reject on create:
+// it must have at least one contact, and at least one contact must be
+// primary. It returns a *ValidationError listing every problem found, or nil.
+func (c *INClient) Validate() error {
+	verr := &ValidationError{}
+
+	if len(c.Contacts) == 0 {
+		verr.add("contacts", "at least one contact is required")
+	} else {
+		hasPrimary := false
+		for _, contact := range c.Contacts {
+			if contact.IsPrimary {
+				hasPrimary = true
+				break
+			}
+		}
+		if !hasPrimary {
+			verr.add("contacts", "at least one contact must be primary")
+		}
+	}
+
+	return verr.errOrNil()
+}
+
+// validateAssignee rejects an empty user ID for Assign, which would otherwise
+// be dropped from the update and leave the assignment unchanged.
+func validateAssignee(userID string) error {
+	verr := &ValidationError{}
+	if userID == "" {
+		verr.add("assigned_user_id", "is required")
+	}
+	return verr.errOrNil()
+}
@@ -0,0 +1,170 @@
+package invoiceninja
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// fieldNames returns the field names of a *ValidationError.
+func fieldNames(t *testing.T, err error) []string {
+	t.Helper()
+
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected *ValidationError, got %v", err)
+	}
+
+	names := make([]string, len(verr.Fields))
+	for i, f := range verr.Fields {
+		names[i] = f.Field
+	}
+	return names
+}
+
+func TestInvoiceValidate(t *testing.T) {
+	valid := &Invoice{ClientID: "c1", LineItems: []LineItem{{Quantity: 1, Cost: 10}}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("expected valid invoice, got %v", err)
+	}
+
+	invalid := &Invoice{Discount: -1}
+	got := fieldNames(t, invalid.Validate())
+	if len(got) != 3 || got[0] != "client_id" || got[1] != "line_items" || got[2] != "discount" {
+		t.Errorf("unexpected fields: %v", got)
+	}
+
+	negative := &Invoice{ClientID: "c1", LineItems: []LineItem{{Quantity: 1, Cost: 5}, {Quantity: -2, Cost: -1}}}
+	got = fieldNames(t, negative.Validate())
+	if len(got) != 2 || got[0] != "line_items[1].quantity" || got[1] != "line_items[1].cost" {
+		t.Errorf("unexpected fields: %v", got)
+	}
+
+	typed := &Invoice{ClientID: "c1", LineItems: []LineItem{{Quantity: 1, TypeID: string(LineItemTask)}, {Quantity: 1, TypeID: "task"}}}
+	got = fieldNames(t, typed.Validate())
+	if len(got) != 1 || got[0] != "line_items[1].type_id" {
+		t.Errorf("unexpected fields: %v", got)
+	}
+}
+
+func TestPaymentRequestValidate(t *testing.T) {
+	valid := &PaymentRequest{ClientID: "c1", Amount: 10, Invoices: []PaymentInvoice{{InvoiceID: "inv1", Amount: 10}}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("expected valid payment, got %v", err)
+	}
+
+	invalid := &PaymentRequest{
+		Amount:   -5,
+		Invoices: []PaymentInvoice{{Amount: 5}},
+		Credits:  []PaymentCredit{{CreditID: "cr1", Amount: -1}},
+	}
+	got := fieldNames(t, invalid.Validate())
+	expected := []string{"client_id", "amount", "invoices[0].invoice_id", "credits[0].amount"}
+	if len(got) != len(expected) {
+		t.Fatalf("expected fields %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("field %d: expected %s, got %s", i, expected[i], got[i])
+		}
+	}
+}
+
+func TestINClientValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		client  *INClient
+		wantErr bool
+	}{
+		{"primary contact", &INClient{Contacts: []ClientContact{{Email: "a@b.co"}, {Email: "c@d.co", IsPrimary: true}}}, false},
+		{"no contacts", &INClient{Name: "Acme"}, true},
+		{"no primary contact", &INClient{Contacts: []ClientContact{{Email: "a@b.co"}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.client.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWithClientValidation(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "new"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithClientValidation())
+	ctx := context.Background()
+
+	if _, err := client.Invoices.Create(ctx, &Invoice{}); err == nil {
+		t.Error("expected invoice validation error")
+	}
+	if _, err := client.Payments.Create(ctx, &PaymentRequest{}); err == nil {
+		t.Error("expected payment validation error")
+	}
+	if _, err := client.Clients.Create(ctx, &INClient{Name: "Acme"}); err == nil {
+		t.Error("expected client validation error")
+	}
+
+	if requests != 0 {
+		t.Errorf("expected no requests for invalid entities, got %d", requests)
+	}
+
+	if _, err := client.Invoices.Create(ctx, &Invoice{ClientID: "c1", LineItems: []LineItem{{Quantity: 1}}}); err != nil {
+		t.Errorf("unexpected error for valid invoice: %v", err)
+	}
+
+	if requests != 1 {
+		t.Errorf("expected valid invoice to be sent, got %d requests", requests)
+	}
+}
+
+func TestCreateWithoutClientValidation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "new"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	if _, err := client.Invoices.Create(context.Background(), &Invoice{}); err != nil {
+		t.Errorf("expected validation to be opt-in, got %v", err)
+	}
+}
+
+func TestPaymentRequestValidateOverApplied(t *testing.T) {
+	over := &PaymentRequest{
+		ClientID: "c1",
+		Amount:   100,
+		Invoices: []PaymentInvoice{{InvoiceID: "inv1", Amount: 80}, {InvoiceID: "inv2", Amount: 40}},
+	}
+	got := fieldNames(t, over.Validate())
+	if len(got) != 1 || got[0] != "amount" {
+		t.Errorf("expected amount error, got %v", got)
+	}
+
+	// Credits count towards the invoices, and a zero amount is computed by the server
+	withCredit := &PaymentRequest{
+		ClientID: "c1",
+		Amount:   100,
+		Invoices: []PaymentInvoice{{InvoiceID: "inv1", Amount: 120}},
+		Credits:  []PaymentCredit{{CreditID: "cr1", Amount: 20}},
+	}
+	if err := withCredit.Validate(); err != nil {
+		t.Errorf("expected credit to cover the difference, got %v", err)
+	}
+	computed := &PaymentRequest{ClientID: "c1", Invoices: []PaymentInvoice{{InvoiceID: "inv1", Amount: 120}}}
+	if err := computed.Validate(); err != nil {
+		t.Errorf("expected zero amount to be valid, got %v", err)
+	}
+}
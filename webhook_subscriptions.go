@@ -0,0 +1,209 @@
+package invoiceninja
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Webhook event IDs for WebhookSubscription.EventID.
+const (
+	WebhookEventCreateClient  = "1"
+	WebhookEventCreateInvoice = "2"
+	WebhookEventCreateQuote   = "3"
+	WebhookEventCreatePayment = "4"
+	WebhookEventCreateVendor  = "5"
+	WebhookEventUpdateQuote   = "6"
+	WebhookEventDeleteQuote   = "7"
+	WebhookEventUpdateInvoice = "8"
+	WebhookEventDeleteInvoice = "9"
+	WebhookEventUpdateClient  = "10"
+	WebhookEventDeleteClient  = "11"
+	WebhookEventDeletePayment = "12"
+)
+
+// WebhooksService manages the webhook subscriptions through which Invoice
+// Ninja notifies external endpoints. Incoming notifications are handled by
+// WebhookHandler.
+type WebhooksService struct {
+	client *Client
+}
+
+// WebhookSubscription is a registered webhook target.
+type WebhookSubscription struct {
+	ID        string `json:"id,omitempty"`
+	UserID    string `json:"user_id,omitempty"`
+	EventID   string `json:"event_id,omitempty"`
+	TargetURL string `json:"target_url,omitempty"`
+
+	// Format is the payload format, "JSON" by default.
+	Format string `json:"format,omitempty"`
+
+	// RestMethod is the HTTP method used for the notification, e.g. "post".
+	RestMethod string `json:"rest_method,omitempty"`
+
+	// Headers are sent with every notification, e.g. for authentication.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	IsDeleted  bool  `json:"is_deleted,omitempty"`
+	CreatedAt  int64 `json:"created_at,omitempty"`
+	UpdatedAt  int64 `json:"updated_at,omitempty"`
+	ArchivedAt int64 `json:"archived_at,omitempty"`
+}
+
+// WebhookListOptions specifies the optional parameters for listing webhook subscriptions.
+type WebhookListOptions struct {
+	PerPage   int
+	Page      int
+	Filter    string
+	Status    string
+	IsDeleted *bool
+	Sort      string
+	Filters   []FilterExpr
+}
+
+// toQuery converts options to URL query parameters.
+func (o *WebhookListOptions) toQuery() url.Values {
+	if o == nil {
+		return nil
+	}
+
+	q := url.Values{}
+
+	if o.PerPage > 0 {
+		q.Set("per_page", strconv.Itoa(o.PerPage))
+	}
+	if o.Page > 0 {
+		q.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.Filter != "" {
+		q.Set("filter", o.Filter)
+	}
+	if o.Status != "" {
+		q.Set("status", o.Status)
+	}
+	if o.IsDeleted != nil {
+		q.Set("is_deleted", strconv.FormatBool(*o.IsDeleted))
+	}
+	if o.Sort != "" {
+		q.Set("sort", o.Sort)
+	}
+
+	applyFilters(q, o.Filters)
+
+	return q
+}
+
+// List retrieves a list of webhook subscriptions.
+func (s *WebhooksService) List(ctx context.Context, opts *WebhookListOptions) (*ListResponse[WebhookSubscription], error) {
+	var resp ListResponse[WebhookSubscription]
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/webhooks"), opts.toQuery(), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Iter returns an iterator over all webhook subscriptions matching opts, fetching pages lazily.
+// Paging starts at opts.Page (or page 1) and keeps every other option.
+func (s *WebhooksService) Iter(ctx context.Context, opts *WebhookListOptions) *Iterator[WebhookSubscription] {
+	return newIterator[WebhookSubscription](ctx, s.client, s.client.apiPath("/webhooks"), opts.toQuery())
+}
+
+// ListAll retrieves all webhook subscriptions matching opts across every page.
+// If a page fails or ctx is done mid-scan, the subscriptions fetched so far are
+// returned together with the error.
+func (s *WebhooksService) ListAll(ctx context.Context, opts *WebhookListOptions) ([]WebhookSubscription, error) {
+	return listAll(s.Iter(ctx, opts))
+}
+
+// Count returns the number of webhook subscriptions matching opts without fetching them,
+// from the pagination total of a one-item page. Paging options are ignored.
+func (s *WebhooksService) Count(ctx context.Context, opts *WebhookListOptions) (int, error) {
+	return count(ctx, s.client, s.client.apiPath("/webhooks"), opts.toQuery())
+}
+
+// Get retrieves a single webhook subscription by ID.
+func (s *WebhooksService) Get(ctx context.Context, id string) (*WebhookSubscription, error) {
+	var resp SingleResponse[WebhookSubscription]
+	if err := s.client.doRequest(ctx, "GET", s.client.apiPath("/webhooks/%s", id), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Create registers a new webhook subscription, e.g. when provisioning a
+// deployment that receives notifications through WebhookHandler.
+func (s *WebhooksService) Create(ctx context.Context, webhook *WebhookSubscription, opts ...RequestOption) (*WebhookSubscription, error) {
+	var resp SingleResponse[WebhookSubscription]
+	if err := s.client.doRequest(ctx, "POST", s.client.apiPath("/webhooks"), nil, webhook, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Update updates an existing webhook subscription.
+func (s *WebhooksService) Update(ctx context.Context, id string, webhook *WebhookSubscription, opts ...RequestOption) (*WebhookSubscription, error) {
+	var resp SingleResponse[WebhookSubscription]
+	if err := s.client.doRequest(ctx, "PUT", s.client.apiPath("/webhooks/%s", id), nil, webhook, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Delete deletes a webhook subscription by ID.
+func (s *WebhooksService) Delete(ctx context.Context, id string, opts ...RequestOption) error {
+	return s.client.doRequest(ctx, "DELETE", s.client.apiPath("/webhooks/%s", id), nil, nil, nil, opts...)
+}
+
+// Archive archives a webhook subscription.
+func (s *WebhooksService) Archive(ctx context.Context, id string) (*WebhookSubscription, error) {
+	return s.bulkAction(ctx, "archive", id)
+}
+
+// Restore restores an archived or deleted webhook subscription.
+func (s *WebhooksService) Restore(ctx context.Context, id string) (*WebhookSubscription, error) {
+	return s.bulkAction(ctx, "restore", id)
+}
+
+// Bulk performs a bulk action on multiple webhook subscriptions.
+func (s *WebhooksService) Bulk(ctx context.Context, action string, ids []string, opts ...RequestOption) ([]WebhookSubscription, error) {
+	req := BulkAction{
+		Action: action,
+		IDs:    ids,
+	}
+
+	return bulkRequest[WebhookSubscription](ctx, s.client, s.client.apiPath("/webhooks/bulk"), req, opts...)
+}
+
+// BulkTyped performs a bulk action on multiple webhook subscriptions like
+// Bulk, but first checks that action is one of BulkArchive, BulkRestore and
+// BulkDelete, returning a *ValidationError otherwise.
+func (s *WebhooksService) BulkTyped(ctx context.Context, action BulkActionType, ids []string, opts ...RequestOption) ([]WebhookSubscription, error) {
+	if err := checkBulkAction("webhook subscriptions", action); err != nil {
+		return nil, err
+	}
+	return s.Bulk(ctx, string(action), ids, opts...)
+}
+
+// BulkChunked performs a bulk action on a large number of webhook subscriptions by splitting ids
+// into chunks of chunkSize (DefaultBulkChunkSize when chunkSize <= 0) and sending
+// one Bulk request per chunk. It returns the webhook subscriptions echoed by successful chunks along
+// with a joined error of *BulkChunkError values for any chunks that failed.
+func (s *WebhooksService) BulkChunked(ctx context.Context, action string, ids []string, chunkSize int, opts ...RequestOption) ([]WebhookSubscription, error) {
+	return bulkChunked(ctx, ids, chunkSize, func(ctx context.Context, chunk []string) ([]WebhookSubscription, error) {
+		return s.Bulk(ctx, action, chunk, opts...)
+	})
+}
+
+// bulkAction performs a single-item bulk action.
+func (s *WebhooksService) bulkAction(ctx context.Context, action, id string) (*WebhookSubscription, error) {
+	webhooks, err := s.Bulk(ctx, action, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	if len(webhooks) == 0 {
+		return nil, fmt.Errorf("%s webhook subscription: %w", action, ErrEmptyBulkResponse)
+	}
+	return &webhooks[0], nil
+}
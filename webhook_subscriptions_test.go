@@ -0,0 +1,115 @@
+package invoiceninja
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhooksServiceList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/api/v1/webhooks" {
+			t.Errorf("expected GET /api/v1/webhooks, got %s %s", r.Method, r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": [
+			{"id": "wh1", "event_id": "2", "target_url": "https://example.com/hooks", "format": "JSON", "rest_method": "post", "headers": {"X-Secret": "s3cret"}}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	resp, err := client.Webhooks.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Data) != 1 {
+		t.Fatalf("expected 1 webhook, got %d", len(resp.Data))
+	}
+
+	wh := resp.Data[0]
+	if wh.EventID != WebhookEventCreateInvoice || wh.TargetURL != "https://example.com/hooks" || wh.RestMethod != "post" {
+		t.Errorf("unexpected webhook: %+v", wh)
+	}
+	if wh.Headers["X-Secret"] != "s3cret" {
+		t.Errorf("expected X-Secret header, got %v", wh.Headers)
+	}
+}
+
+func TestWebhooksServiceCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/v1/webhooks" {
+			t.Errorf("expected POST /api/v1/webhooks, got %s %s", r.Method, r.URL.Path)
+		}
+
+		var wh WebhookSubscription
+		json.NewDecoder(r.Body).Decode(&wh)
+		if wh.TargetURL != "https://example.com/hooks" || wh.EventID != "4" || wh.Format != "JSON" || wh.Headers["Authorization"] != "Bearer abc" {
+			t.Errorf("unexpected webhook body: %+v", wh)
+		}
+
+		wh.ID = "wh1"
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SingleResponse[WebhookSubscription]{Data: wh})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+
+	wh, err := client.Webhooks.Create(context.Background(), &WebhookSubscription{
+		TargetURL:  "https://example.com/hooks",
+		EventID:    WebhookEventCreatePayment,
+		Format:     "JSON",
+		RestMethod: "post",
+		Headers:    map[string]string{"Authorization": "Bearer abc"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if wh.ID != "wh1" {
+		t.Errorf("expected ID wh1, got %s", wh.ID)
+	}
+}
+
+func TestWebhooksServiceUpdateAndDelete(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "wh1", "target_url": "https://example.com/v2"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	wh, err := client.Webhooks.Update(ctx, "wh1", &WebhookSubscription{TargetURL: "https://example.com/v2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if wh.TargetURL != "https://example.com/v2" {
+		t.Errorf("unexpected target URL %s", wh.TargetURL)
+	}
+
+	if _, err := client.Webhooks.Get(ctx, "wh1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := client.Webhooks.Delete(ctx, "wh1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"PUT /api/v1/webhooks/wh1", "GET /api/v1/webhooks/wh1", "DELETE /api/v1/webhooks/wh1"}
+	if len(requests) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, requests)
+	}
+	for i := range expected {
+		if requests[i] != expected[i] {
+			t.Errorf("request %d: expected %s, got %s", i, expected[i], requests[i])
+		}
+	}
+}
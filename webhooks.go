@@ -18,6 +18,9 @@ type WebhookEvent struct {
 
 	// Data contains the event payload.
 	Data json.RawMessage `json:"data"`
+
+	// codec decodes Data in the Parse methods; nil means encoding/json.
+	codec Codec
 }
 
 // WebhookHandler handles incoming webhook requests from Invoice Ninja.
@@ -27,6 +30,9 @@ type WebhookHandler struct {
 
 	// handlers maps event types to handler functions.
 	handlers map[string]WebhookEventHandler
+
+	// codec decodes webhook payloads; nil means encoding/json.
+	codec Codec
 }
 
 // WebhookEventHandler is a function that handles a specific webhook event.
@@ -41,6 +47,13 @@ func NewWebhookHandler(secret string) *WebhookHandler {
 	}
 }
 
+// SetCodec sets the codec used to decode webhook payloads, both the event
+// itself and the entities returned by its Parse methods. By default
+// encoding/json is used. See Codec.
+func (h *WebhookHandler) SetCodec(codec Codec) {
+	h.codec = codec
+}
+
 // On registers a handler for a specific event type.
 func (h *WebhookHandler) On(eventType string, handler WebhookEventHandler) {
 	h.handlers[eventType] = handler
@@ -123,8 +136,8 @@ func (h *WebhookHandler) HandleRequest(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	var event WebhookEvent
-	if err := json.Unmarshal(body, &event); err != nil {
+	event := WebhookEvent{codec: h.codec}
+	if err := event.jsonCodec().Unmarshal(body, &event); err != nil {
 		http.Error(w, "Failed to parse webhook payload", http.StatusBadRequest)
 		return
 	}
@@ -161,10 +174,19 @@ func (h *WebhookHandler) verifySignature(payload []byte, signature string) bool
 	return hmac.Equal([]byte(signature), []byte(expectedMAC))
 }
 
+// jsonCodec returns the codec of the handler that received the event, or the
+// encoding/json one.
+func (e *WebhookEvent) jsonCodec() Codec {
+	if e.codec == nil {
+		return stdCodec{}
+	}
+	return e.codec
+}
+
 // ParseInvoice parses the webhook data as an Invoice.
 func (e *WebhookEvent) ParseInvoice() (*Invoice, error) {
 	var invoice Invoice
-	if err := json.Unmarshal(e.Data, &invoice); err != nil {
+	if err := e.jsonCodec().Unmarshal(e.Data, &invoice); err != nil {
 		return nil, fmt.Errorf("failed to parse invoice data: %w", err)
 	}
 	return &invoice, nil
@@ -173,7 +195,7 @@ func (e *WebhookEvent) ParseInvoice() (*Invoice, error) {
 // ParsePayment parses the webhook data as a Payment.
 func (e *WebhookEvent) ParsePayment() (*Payment, error) {
 	var payment Payment
-	if err := json.Unmarshal(e.Data, &payment); err != nil {
+	if err := e.jsonCodec().Unmarshal(e.Data, &payment); err != nil {
 		return nil, fmt.Errorf("failed to parse payment data: %w", err)
 	}
 	return &payment, nil
@@ -182,7 +204,7 @@ func (e *WebhookEvent) ParsePayment() (*Payment, error) {
 // ParseClient parses the webhook data as a Client.
 func (e *WebhookEvent) ParseClient() (*INClient, error) {
 	var client INClient
-	if err := json.Unmarshal(e.Data, &client); err != nil {
+	if err := e.jsonCodec().Unmarshal(e.Data, &client); err != nil {
 		return nil, fmt.Errorf("failed to parse client data: %w", err)
 	}
 	return &client, nil
@@ -191,7 +213,7 @@ func (e *WebhookEvent) ParseClient() (*INClient, error) {
 // ParseCredit parses the webhook data as a Credit.
 func (e *WebhookEvent) ParseCredit() (*Credit, error) {
 	var credit Credit
-	if err := json.Unmarshal(e.Data, &credit); err != nil {
+	if err := e.jsonCodec().Unmarshal(e.Data, &credit); err != nil {
 		return nil, fmt.Errorf("failed to parse credit data: %w", err)
 	}
 	return &credit, nil